@@ -0,0 +1,306 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package diff provides utilities to compute differences between texts.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultContextLines is the default number of unchanged context lines around changes in unified diffs.
+	DefaultContextLines = 3
+
+	// NoNewlineMarker is the marker that follows the last line of a text without trailing newline in unified diffs.
+	NoNewlineMarker = `\ No newline at end of file`
+)
+
+// FileDiff is the unified diff of a single file.
+type FileDiff struct {
+	// Diff is the unified diff including the file headers.
+	Diff string
+
+	// Path is the path to the changed file.
+	Path string
+}
+
+// op is a line edit operation.
+type op struct {
+	// kind is the operation kind, either ' ' for unchanged, '-' for deleted or '+' for inserted lines.
+	kind byte
+	// line is the line text including the line terminator, if any.
+	line string
+}
+
+// SplitFiles splits the output of commands like "gofmt -d", that print unified diffs of multiple files where each diff
+// starts with a "diff" command line, into the diffs of single files.
+func SplitFiles(text string) []FileDiff {
+	var diffs []FileDiff
+	var current *strings.Builder
+	var header string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		d := current.String()
+		diffs = append(diffs, FileDiff{Diff: d, Path: diffPath(header, d)})
+	}
+
+	for _, line := range splitLines(text) {
+		if strings.HasPrefix(line, "diff ") {
+			flush()
+			current, header = &strings.Builder{}, line
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	flush()
+
+	return diffs
+}
+
+// diffPath returns the path to the changed file from the "+++" header of the given unified diff or from the last field
+// of the given "diff" command line.
+func diffPath(header, diff string) string {
+	for _, line := range splitLines(diff) {
+		if strings.HasPrefix(line, "+++ ") {
+			path, _, _ := strings.Cut(strings.TrimPrefix(line, "+++ "), "\t")
+			return path
+		}
+	}
+	fields := strings.Fields(header)
+	return strings.TrimSuffix(fields[len(fields)-1], ".orig")
+}
+
+// Unified returns the differences between the old and new text in the unified diff format with DefaultContextLines
+// lines of context. The given names are used for the file headers.
+// A missing newline at the end of a text is indicated through NoNewlineMarker.
+// An empty string is returned when both texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := lineOps(splitLinesKeepEnds(oldText), splitLinesKeepEnds(newText))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for i := 0; i < len(ops); {
+		// Skip unchanged lines that are not part of any hunk context.
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		start := i - DefaultContextLines
+		if start < 0 {
+			start = 0
+		}
+		for start < i && ops[start].kind != ' ' {
+			start++
+		}
+
+		// Extend the hunk as long as changes are separated by less than two times the context lines.
+		end, unchanged := i, 0
+		for end < len(ops) && unchanged <= 2*DefaultContextLines {
+			if ops[end].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+			end++
+		}
+		end -= unchanged
+		if end+DefaultContextLines < len(ops) {
+			end += DefaultContextLines
+		} else {
+			end = len(ops)
+		}
+
+		oldStart, newStart := lineNumbers(ops[:start])
+		var oldCount, newCount int
+		for _, o := range ops[start:end] {
+			if o.kind != '+' {
+				oldCount++
+			}
+			if o.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+		for _, o := range ops[start:end] {
+			b.WriteByte(o.kind)
+			b.WriteString(o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				b.WriteString("\n" + NoNewlineMarker + "\n")
+			}
+		}
+		i = end
+	}
+
+	return b.String()
+}
+
+// hunkRange formats the range of a hunk header.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// lineNumbers returns the number of old and new lines covered by the given operations.
+func lineNumbers(ops []op) (int, int) {
+	var oldLines, newLines int
+	for _, o := range ops {
+		if o.kind != '+' {
+			oldLines++
+		}
+		if o.kind != '-' {
+			newLines++
+		}
+	}
+	return oldLines, newLines
+}
+
+// lineOps computes the line edit operations to transform a into b based on the shortest edit script.
+// It uses the linear space variant of the Myers difference algorithm that recursively bisects the edit graph at the
+// middle snake of the shortest edit script.
+//
+// See https://doi.org/10.1007/BF01840446 for more details.
+func lineOps(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	return appendLineOps(ops, a, b)
+}
+
+// appendLineOps appends the line edit operations to transform a into b to the given operations.
+func appendLineOps(ops []op, a, b []string) []op {
+	// Common prefixes and suffixes are always part of the shortest edit script.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, op{kind: ' ', line: a[prefix]})
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			ops = append(ops, op{kind: '+', line: line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			ops = append(ops, op{kind: '-', line: line})
+		}
+	default:
+		x, y, u, v := middleSnake(a, b)
+		ops = appendLineOps(ops, a[:x], b[:y])
+		for _, line := range a[x:u] {
+			ops = append(ops, op{kind: ' ', line: line})
+		}
+		ops = appendLineOps(ops, a[u:], b[v:])
+	}
+
+	for _, line := range common {
+		ops = append(ops, op{kind: ' ', line: line})
+	}
+	return ops
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake of the shortest edit script to transform a
+// into b by searching forward from the start and backward from the end of the edit graph simultaneously.
+// Both a and b must not be empty.
+func middleSnake(a, b []string) (int, int, int, int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	// The furthest reaching x of the forward and backward paths indexed by diagonal where the x of backward paths is
+	// counted from the end of a.
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			if kb := delta - k; odd && kb >= -(d-1) && kb <= d-1 && x+backward[offset+kb] >= n {
+				return x0, y0, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			if kf := delta - k; !odd && kf >= -d && kf <= d && x+forward[offset+kf] >= n {
+				return n - x, m - y, n - x0, m - y0
+			}
+		}
+	}
+
+	// Not reachable since the forward and backward paths always overlap at the latest when d reaches maxD.
+	return n, m, n, m
+}
+
+// splitLines splits the text into lines without line terminators.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// splitLinesKeepEnds splits the text into lines including their line terminators.
+// The last line has no line terminator when the text does not end with a newline.
+func splitLinesKeepEnds(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package diff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineOps(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		ops  string
+	}{
+		{name: "equal", a: "abc", b: "abc", ops: "   "},
+		{name: "empty old", a: "", b: "ab", ops: "++"},
+		{name: "empty new", a: "ab", b: "", ops: "--"},
+		{name: "insert middle", a: "ac", b: "abc", ops: " + "},
+		{name: "delete middle", a: "abc", b: "ac", ops: " - "},
+		{name: "replace", a: "abc", b: "axc", ops: " -+ "},
+		{name: "myers paper example", a: "abcabba", b: "cbabac", ops: "-+ -  - +"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ops := lineOps(strings.Split(tc.a, ""), strings.Split(tc.b, ""))
+			kinds := make([]byte, 0, len(ops))
+			for _, o := range ops {
+				kinds = append(kinds, o.kind)
+			}
+			require.Equal(t, tc.ops, string(kinds))
+			requireValidOps(t, strings.Split(tc.a, ""), strings.Split(tc.b, ""), ops)
+		})
+	}
+}
+
+func TestLineOpsShortestEditScript(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		ops := lineOps(a, b)
+		requireValidOps(t, a, b, ops)
+
+		var unchanged int
+		for _, o := range ops {
+			if o.kind == ' ' {
+				unchanged++
+			}
+		}
+		require.Equal(t, lcsLength(a, b), unchanged, "a=%v b=%v", a, b)
+	}
+}
+
+func TestSplitFiles(t *testing.T) {
+	text := `diff -u a.go.orig a.go
+--- a.go.orig
++++ a.go
+@@ -1 +1 @@
+-a
++b
+diff b.go.orig b.go
+@@ -1 +1 @@
+-c
++d
+`
+	files := SplitFiles(text)
+	require.Len(t, files, 2)
+	require.Equal(t, "a.go", files[0].Path)
+	require.Equal(t, "--- a.go.orig\n+++ a.go\n@@ -1 +1 @@\n-a\n+b\n", files[0].Diff)
+	require.Equal(t, "b.go", files[1].Path)
+	require.Equal(t, "@@ -1 +1 @@\n-c\n+d\n", files[1].Diff)
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		diff    string
+	}{
+		{
+			name:    "equal",
+			oldText: "a\nb\n",
+			newText: "a\nb\n",
+		},
+		{
+			name:    "changed line",
+			oldText: "a\nb\nc\n",
+			newText: "a\nx\nc\n",
+			diff:    "--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name:    "created file",
+			oldText: "",
+			newText: "a\n",
+			diff:    "--- a\n+++ b\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			newText: "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			diff: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			name:    "added newline at end of file",
+			oldText: "a\nb",
+			newText: "a\nb\n",
+			diff:    "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n" + NoNewlineMarker + "\n+b\n",
+		},
+		{
+			name:    "removed newline at end of file",
+			oldText: "a\nb\n",
+			newText: "a\nc",
+			diff:    "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n" + NoNewlineMarker + "\n",
+		},
+		{
+			name:    "unchanged last line without newline",
+			oldText: "a\nb",
+			newText: "x\nb",
+			diff:    "--- a\n+++ b\n@@ -1,2 +1,2 @@\n-a\n+x\n b\n" + NoNewlineMarker + "\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.diff, Unified("a", "b", tc.oldText, tc.newText))
+		})
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b.
+func lcsLength(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] >= cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// requireValidOps asserts that the given operations transform a into b.
+func requireValidOps(t *testing.T, a, b []string, ops []op) {
+	t.Helper()
+
+	var oldLines, newLines []string
+	for _, o := range ops {
+		if o.kind != '+' {
+			oldLines = append(oldLines, o.line)
+		}
+		if o.kind != '-' {
+			newLines = append(newLines, o.line)
+		}
+	}
+	require.Equal(t, strings.Join(a, ""), strings.Join(oldLines, ""))
+	require.Equal(t, strings.Join(b, ""), strings.Join(newLines, ""))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package fs provides utilities for file system paths and files.
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// AbsPath returns the given path as absolute path where relative paths are resolved from the given root directory.
+func AbsPath(rootDir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(rootDir, p)
+}
+
+// FileSHA256 returns the hex encoded SHA-256 checksum of the file at the given path.
+// An empty string is returned for directories.
+func FileSHA256(path string) (string, error) {
+	fi, statErr := os.Stat(path)
+	if statErr != nil {
+		return "", statErr
+	}
+	if fi.IsDir() {
+		return "", nil
+	}
+
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return "", fmt.Errorf("open %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("compute checksum of %q: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
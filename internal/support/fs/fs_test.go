@@ -0,0 +1,37 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "project")
+	abs := filepath.Join(t.TempDir(), "out")
+
+	require.Equal(t, filepath.Join(root, "out", "app"), AbsPath(root, filepath.Join("out", "app")))
+	require.Equal(t, abs, AbsPath(root, abs))
+}
+
+func TestFileSHA256(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app")
+	require.NoError(t, os.WriteFile(file, []byte("wand"), 0o600))
+
+	sum, err := FileSHA256(file)
+	require.NoError(t, err)
+	require.Equal(t, "04224b1d2fb402314a8dfc9d03e00937aae352fd20d3070181209a66c5127fb2", sum)
+
+	sum, err = FileSHA256(dir)
+	require.NoError(t, err)
+	require.Empty(t, sum)
+
+	_, err = FileSHA256(filepath.Join(dir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -11,7 +11,7 @@ import (
 
 // EnvMapToSlice transforms a map of environment variable key/value pairs into a slice separated by an equal sign.
 func EnvMapToSlice(src map[string]string) []string {
-	dst := make([]string, len(src))
+	dst := make([]string, 0, len(src))
 	for k, v := range src {
 		dst = append(dst, fmt.Sprintf("%s=%s", k, v))
 	}
@@ -22,12 +22,8 @@ func EnvMapToSlice(src map[string]string) []string {
 func EnvSliceToMap(src []string) map[string]string {
 	dst := make(map[string]string, len(src))
 	for _, envVar := range src {
-		kv := strings.Split(envVar, "=")
-		if len(kv) == 1 {
-			dst[kv[0]] = ""
-		} else {
-			dst[kv[0]] = kv[1]
-		}
+		k, v, _ := strings.Cut(envVar, "=")
+		dst[k] = v
 	}
 	return dst
 }
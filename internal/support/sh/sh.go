@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package sh provides utilities to run commands in a specific working directory.
+// It mirrors the behavior of the [github.com/magefile/mage/sh] package which always runs commands in the working
+// directory of the current process.
+package sh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+// Exec runs the command with the given environment in the given working directory.
+// When the directory is empty the command runs in the working directory of the current process.
+// Environment variables in the command and arguments are expanded like [sh.Exec] does.
+// It returns an error with the exit code of the command when it ran but failed.
+func Exec(env map[string]string, dir string, stdout, stderr io.Writer, cmd string, args ...string) error {
+	expand := func(s string) string {
+		if v, ok := env[s]; ok {
+			return v
+		}
+		return os.Getenv(s)
+	}
+	cmd = os.Expand(cmd, expand)
+	for i := range args {
+		args[i] = os.Expand(args[i], expand)
+	}
+
+	c := exec.Command(cmd, args...)
+	c.Dir = dir
+	c.Env = os.Environ()
+	for k, v := range env {
+		c.Env = append(c.Env, k+"="+v)
+	}
+	c.Stderr = stderr
+	c.Stdout = stdout
+	c.Stdin = os.Stdin
+	log.Println("exec:", cmd, strings.Join(args, " "))
+
+	err := c.Run()
+	if err == nil {
+		return nil
+	}
+	if sh.CmdRan(err) {
+		code := sh.ExitStatus(err)
+		return mg.Fatalf(code, `running "%s %s" failed with exit code %d`, cmd, strings.Join(args, " "), code)
+	}
+	return fmt.Errorf(`failed to run "%s %s": %w`, cmd, strings.Join(args, " "), err)
+}
+
+// OutputWith runs the command with the given environment in the given working directory and returns its output.
+// The output is also returned when the command failed to allow callers to process machine-readable reports of commands
+// that use a non-zero exit code to indicate findings.
+func OutputWith(env map[string]string, dir, cmd string, args ...string) (string, error) {
+	buf := &bytes.Buffer{}
+	err := Exec(env, dir, buf, os.Stderr, cmd, args...)
+	return strings.TrimSuffix(buf.String(), "\n"), err
+}
+
+// RunWith runs the command with the given environment in the given working directory.
+// The command output is only printed when Mage runs in verbose mode.
+func RunWith(env map[string]string, dir, cmd string, args ...string) error {
+	var output io.Writer
+	if mg.Verbose() {
+		output = os.Stdout
+	}
+	return Exec(env, dir, output, os.Stderr, cmd, args...)
+}
+
+// RunWithV runs the command with the given environment in the given working directory and always prints its output.
+func RunWithV(env map[string]string, dir, cmd string, args ...string) error {
+	return Exec(env, dir, os.Stdout, os.Stderr, cmd, args...)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package artifact provides a registry of the artifacts tasks produced, like binaries, archives, reports and profiles.
+// The registry is persisted as JSON file so that downstream steps, like packaging or cleaning, can look up artifacts by
+// application and kind instead of recomputing their paths.
+package artifact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	fsSupport "github.com/svengreb/wand/internal/support/fs"
+)
+
+// DefaultRegistryFileName is the default file name for the artifact registry.
+const DefaultRegistryFileName = "artifacts.json"
+
+// Artifact is an output produced by a task.
+type Artifact struct {
+	// App is the name of the application the artifact belongs to.
+	App string `json:"app"`
+
+	// Created is the date and time the artifact has been recorded.
+	Created time.Time `json:"created"`
+
+	// Kind is the artifact kind.
+	Kind Kind `json:"kind"`
+
+	// Path is the path to the artifact relative to the project root directory.
+	Path string `json:"path"`
+
+	// Platform is the target platform in the "os/arch" format, if any.
+	Platform string `json:"platform,omitempty"`
+
+	// SHA256 is the hex encoded SHA-256 checksum of the artifact. It is empty for directories.
+	SHA256 string `json:"sha256,omitempty"`
+
+	// Task is the name of the task that produced the artifact.
+	Task string `json:"task"`
+}
+
+// Registry is a registry of artifacts that is persisted as JSON file.
+// It is safe for concurrent use.
+type Registry struct {
+	artifacts []Artifact
+	file      string
+	mu        sync.Mutex
+	rootDir   string
+}
+
+// Query returns all artifacts of the application with the given name, or of all applications when the name is empty,
+// optionally limited to the given kinds. The artifacts are sorted by their path.
+func (r *Registry) Query(appName string, kinds ...Kind) []Artifact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var artifacts []Artifact
+	for _, a := range r.artifacts {
+		if appName != "" && a.App != appName {
+			continue
+		}
+		if len(kinds) > 0 && !containsKind(kinds, a.Kind) {
+			continue
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts
+}
+
+// Record records the given artifacts and persists the registry.
+// Artifacts with the same path as an already recorded artifact replace it. The checksum of regular files and the
+// creation time are set when they are not set yet. Paths can be absolute or relative to the project root directory and
+// are stored relative to it.
+func (r *Registry) Record(artifacts ...Artifact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byPath := make(map[string]int, len(r.artifacts))
+	for i, a := range r.artifacts {
+		byPath[a.Path] = i
+	}
+
+	now := time.Now()
+	for _, a := range artifacts {
+		abs := a.Path
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(r.rootDir, abs)
+		}
+		rel, relErr := filepath.Rel(r.rootDir, abs)
+		if relErr != nil {
+			return fmt.Errorf("resolve path of artifact %q relative to %q: %w", a.Path, r.rootDir, relErr)
+		}
+		a.Path = rel
+
+		if a.SHA256 == "" {
+			sum, err := fsSupport.FileSHA256(abs)
+			if err != nil {
+				return fmt.Errorf("compute checksum of artifact %q: %w", a.Path, err)
+			}
+			a.SHA256 = sum
+		}
+		if a.Created.IsZero() {
+			a.Created = now
+		}
+
+		if i, ok := byPath[a.Path]; ok {
+			r.artifacts[i] = a
+			continue
+		}
+		byPath[a.Path] = len(r.artifacts)
+		r.artifacts = append(r.artifacts, a)
+	}
+	return r.save()
+}
+
+// Remove removes the artifacts with the given paths, relative to the project root directory, and persists the
+// registry.
+// Note that only the records are removed while the artifacts itself are left untouched.
+func (r *Registry) Remove(paths ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remove := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		remove[filepath.Clean(p)] = true
+	}
+	artifacts := r.artifacts[:0]
+	for _, a := range r.artifacts {
+		if !remove[a.Path] {
+			artifacts = append(artifacts, a)
+		}
+	}
+	r.artifacts = artifacts
+	return r.save()
+}
+
+// save stores the registry as JSON file and creates all parent directories.
+func (r *Registry) save() error {
+	sort.Slice(r.artifacts, func(i, j int) bool { return r.artifacts[i].Path < r.artifacts[j].Path })
+
+	if err := os.MkdirAll(filepath.Dir(r.file), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(r.file), err)
+	}
+	data, marshalErr := json.MarshalIndent(r.artifacts, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("encode artifact registry: %w", marshalErr)
+	}
+	if err := os.WriteFile(r.file, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write artifact registry %q: %w", r.file, err)
+	}
+	return nil
+}
+
+// Load loads the registry from the JSON file at the given path where the paths of artifacts are relative to the given
+// project root directory.
+// An empty registry is returned when the file does not exist.
+func Load(file, rootDir string) (*Registry, error) {
+	r := &Registry{file: file, rootDir: rootDir}
+
+	data, readErr := os.ReadFile(file)
+	if readErr != nil {
+		if errors.Is(readErr, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("read artifact registry %q: %w", file, readErr)
+	}
+	if err := json.Unmarshal(data, &r.artifacts); err != nil {
+		return nil, fmt.Errorf("decode artifact registry %q: %w", file, err)
+	}
+	return r, nil
+}
+
+// containsKind checks whether the given kinds contain the given kind.
+func containsKind(kinds []Kind, k Kind) bool {
+	for _, kind := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
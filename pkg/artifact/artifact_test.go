@@ -0,0 +1,172 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sha256Wand is the hex encoded SHA-256 checksum of the "wand" string.
+const sha256Wand = "04224b1d2fb402314a8dfc9d03e00937aae352fd20d3070181209a66c5127fb2"
+
+// newTestRegistry creates a registry in a temporary project root directory with a binary and a report file.
+func newTestRegistry(t *testing.T) (*Registry, string) {
+	t.Helper()
+
+	rootDir := t.TempDir()
+	for _, p := range []string{"out/app", "out/coverage.out"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, filepath.Dir(p)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, p), []byte("wand"), 0o600))
+	}
+	r, err := Load(filepath.Join(rootDir, "out", DefaultRegistryFileName), rootDir)
+	require.NoError(t, err)
+	return r, rootDir
+}
+
+func TestRegistryRecordPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		path func(rootDir string) string
+		want string
+	}{
+		{name: "relative", path: func(string) string { return "out/app" }, want: filepath.Join("out", "app")},
+		{
+			name: "relative not clean",
+			path: func(string) string { return "./out/../out/app" },
+			want: filepath.Join("out", "app"),
+		},
+		{
+			name: "absolute",
+			path: func(rootDir string) string { return filepath.Join(rootDir, "out", "app") },
+			want: filepath.Join("out", "app"),
+		},
+		{name: "directory", path: func(string) string { return "out" }, want: "out"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, rootDir := newTestRegistry(t)
+			require.NoError(t, r.Record(Artifact{App: "app", Kind: KindBinary, Path: tc.path(rootDir), Task: "go/build"}))
+
+			artifacts := r.Query("")
+			require.Len(t, artifacts, 1)
+			require.Equal(t, tc.want, artifacts[0].Path)
+			require.False(t, artifacts[0].Created.IsZero())
+			if tc.want == "out" {
+				require.Empty(t, artifacts[0].SHA256)
+			} else {
+				require.Equal(t, sha256Wand, artifacts[0].SHA256)
+			}
+		})
+	}
+}
+
+func TestRegistryRecordReplacesSamePath(t *testing.T) {
+	r, rootDir := newTestRegistry(t)
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, r.Record(Artifact{App: "app", Kind: KindBinary, Path: "out/app", Task: "go/build"}))
+	require.NoError(t, r.Record(Artifact{
+		App:      "app",
+		Created:  created,
+		Kind:     KindBinary,
+		Path:     filepath.Join(rootDir, "out", "app"),
+		Platform: "linux/amd64",
+		SHA256:   "abc123",
+		Task:     "gox",
+	}))
+
+	require.Equal(t, []Artifact{{
+		App:      "app",
+		Created:  created,
+		Kind:     KindBinary,
+		Path:     filepath.Join("out", "app"),
+		Platform: "linux/amd64",
+		SHA256:   "abc123",
+		Task:     "gox",
+	}}, r.Query("app"))
+}
+
+func TestRegistryRecordMissingFile(t *testing.T) {
+	r, _ := newTestRegistry(t)
+	require.ErrorIs(t, r.Record(Artifact{App: "app", Kind: KindBinary, Path: "out/missing"}), os.ErrNotExist)
+	require.Empty(t, r.Query(""))
+}
+
+func TestRegistryQuery(t *testing.T) {
+	r, _ := newTestRegistry(t)
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	binary := Artifact{App: "app", Created: created, Kind: KindBinary, Path: "out/app", SHA256: "a"}
+	report := Artifact{App: "app", Created: created, Kind: KindReport, Path: "out/coverage.out", SHA256: "b"}
+	other := Artifact{App: "cli", Created: created, Kind: KindBinary, Path: "out/cli", SHA256: "c"}
+	require.NoError(t, r.Record(report, other, binary))
+
+	tests := []struct {
+		name    string
+		appName string
+		kinds   []Kind
+		want    []Artifact
+	}{
+		{name: "all applications", want: []Artifact{binary, other, report}},
+		{name: "single application", appName: "app", want: []Artifact{binary, report}},
+		{name: "single kind", appName: "app", kinds: []Kind{KindReport}, want: []Artifact{report}},
+		{name: "kind of all applications", kinds: []Kind{KindBinary}, want: []Artifact{binary, other}},
+		{name: "multiple kinds", kinds: []Kind{KindBinary, KindReport}, want: []Artifact{binary, other, report}},
+		{name: "unknown application", appName: "unknown"},
+		{name: "kind without artifacts", kinds: []Kind{KindProfile}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, r.Query(tc.appName, tc.kinds...))
+		})
+	}
+}
+
+func TestRegistryRemove(t *testing.T) {
+	r, _ := newTestRegistry(t)
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	binary := Artifact{App: "app", Created: created, Kind: KindBinary, Path: "out/app", SHA256: "a"}
+	report := Artifact{App: "app", Created: created, Kind: KindReport, Path: "out/coverage.out", SHA256: "b"}
+	require.NoError(t, r.Record(binary, report))
+
+	require.NoError(t, r.Remove("./out/../out/app", "out/unknown"))
+	require.Equal(t, []Artifact{report}, r.Query(""))
+
+	loaded, err := Load(r.file, r.rootDir)
+	require.NoError(t, err)
+	require.Equal(t, []Artifact{report}, loaded.Query(""))
+}
+
+func TestRegistrySaveLoad(t *testing.T) {
+	r, rootDir := newTestRegistry(t)
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	artifacts := []Artifact{
+		{App: "app", Created: created, Kind: KindBinary, Path: "out/app", Platform: "linux/amd64", SHA256: "a", Task: "gox"},
+		{App: "app", Created: created, Kind: KindReport, Path: "out/coverage.out", SHA256: "b", Task: "go/test"},
+	}
+	require.NoError(t, r.Record(artifacts[1], artifacts[0]))
+
+	loaded, err := Load(filepath.Join(rootDir, "out", DefaultRegistryFileName), rootDir)
+	require.NoError(t, err)
+	require.Equal(t, artifacts, loaded.Query(""))
+}
+
+func TestLoad(t *testing.T) {
+	rootDir := t.TempDir()
+
+	r, err := Load(filepath.Join(rootDir, "missing", DefaultRegistryFileName), rootDir)
+	require.NoError(t, err)
+	require.Empty(t, r.Query(""))
+
+	file := filepath.Join(rootDir, DefaultRegistryFileName)
+	require.NoError(t, os.WriteFile(file, []byte(`[{"kind":"unknown"}]`), 0o600))
+	_, err = Load(file, rootDir)
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package artifact
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// KindNameArchive is the Kind name for archives like distribution bundles or image layouts.
+	KindNameArchive = "archive"
+	// KindNameBinary is the Kind name for compiled executables and libraries.
+	KindNameBinary = "binary"
+	// KindNameHeader is the Kind name for C header files generated along with C archives or shared libraries.
+	KindNameHeader = "header"
+	// KindNameProfile is the Kind name for runtime profiles like CPU or memory profiles.
+	KindNameProfile = "profile"
+	// KindNameReport is the Kind name for reports like test coverage profiles or software bills of materials.
+	KindNameReport = "report"
+	// KindNameUnknown is the name for a unknown Kind.
+	KindNameUnknown = "unknown"
+)
+
+const (
+	// KindBinary is the Kind for compiled executables and libraries.
+	KindBinary Kind = iota
+	// KindArchive is the Kind for archives like distribution bundles or image layouts.
+	KindArchive
+	// KindReport is the Kind for reports like test coverage profiles or software bills of materials.
+	KindReport
+	// KindProfile is the Kind for runtime profiles like CPU or memory profiles.
+	KindProfile
+	// KindHeader is the Kind for C header files generated along with C archives or shared libraries.
+	KindHeader
+)
+
+// Kind defines the kind of an artifact.
+type Kind uint32
+
+// MarshalText returns the textual representation of itself.
+func (k Kind) MarshalText() ([]byte, error) {
+	switch k {
+	case KindBinary:
+		return []byte(KindNameBinary), nil
+	case KindArchive:
+		return []byte(KindNameArchive), nil
+	case KindReport:
+		return []byte(KindNameReport), nil
+	case KindProfile:
+		return []byte(KindNameProfile), nil
+	case KindHeader:
+		return []byte(KindNameHeader), nil
+	}
+
+	return nil, fmt.Errorf("not a valid kind %d", k)
+}
+
+func (k Kind) String() string {
+	if b, err := k.MarshalText(); err == nil {
+		return string(b)
+	}
+	return KindNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (k *Kind) UnmarshalText(text []byte) error {
+	parsed, err := ParseKind(string(text))
+	if err != nil {
+		return err
+	}
+
+	*k = parsed
+	return nil
+}
+
+// ParseKind takes a kind name and returns the Kind constant.
+func ParseKind(name string) (Kind, error) {
+	switch strings.ToLower(name) {
+	case KindNameBinary:
+		return KindBinary, nil
+	case KindNameArchive:
+		return KindArchive, nil
+	case KindNameReport:
+		return KindReport, nil
+	case KindNameProfile:
+		return KindProfile, nil
+	case KindNameHeader:
+		return KindHeader, nil
+	}
+
+	var k Kind
+	return k, fmt.Errorf("not a valid kind: %q", name)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+)
+
+// goTestMainSuffix is the import path suffix of the generated main package of a test binary.
+const goTestMainSuffix = ".test"
+
+// goWorkFileNames are the names of the files of a Go workspace.
+var goWorkFileNames = []string{"go.work", "go.work.sum"}
+
+// affectedGoPackages returns the import paths of the packages matching the given patterns that are affected by the
+// given change set. A package is affected when any of its files, including embedded files and files in its "testdata"
+// directory, changed or when it depends on a package with changed files, either directly, transitively or through the
+// transitive imports of its tests.
+// When any Go module file of the main modules or any Go workspace file in the project root directory changed all
+// packages are affected which is indicated by the returned boolean and the given patterns are returned as they are.
+func (e *Elder) affectedGoPackages(
+	env map[string]string, tags []string, cs *ChangeSet, patterns ...string,
+) ([]string, bool, error) {
+	listOpts := []taskGoList.Option{taskGoList.WithDeps(true), taskGoList.WithTest(true)}
+	if len(tags) > 0 {
+		listOpts = append(listOpts, taskGoList.WithFlags(fmt.Sprintf("-tags=%s", strings.Join(tags, ","))))
+	}
+	pkgs, listErr := e.goListPackages(env, "", patterns, listOpts...)
+	if listErr != nil {
+		return nil, false, listErr
+	}
+
+	affected, all := affectedGoPackagesOf(pkgs, e.project.Options().RootDirPathAbs, cs.Files, patterns...)
+	return affected, all, nil
+}
+
+// affectedGoPackagesOf returns the import paths of the given packages that are affected by the given changed files
+// which are relative to the given project root directory.
+// When any Go module or workspace file changed the given patterns are returned as they are along with true.
+func affectedGoPackagesOf(
+	pkgs []taskGoList.Package, rootDir string, files []string, patterns ...string,
+) ([]string, bool) {
+	moduleFiles := goModuleFiles(pkgs, rootDir)
+	for _, f := range files {
+		if moduleFiles[filepath.ToSlash(filepath.Clean(f))] {
+			return patterns, true
+		}
+	}
+
+	changed := changedGoPackages(pkgs, rootDir, files)
+	if len(changed) == 0 {
+		return nil, false
+	}
+	return dependentGoPackages(pkgs, changed), false
+}
+
+// changedGoPackages returns the import paths of the given packages with changed files.
+// The given files are relative to the given project root directory. A file belongs to a package when it is in the
+// directory of the package, in the "testdata" directory of the package or embedded by the package.
+func changedGoPackages(pkgs []taskGoList.Package, rootDir string, files []string) map[string]bool {
+	byDir := make(map[string]string)
+	embedded := make(map[string]string)
+	for _, p := range pkgs {
+		if p.Standard || p.Dir == "" || isGoTestPackage(p) {
+			continue
+		}
+		byDir[p.Dir] = p.ImportPath
+		for _, f := range append(append(append([]string{}, p.EmbedFiles...), p.TestEmbedFiles...), p.XTestEmbedFiles...) {
+			embedded[filepath.Join(p.Dir, f)] = p.ImportPath
+		}
+	}
+
+	changed := make(map[string]bool)
+	for _, f := range files {
+		abs := filepath.Join(rootDir, f)
+		if importPath, ok := embedded[abs]; ok {
+			changed[importPath] = true
+		}
+		if importPath, ok := byDir[filepath.Dir(abs)]; ok {
+			changed[importPath] = true
+		}
+		// Files in "testdata" directories are only used by the tests of the package of the parent directory.
+		elems := strings.Split(filepath.ToSlash(f), "/")
+		for i, elem := range elems {
+			if elem != "testdata" {
+				continue
+			}
+			dir := filepath.Join(rootDir, filepath.FromSlash(strings.Join(elems[:i], "/")))
+			if importPath, ok := byDir[dir]; ok {
+				changed[importPath] = true
+			}
+			break
+		}
+	}
+	return changed
+}
+
+// dependentGoPackages returns the import paths of the given packages, that are not only listed as dependency, which
+// are changed or depend on any of the given changed packages. The dependencies of the test variants and the test main
+// package of a package, listed with the "-test" flag, are accounted to the package itself.
+func dependentGoPackages(pkgs []taskGoList.Package, changed map[string]bool) []string {
+	var importPaths []string
+	deps := make(map[string][]string)
+	for _, p := range pkgs {
+		if p.DepOnly || p.Standard {
+			continue
+		}
+		importPath := p.ImportPath
+		switch {
+		case p.ForTest != "":
+			importPath = p.ForTest
+		case isGoTestPackage(p):
+			importPath = strings.TrimSuffix(p.ImportPath, goTestMainSuffix)
+		default:
+			importPaths = append(importPaths, importPath)
+		}
+		deps[importPath] = append(deps[importPath], p.Deps...)
+	}
+
+	var affected []string
+	for _, importPath := range importPaths {
+		isAffected := changed[importPath]
+		for _, dep := range deps[importPath] {
+			if isAffected {
+				break
+			}
+			// Test variants of dependencies are suffixed with the test binary they are compiled for, e.g.
+			// "example.com/pkg [example.com/other.test]".
+			depPath, _, _ := strings.Cut(dep, " [")
+			isAffected = changed[depPath]
+		}
+		if isAffected {
+			affected = append(affected, importPath)
+		}
+	}
+	return affected
+}
+
+// goModuleFiles returns the paths, relative to the given project root directory and with forward slashes, of the Go
+// module files of the main modules of the given packages and the Go workspace files in the project root directory.
+func goModuleFiles(pkgs []taskGoList.Package, rootDir string) map[string]bool {
+	files := make(map[string]bool)
+	for _, name := range goWorkFileNames {
+		files[name] = true
+	}
+	for _, p := range pkgs {
+		if p.Module == nil || !p.Module.Main || p.Module.GoMod == "" {
+			continue
+		}
+		for _, name := range goModFileNames {
+			rel, err := filepath.Rel(rootDir, filepath.Join(filepath.Dir(p.Module.GoMod), name))
+			if err == nil {
+				files[filepath.ToSlash(rel)] = true
+			}
+		}
+	}
+	return files
+}
+
+// isGoTestPackage indicates whether the given package is a test variant or the generated main package of a test
+// binary that are listed with the "-test" flag.
+func isGoTestPackage(p taskGoList.Package) bool {
+	return p.ForTest != "" || (p.Name == "main" && strings.HasSuffix(p.ImportPath, goTestMainSuffix))
+}
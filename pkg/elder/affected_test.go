@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vcsGit "github.com/svengreb/wand/pkg/project/vcs/git"
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+)
+
+func TestDependentGoPackages(t *testing.T) {
+	// The packages as listed by "go list -deps -test" where "example.com/b" is only imported by the external tests of
+	// "example.com/a" and "example.com/c" depends on "example.com/b" directly.
+	pkgs := []taskGoList.Package{
+		{ImportPath: "fmt", Standard: true},
+		{ImportPath: "example.com/dep", DepOnly: true},
+		{ImportPath: "example.com/a", Deps: []string{"fmt"}},
+		{ImportPath: "example.com/b", Deps: []string{"example.com/dep"}},
+		{ImportPath: "example.com/c", Deps: []string{"example.com/b", "example.com/dep"}},
+		{ImportPath: "example.com/a [example.com/a.test]", ForTest: "example.com/a", Deps: []string{"fmt"}},
+		{
+			ImportPath: "example.com/a_test [example.com/a.test]",
+			ForTest:    "example.com/a",
+			Deps:       []string{"example.com/a [example.com/a.test]", "example.com/b", "example.com/dep"},
+		},
+		{
+			ImportPath: "example.com/a.test",
+			Name:       "main",
+			Deps:       []string{"example.com/a [example.com/a.test]", "example.com/a_test [example.com/a.test]"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		changed  map[string]bool
+		affected []string
+	}{
+		{name: "leaf package", changed: map[string]bool{"example.com/c": true}, affected: []string{"example.com/c"}},
+		{
+			name:     "imported by tests only",
+			changed:  map[string]bool{"example.com/b": true},
+			affected: []string{"example.com/a", "example.com/b", "example.com/c"},
+		},
+		{
+			name:     "transitive dependency",
+			changed:  map[string]bool{"example.com/dep": true},
+			affected: []string{"example.com/a", "example.com/b", "example.com/c"},
+		},
+		{name: "no changes", changed: map[string]bool{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.affected, dependentGoPackages(pkgs, tc.changed))
+		})
+	}
+}
+
+func TestChangedGoPackages(t *testing.T) {
+	root := filepath.FromSlash("/project")
+	pkgs := []taskGoList.Package{
+		{ImportPath: "example.com/a", Dir: filepath.Join(root, "a"), EmbedFiles: []string{"assets/logo.svg"}},
+		{ImportPath: "example.com/a [example.com/a.test]", Dir: filepath.Join(root, "a"), ForTest: "example.com/a"},
+		{ImportPath: "example.com/a.test", Dir: filepath.Join(root, "a"), Name: "main"},
+		{ImportPath: "example.com/b", Dir: filepath.Join(root, "b")},
+	}
+
+	tests := []struct {
+		name    string
+		files   []string
+		changed map[string]bool
+	}{
+		{name: "source file", files: []string{"a/a.go"}, changed: map[string]bool{"example.com/a": true}},
+		{name: "embedded file", files: []string{"a/assets/logo.svg"}, changed: map[string]bool{"example.com/a": true}},
+		{name: "test data", files: []string{"b/testdata/in/golden.txt"}, changed: map[string]bool{"example.com/b": true}},
+		{name: "unrelated file", files: []string{"docs/README.md"}, changed: map[string]bool{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.changed, changedGoPackages(pkgs, root, tc.files))
+		})
+	}
+}
+
+func TestGoModuleFiles(t *testing.T) {
+	root := filepath.FromSlash("/project")
+	pkgs := []taskGoList.Package{
+		{ImportPath: "example.com/a", Module: &taskGoList.Module{Main: true, GoMod: filepath.Join(root, "go.mod")}},
+		{
+			ImportPath: "example.com/tools",
+			Module:     &taskGoList.Module{Main: true, GoMod: filepath.Join(root, "tools", "go.mod")},
+		},
+		{ImportPath: "example.com/dep", DepOnly: true, Module: &taskGoList.Module{GoMod: "/cache/dep/go.mod"}},
+	}
+
+	files := goModuleFiles(pkgs, root)
+	for _, f := range []string{"go.mod", "go.sum", "go.work", "go.work.sum", "tools/go.mod", "tools/go.sum"} {
+		require.True(t, files[f], f)
+	}
+	for _, f := range []string{"a/testdata/go.mod", "testdata/mod/go.sum", "vendor/go.mod"} {
+		require.False(t, files[f], f)
+	}
+}
+
+func TestAffectedGoPackagesProjectInSubdirectory(t *testing.T) {
+	root := newGitTestRepo(t, map[string]string{
+		"go.mod":           "module example.com/top\n",
+		"top.go":           "package top\n",
+		"svc/go.mod":       "module example.com/svc\n",
+		"svc/a/a.go":       "package a\n",
+		"svc/b/b.go":       "package b\n",
+		"svc/a/testdata/x": "x\n",
+	})
+	projectRootDir := filepath.Join(root, "svc")
+	pkgs := []taskGoList.Package{
+		{
+			ImportPath: "example.com/svc/a",
+			Dir:        filepath.Join(projectRootDir, "a"),
+			Module:     &taskGoList.Module{Main: true, GoMod: filepath.Join(projectRootDir, "go.mod")},
+		},
+		{
+			ImportPath: "example.com/svc/b",
+			Dir:        filepath.Join(projectRootDir, "b"),
+			Deps:       []string{"example.com/svc/a"},
+			Module:     &taskGoList.Module{Main: true, GoMod: filepath.Join(projectRootDir, "go.mod")},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		changed  []string
+		affected []string
+		all      bool
+	}{
+		{name: "package", changed: []string{"svc/a/a.go"}, affected: []string{"example.com/svc/a", "example.com/svc/b"}},
+		{
+			name:     "test data",
+			changed:  []string{"svc/a/testdata/x"},
+			affected: []string{"example.com/svc/a", "example.com/svc/b"},
+		},
+		{name: "module file", changed: []string{"svc/go.mod"}, affected: []string{"./..."}, all: true},
+		{name: "outside of project", changed: []string{"go.mod", "top.go"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, f := range tc.changed {
+				writeTestFile(t, filepath.Join(root, f), "// changed\n")
+			}
+			t.Cleanup(func() { runGit(t, root, "checkout", "--quiet", "--", ".") })
+
+			cs, err := changeSet(vcsGit.New(vcsGit.WithPath(projectRootDir)), projectRootDir, NewChangeOptions())
+			require.NoError(t, err)
+			affected, all := affectedGoPackagesOf(pkgs, projectRootDir, cs.Files, "./...")
+			require.Equal(t, tc.all, all)
+			require.Equal(t, tc.affected, affected)
+		})
+	}
+}
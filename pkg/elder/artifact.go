@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/svengreb/wand/pkg/artifact"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+)
+
+// artifactRegistry returns the artifact registry that is loaded from the wand data directory on first use.
+func (e *Elder) artifactRegistry() (*artifact.Registry, error) {
+	if e.artifacts != nil {
+		return e.artifacts, nil
+	}
+
+	r, err := artifact.Load(
+		filepath.Join(e.project.Options().WandDataDir, artifact.DefaultRegistryFileName),
+		e.project.Options().RootDirPathAbs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.artifacts = r
+	return r, nil
+}
+
+// recordArtifacts records the given artifacts of the given task in the artifact registry.
+// Artifacts are only recorded after the task ran successfully, but paths that do not exist are skipped anyway, e.g.
+// optional outputs the tool did not produce.
+// Failing to record artifacts does not fail the task that produced them, so only a warning is logged.
+func (e *Elder) recordArtifacts(taskName string, artifacts ...artifact.Artifact) {
+	var existing []artifact.Artifact
+	for _, a := range artifacts {
+		p := a.Path
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(e.project.Options().RootDirPathAbs, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+		a.Task = taskName
+		existing = append(existing, a)
+	}
+	if len(existing) == 0 {
+		return
+	}
+
+	r, loadErr := e.artifactRegistry()
+	if loadErr != nil {
+		e.Warnf("Failed to load artifact registry: %v", loadErr)
+		return
+	}
+	if err := r.Record(existing...); err != nil {
+		e.Warnf("Failed to record artifacts of task %q: %v", taskName, err)
+	}
+}
+
+// recordTestArtifacts records the coverage and runtime profiles the tests of the given application produced with the
+// given options.
+func (e *Elder) recordTestArtifacts(appName, taskName string, tOpts *taskGoTest.Options) {
+	profiles := []struct {
+		enabled bool
+		file    string
+		kind    artifact.Kind
+	}{
+		{tOpts.EnableBlockProfile, tOpts.BlockProfileOutputFileName, artifact.KindProfile},
+		{tOpts.EnableCoverageProfile, tOpts.CoverageProfileOutputFileName, artifact.KindReport},
+		{tOpts.EnableCPUProfile, tOpts.CPUProfileOutputFileName, artifact.KindProfile},
+		{tOpts.EnableMemoryProfile, tOpts.MemoryProfileOutputFileName, artifact.KindProfile},
+		{tOpts.EnableMutexProfile, tOpts.MutexProfileOutputFileName, artifact.KindProfile},
+		{tOpts.EnableTraceProfile, tOpts.TraceProfileOutputFileName, artifact.KindProfile},
+	}
+
+	var artifacts []artifact.Artifact
+	for _, p := range profiles {
+		if p.enabled {
+			artifacts = append(artifacts, artifact.Artifact{
+				App:      appName,
+				Kind:     p.kind,
+				Path:     filepath.Join(tOpts.OutputDir, p.file),
+				Platform: taskGo.TargetPlatform(tOpts.Env),
+			})
+		}
+	}
+	e.recordArtifacts(taskName, artifacts...)
+}
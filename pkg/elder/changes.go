@@ -0,0 +1,190 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	vcsGit "github.com/svengreb/wand/pkg/project/vcs/git"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+const (
+	// DefaultChangeBaseRef is the default Git reference whose merge base with "HEAD" is used as base to compute changed
+	// files.
+	DefaultChangeBaseRef = "main"
+
+	// changeStagedBaseRev is the revision that is used as base for staged files.
+	changeStagedBaseRev = "HEAD"
+)
+
+// ChangeOption is a option to compute changed files.
+type ChangeOption func(*ChangeOptions)
+
+// ChangeOptions are options to compute changed files.
+type ChangeOptions struct {
+	// BaseRef is the Git reference whose merge base with "HEAD" is used as base to compute changed files.
+	BaseRef string
+
+	// EnableStaged indicates whether only staged files should be used instead of all files changed compared to the
+	// merge base of BaseRef.
+	EnableStaged bool
+}
+
+// NewChangeOptions creates new options to compute changed files.
+func NewChangeOptions(opts ...ChangeOption) *ChangeOptions {
+	opt := &ChangeOptions{BaseRef: DefaultChangeBaseRef}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithChangeBaseRef sets the Git reference whose merge base with "HEAD" is used as base to compute changed files.
+// Defaults to DefaultChangeBaseRef.
+func WithChangeBaseRef(ref string) ChangeOption {
+	return func(o *ChangeOptions) {
+		if ref != "" {
+			o.BaseRef = ref
+		}
+	}
+}
+
+// WithChangeStaged indicates whether only staged files should be used, e.g. in a Git "pre-commit" hook.
+func WithChangeStaged(enableStaged bool) ChangeOption {
+	return func(o *ChangeOptions) {
+		o.EnableStaged = enableStaged
+	}
+}
+
+// ChangeSet are files of the project that changed compared to a Git base revision.
+type ChangeSet struct {
+	// BaseRev is the Git revision the files were compared to.
+	BaseRev string
+
+	// Files are the paths, relative to the project root directory, of all changed files.
+	// Deleted files are not included.
+	Files []string
+
+	// Staged indicates whether only staged files are included.
+	Staged bool
+}
+
+// GoFiles returns all changed Go source files.
+// Files in "testdata" and "vendor" directories are excluded since the Go toolchain ignores them as well.
+func (cs *ChangeSet) GoFiles() []string {
+	var files []string
+	for _, f := range cs.Files {
+		if filepath.Ext(f) != ".go" {
+			continue
+		}
+		if hasPathElement(f, "testdata") || hasPathElement(f, "vendor") {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// changeSet returns the files of the project at the given root directory that changed in the given Git repository.
+// When any error occurs it will be of type *task.ErrTask.
+func changeSet(repo *vcsGit.Git, projectRootDir string, cOpts *ChangeOptions) (*ChangeSet, error) {
+	// Git reports changed paths relative to the root directory of the working tree which differs from the project root
+	// directory when the project is a subdirectory of the repository, e.g. in a monorepo.
+	rootDir, rootDirErr := repo.RootDir()
+	if rootDirErr != nil {
+		return nil, &task.ErrTask{Err: rootDirErr, Kind: task.ErrRun}
+	}
+	// Git resolves symbolic links of the root directory of the working tree so the project root directory must be
+	// resolved as well to compute relative paths.
+	if resolved, err := filepath.EvalSymlinks(projectRootDir); err == nil {
+		projectRootDir = resolved
+	}
+
+	cs := &ChangeSet{Staged: cOpts.EnableStaged}
+	var files []string
+	var filesErr error
+	if cOpts.EnableStaged {
+		cs.BaseRev = changeStagedBaseRev
+		files, filesErr = repo.StagedFiles()
+	} else {
+		mergeBase, mbErr := repo.MergeBase(cOpts.BaseRef)
+		if mbErr != nil {
+			return nil, &task.ErrTask{Err: mbErr, Kind: task.ErrRun}
+		}
+		cs.BaseRev = mergeBase
+		files, filesErr = repo.ChangedFiles(mergeBase)
+	}
+	if filesErr != nil {
+		return nil, &task.ErrTask{Err: filesErr, Kind: task.ErrRun}
+	}
+
+	for _, f := range files {
+		rel, relErr := filepath.Rel(projectRootDir, filepath.Join(rootDir, f))
+		if relErr != nil || isOutsideDir(rel) {
+			continue
+		}
+		cs.Files = append(cs.Files, rel)
+	}
+	return cs, nil
+}
+
+// changedGoFilesAbs returns the absolute paths of all changed Go source files of the given change set.
+// Tasks are run from within the current working directory which might differ from the project root directory the paths
+// of the change set are relative to.
+func (e *Elder) changedGoFilesAbs(cs *ChangeSet) []string {
+	files := cs.GoFiles()
+	for i, f := range files {
+		files[i] = filepath.Join(e.project.Options().RootDirPathAbs, f)
+	}
+	return files
+}
+
+// hasPathElement checks whether any element of the given path equals the given name.
+func hasPathElement(path, name string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(path), "/") {
+		if elem == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isOutsideDir checks whether the given relative path, as returned by filepath.Rel, points outside of its base
+// directory.
+func isOutsideDir(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	return rel == ".." || strings.HasPrefix(rel, "../")
+}
+
+// writeStagedPatch writes the unified diff of all staged changes compared to "HEAD" into a temporary file and returns
+// its path. The caller is responsible for removing the file.
+func (e *Elder) writeStagedPatch() (string, error) {
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return "", repoErr
+	}
+	patch, patchErr := repo.StagedDiff()
+	if patchErr != nil {
+		return "", patchErr
+	}
+
+	f, createErr := os.CreateTemp("", "wand-staged-*.patch")
+	if createErr != nil {
+		return "", fmt.Errorf("create patch file for staged changes: %w", createErr)
+	}
+	if _, err := f.WriteString(patch + "\n"); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("write patch file for staged changes: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("close patch file for staged changes: %w", err)
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vcsGit "github.com/svengreb/wand/pkg/project/vcs/git"
+)
+
+func TestChangeSetGoFiles(t *testing.T) {
+	cs := &ChangeSet{Files: []string{
+		"main.go",
+		"README.md",
+		"pkg/a/a.go",
+		"pkg/a/testdata/fixture.go",
+		"vendor/example.com/mod/mod.go",
+		"pkg/vendored/b.go",
+	}}
+	require.Equal(t, []string{"main.go", "pkg/a/a.go", "pkg/vendored/b.go"}, cs.GoFiles())
+}
+
+func TestIsOutsideDir(t *testing.T) {
+	tests := []struct {
+		rel     string
+		outside bool
+	}{
+		{rel: "..", outside: true},
+		{rel: "../a.go", outside: true},
+		{rel: "../../pkg", outside: true},
+		{rel: "..a.go"},
+		{rel: "..pkg/a.go"},
+		{rel: "."},
+		{rel: "pkg/a.go"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rel, func(t *testing.T) {
+			require.Equal(t, tc.outside, isOutsideDir(tc.rel))
+		})
+	}
+}
+
+func TestChangeSetProjectInSubdirectory(t *testing.T) {
+	root := newGitTestRepo(t, map[string]string{
+		"top.go":         "package top\n",
+		"sub/a.go":       "package a\n",
+		"sub/pkg/b.go":   "package b\n",
+		"other/c.go":     "package c\n",
+		"sub/README.md":  "# sub\n",
+		"subsequent.txt": "sub\n",
+	})
+	projectRootDir := filepath.Join(root, "sub")
+	repo := vcsGit.New(vcsGit.WithPath(projectRootDir))
+
+	for _, f := range []string{"top.go", "sub/a.go", "other/c.go", "subsequent.txt"} {
+		writeTestFile(t, filepath.Join(root, f), "// changed\n")
+	}
+	runGit(t, root, "add", "top.go", "sub/a.go")
+	writeTestFile(t, filepath.Join(root, "sub", "pkg", "b.go"), "// changed\n")
+
+	tests := []struct {
+		name  string
+		opts  []ChangeOption
+		files []string
+	}{
+		{name: "working tree", files: []string{"a.go", filepath.Join("pkg", "b.go")}},
+		{name: "staged", opts: []ChangeOption{WithChangeStaged(true)}, files: []string{"a.go"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := changeSet(repo, projectRootDir, NewChangeOptions(tc.opts...))
+			require.NoError(t, err)
+			require.Equal(t, tc.files, cs.Files)
+		})
+	}
+}
+
+// newGitTestRepo creates a Git repository in a temporary directory with a single commit of the given files on the
+// DefaultChangeBaseRef branch and returns its path.
+func newGitTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable not found")
+	}
+
+	root := t.TempDir()
+	runGit(t, root, "init", "--quiet", "--initial-branch="+DefaultChangeBaseRef)
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(root, name), content)
+	}
+	runGit(t, root, "add", "--all")
+	runGit(t, root, "commit", "--quiet", "--message=initial")
+	return root
+}
+
+// runGit runs Git with the given arguments within the given directory.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{
+		"-C", dir, "-c", "user.name=wand", "-c", "user.email=wand@example.com", "-c", "commit.gpgsign=false",
+	}, args...)...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+// writeTestFile writes the given content into the file at the given path and creates all parent directories.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
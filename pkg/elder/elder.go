@@ -13,32 +13,59 @@
 package elder
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	glFilePath "github.com/svengreb/golib/pkg/io/fs/filepath"
 	"github.com/svengreb/nib"
 
 	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/artifact"
 	"github.com/svengreb/wand/pkg/project"
 	"github.com/svengreb/wand/pkg/task"
 	taskFSClean "github.com/svengreb/wand/pkg/task/fs/clean"
 	taskGofumpt "github.com/svengreb/wand/pkg/task/gofumpt"
 	taskGoimports "github.com/svengreb/wand/pkg/task/goimports"
 	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
 	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+	taskGoFuzz "github.com/svengreb/wand/pkg/task/golang/fuzz"
+	taskGoGenerate "github.com/svengreb/wand/pkg/task/golang/generate"
+	taskGoGet "github.com/svengreb/wand/pkg/task/golang/get"
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+	taskGoModDownload "github.com/svengreb/wand/pkg/task/golang/mod/download"
+	taskGoModGraph "github.com/svengreb/wand/pkg/task/golang/mod/graph"
+	taskGoModTidy "github.com/svengreb/wand/pkg/task/golang/mod/tidy"
+	taskGoModVerify "github.com/svengreb/wand/pkg/task/golang/mod/verify"
+	taskGoModWhy "github.com/svengreb/wand/pkg/task/golang/mod/why"
+	taskGoPGO "github.com/svengreb/wand/pkg/task/golang/pgo"
+	taskGoPprof "github.com/svengreb/wand/pkg/task/golang/pprof"
+	taskGoRepro "github.com/svengreb/wand/pkg/task/golang/repro"
 	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+	"github.com/svengreb/wand/pkg/task/golang/test/fixture"
 	taskGolangCILint "github.com/svengreb/wand/pkg/task/golangcilint"
 	taskGoModUpgrade "github.com/svengreb/wand/pkg/task/gomodupgrade"
 	taskGoTool "github.com/svengreb/wand/pkg/task/gotool"
+	taskGoToolGeneric "github.com/svengreb/wand/pkg/task/gotool/generic"
+	taskGoVulnCheck "github.com/svengreb/wand/pkg/task/govulncheck"
 	taskGox "github.com/svengreb/wand/pkg/task/gox"
+	taskOCI "github.com/svengreb/wand/pkg/task/oci"
+	taskSBOM "github.com/svengreb/wand/pkg/task/sbom"
+	taskSize "github.com/svengreb/wand/pkg/task/size"
 )
 
 // Elder is a wand.Wand reference implementation that provides common Mage tasks and stores configurations and metadata
 // for applications of a project.
 type Elder struct {
 	nib.Nib
+	artifacts    *artifact.Registry
 	as           app.Store
 	goRunner     *taskGo.Runner
 	goToolRunner *taskGoTool.Runner
@@ -46,6 +73,49 @@ type Elder struct {
 	project      *project.Metadata
 }
 
+// Artifacts returns the artifacts tasks recorded for the application with the given name, or for all applications when
+// the name is empty, optionally limited to the given kinds.
+// Tasks like [*Elder.GoBuild], [*Elder.Gox], [*Elder.GoTest], [*Elder.OCIImage] and [*Elder.SBOM] record the artifacts
+// they produced in the artifact registry that is persisted in the wand data directory.
+func (e *Elder) Artifacts(appName string, kinds ...artifact.Kind) ([]artifact.Artifact, error) {
+	r, err := e.artifactRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("load artifact registry: %w", err)
+	}
+	return r.Query(appName, kinds...), nil
+}
+
+// BinarySize is a task to analyze the size of the binary artifact of the application by section and package.
+// The analysis is compared with the stored baseline analysis that is created on the first run and only updated when
+// configured.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner. An error of kind
+// taskSize.ErrBudgetExceeded is returned along with the report when the size exceeds the configured budget.
+//
+// See the "github.com/svengreb/wand/pkg/task/size" package for all available options.
+func (e *Elder) BinarySize(appName string, opts ...taskSize.Option) (*taskSize.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskSize.New(e.GetProjectMetadata(), ac, opts...)
+	report, err := t.Analyze()
+	if report == nil {
+		return nil, err
+	}
+
+	if report.Analysis.Stripped {
+		e.Infof("Binary artifact %q has no symbol table, sizes by package are not available", report.Artifact)
+	}
+	if report.Comparison != nil {
+		e.Infof("Binary size comparison:\n%s", report.Comparison)
+	}
+	if report.BaselineUpdated {
+		e.Successf("Stored size baseline of %d bytes in %q", report.Analysis.Size, report.BaselineFile)
+	}
+	return report, err
+}
+
 // Bootstrap runs initialization tasks to ensure the wand is operational.
 //
 // NOTE(Go 1.17): As of version 0.9.0 Bootstrap is a no-op!
@@ -69,6 +139,30 @@ func (e *Elder) Bootstrap(goModuleImportPaths ...string) []error {
 	return []error{}
 }
 
+// BuildProfile returns the build profile with the given name.
+// When the name is empty the build profile is selected through the EnvVarBuildProfile environment variable and falls
+// back to DefaultBuildProfileName.
+// It returns an error of type *task.ErrTask when the build profile does not exist or contains conflicting options.
+//
+// See DefaultBuildProfiles for all default build profiles and WithBuildProfiles to define additional ones.
+func (e *Elder) BuildProfile(name string) (*BuildProfile, error) {
+	name = selectBuildProfileName(name)
+	profiles := e.buildProfiles()
+	p, ok := profiles[name]
+	if !ok {
+		return nil, &task.ErrTask{
+			Err: fmt.Errorf(
+				"unknown build profile %q, available are %s", name, strings.Join(sortedBuildProfileNames(profiles), ", "),
+			),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 // CacheExecutables installs and caches executables from Go module-based "main" packages into a local cache within the
 // working directory. Note that this only works when the [taskGoTool.WithCache] option was set to `true`!
 // The given paths must be valid Go module import paths, that can optionally include the version suffix in the
@@ -89,188 +183,1698 @@ func (e *Elder) CacheExecutables(goModuleImportPaths ...string) error {
 			return installErr
 		}
 	}
-	return nil
+	return nil
+}
+
+// Changes computes the files of the project that changed compared to a Git base revision.
+// By default the base revision is the merge base of DefaultChangeBaseRef and "HEAD" and files are compared with the
+// working tree. When only staged files should be used the base revision is "HEAD".
+// When any error occurs it will be of type *task.ErrTask.
+func (e *Elder) Changes(opts ...ChangeOption) (*ChangeSet, error) {
+	cOpts := NewChangeOptions(opts...)
+
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return nil, &task.ErrTask{Err: repoErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	return changeSet(repo, e.project.Options().RootDirPathAbs, cOpts)
+}
+
+// Clean is a task to remove filesystem paths, e.g. output data like artifacts and reports from previous development,
+// test, production and distribution builds.
+// It returns paths that have been cleaned along with an error when the task execution fails.
+//
+// See the "github.com/svengreb/wand/pkg/task/fs/clean" package for all available options.
+func (e *Elder) Clean(appName string, opts ...taskFSClean.Option) ([]string, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return []string{}, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskFSClean.New(e.GetProjectMetadata(), ac, opts...)
+	return t.Clean()
+}
+
+// CleanArtifacts is a task to remove exactly the artifacts tasks recorded for the application with the given name,
+// optionally limited to the given kinds, and to remove them from the artifact registry afterwards.
+// It returns the paths of the removed artifacts, relative to the project root directory.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
+//
+// See [*Elder.Artifacts] for more details about recorded artifacts and the "github.com/svengreb/wand/pkg/task/fs/clean"
+// package for all available options.
+func (e *Elder) CleanArtifacts(appName string, kinds []artifact.Kind, opts ...taskFSClean.Option) ([]string, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return []string{}, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+	r, rErr := e.artifactRegistry()
+	if rErr != nil {
+		return []string{}, fmt.Errorf("load artifact registry: %w", rErr)
+	}
+
+	artifacts := r.Query(ac.Name, kinds...)
+	if len(artifacts) == 0 {
+		return []string{}, nil
+	}
+	t := taskFSClean.New(e.GetProjectMetadata(), ac, append([]taskFSClean.Option{taskFSClean.WithArtifacts(artifacts...)},
+		opts...)...)
+	cleaned, cleanErr := t.Clean()
+	if cleanErr != nil {
+		return cleaned, cleanErr
+	}
+
+	// Also remove records of artifacts that have already been removed by other means.
+	paths := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		paths = append(paths, a.Path)
+	}
+	if err := r.Remove(paths...); err != nil {
+		return cleaned, &task.ErrRunner{Err: fmt.Errorf("update artifact registry: %w", err), Kind: task.ErrRun}
+	}
+	return cleaned, nil
+}
+
+// ExitPrintf simplifies the logging for process exits with a suitable verbosity.
+//
+// References
+//
+//   - https://unix.stackexchange.com/questions/418784/what-is-the-min-and-max-values-of-exit-codes-in-linux
+func (e *Elder) ExitPrintf(code int, verb nib.Verbosity, format string, args ...interface{}) {
+	if code < 0 {
+		code = 1
+	}
+	switch verb {
+	case nib.DebugVerbosity:
+		e.Debugf(format, args...)
+	case nib.ErrorVerbosity:
+		e.Errorf(format, args...)
+	case nib.FatalVerbosity:
+		e.Fatalf(format, args...)
+	case nib.InfoVerbosity:
+		e.Infof(format, args...)
+	case nib.SuccessVerbosity:
+		e.Successf(format, args...)
+	case nib.WarnVerbosity:
+		e.Warnf(format, args...)
+	case nib.SuppressVerbosity:
+		// noop
+	}
+
+	//nolint:revive // Exiting is an explicit logic of this method.
+	os.Exit(code)
+}
+
+// Format is a task to check the formatting of Go source files with the "gofumpt" and "goimports" Go module commands.
+// Both formatters run in diff mode over the same paths and the unified diffs are collected per file into a typed report.
+// When fixing is enabled all non-compliant files are formatted and verified again afterwards.
+// The diffs of all non-compliant files are printed.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner. An error of kind ErrNonCompliantFormat is
+// returned along with the report when the formatting of any file is not compliant.
+//
+// See the "github.com/svengreb/wand/pkg/task/gofumpt" and "github.com/svengreb/wand/pkg/task/goimports" packages for
+// all available formatter options.
+func (e *Elder) Format(opts ...FormatOption) (*FormatReport, error) {
+	fOpts := NewFormatOptions(opts...)
+
+	files, diffErr := e.formatDiffs(fOpts)
+	if diffErr != nil {
+		return nil, diffErr
+	}
+	report := &FormatReport{Files: files}
+
+	if fOpts.EnableFix && len(report.Files) > 0 {
+		paths := report.Paths()
+		if err := e.formatFix(fOpts, paths); err != nil {
+			return report, err
+		}
+		report.Fixed = paths
+		if report.Files, diffErr = e.formatDiffs(fOpts); diffErr != nil {
+			return report, diffErr
+		}
+	}
+
+	if len(report.Files) > 0 {
+		for _, f := range report.Files {
+			for _, name := range f.Formatters() {
+				e.Warnf("%s is not compliant to %q:\n%s", f.Path, name, f.Diffs[name])
+			}
+		}
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d files:\n%s", len(report.Files), formatSummary(report.Files)),
+			Kind: ErrNonCompliantFormat,
+		}
+	}
+	return report, nil
+}
+
+// GetAppConfig returns an application configuration.
+// An empty application configuration is returned along with an error of type *app.ErrApp when there is no configuration
+// in the store for the given name.
+func (e *Elder) GetAppConfig(name string) (app.Config, error) {
+	ac, acErr := e.as.Get(name)
+	if acErr != nil {
+		return app.Config{}, fmt.Errorf("get %q application configuration: %w", name, acErr)
+	}
+
+	return *ac, nil
+}
+
+// GetProjectMetadata returns metadata of the project.
+func (e *Elder) GetProjectMetadata() project.Metadata {
+	return *e.project
+}
+
+// GitHooksInstall installs Git hooks that run the configured Mage targets, e.g. to check the format of staged files,
+// lint and run short tests before committing or pushing.
+// Hooks are written into the directory of the "core.hooksPath" Git configuration when set, otherwise into the
+// ".git/hooks" directory. Existing user hooks are kept and chained so that they run before the Mage targets.
+// It returns the status of all installed hooks. When any error occurs it will be of type *task.ErrTask.
+func (e *Elder) GitHooksInstall(opts ...GitHookOption) ([]GitHookStatus, error) {
+	hOpts := NewGitHookOptions(opts...)
+
+	var names []string
+	for _, name := range gitHookNames {
+		if len(hOpts.Targets[name]) > 0 {
+			names = append(names, name)
+		}
+	}
+	for name := range hOpts.Targets {
+		if !isGitHookName(name) {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("unsupported Git hook %q, supported are %s", name, strings.Join(gitHookNames, ", ")),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, &task.ErrTask{
+			Err:  errors.New("no Mage targets configured for any Git hook"),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	hooksDir, projectRelDir, dirErr := e.gitHooksDir()
+	if dirErr != nil {
+		return nil, &task.ErrTask{Err: dirErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	statuses := make([]GitHookStatus, 0, len(names))
+	for _, name := range names {
+		status, statusErr := gitHookStatus(hooksDir, name)
+		if statusErr != nil {
+			return statuses, &task.ErrTask{Err: statusErr, Kind: task.ErrRun}
+		}
+		script := gitHookScript(name, projectRelDir, hOpts.MageExec, hOpts.Targets[name])
+		if err := installGitHook(status, script); err != nil {
+			return statuses, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+		if status, statusErr = gitHookStatus(hooksDir, name); statusErr != nil {
+			return statuses, &task.ErrTask{Err: statusErr, Kind: task.ErrRun}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// GitHooksStatus returns the status of all supported Git hooks.
+// When any error occurs it will be of type *task.ErrTask.
+func (e *Elder) GitHooksStatus() ([]GitHookStatus, error) {
+	hooksDir, _, dirErr := e.gitHooksDir()
+	if dirErr != nil {
+		return nil, &task.ErrTask{Err: dirErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	statuses := make([]GitHookStatus, 0, len(gitHookNames))
+	for _, name := range gitHookNames {
+		status, statusErr := gitHookStatus(hooksDir, name)
+		if statusErr != nil {
+			return statuses, &task.ErrTask{Err: statusErr, Kind: task.ErrRun}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// GitHooksUninstall removes all Git hooks managed by wand and restores chained user hooks.
+// Hooks that are not managed by wand are left untouched.
+// It returns the status of all supported hooks after uninstalling. When any error occurs it will be of type
+// *task.ErrTask.
+func (e *Elder) GitHooksUninstall() ([]GitHookStatus, error) {
+	statuses, statusErr := e.GitHooksStatus()
+	if statusErr != nil {
+		return nil, statusErr
+	}
+
+	hooksDir := filepath.Dir(statuses[0].Path)
+	for i, status := range statuses {
+		if err := uninstallGitHook(status); err != nil {
+			return statuses, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+		updated, updateErr := gitHookStatus(hooksDir, status.Name)
+		if updateErr != nil {
+			return statuses, &task.ErrTask{Err: updateErr, Kind: task.ErrRun}
+		}
+		statuses[i] = updated
+	}
+	return statuses, nil
+}
+
+// GoBench is a task to run benchmarks with the Go toolchain "test" command.
+// The benchmark output is parsed into a typed run that is stored as JSON file named by the Git commit of "HEAD" within
+// the output directory. Note that a run for a working tree with uncommitted changes is also stored for the "HEAD"
+// commit and overwrites any previously stored run of the same commit.
+// When a base revision is configured the run is compared statistically with the stored run of the commit the base
+// revision resolves to.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner. An error of kind
+// taskGoBench.ErrRegression is returned along with the report when any benchmark regressed beyond the configured
+// threshold.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/bench" package for all available options.
+func (e *Elder) GoBench(appName string, opts ...taskGoBench.Option) (*taskGoBench.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t, tErr := taskGoBench.New(ac, opts...)
+	if tErr != nil {
+		return nil, fmt.Errorf(`create "go/bench" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoBench.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoBench.Options{})
+	}
+
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return nil, &task.ErrTask{Err: repoErr, Kind: task.ErrInvalidTaskOpts}
+	}
+	headCommit, headErr := repo.Commit("HEAD")
+	if headErr != nil {
+		return nil, &task.ErrTask{Err: headErr, Kind: task.ErrRun}
+	}
+
+	// Load the base run before running the benchmarks so that missing runs are detected early.
+	var base *taskGoBench.Run
+	if tOpts.BaseRev != "" {
+		baseCommit, baseErr := repo.Commit(tOpts.BaseRev)
+		if baseErr != nil {
+			return nil, &task.ErrTask{Err: baseErr, Kind: task.ErrInvalidTaskOpts}
+		}
+		if base, baseErr = taskGoBench.LoadRun(tOpts.OutputDir, baseCommit); baseErr != nil {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("load stored run of base revision %q: %w", tOpts.BaseRev, baseErr),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+	}
+
+	out, runErr := e.goRunner.RunOut(t)
+	if runErr != nil {
+		e.Errorf("%s", out)
+		return nil, runErr
+	}
+	run, parseErr := taskGoBench.ParseOutput(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, &task.ErrTask{Err: fmt.Errorf("parse %q output: %w", t.Name(), parseErr), Kind: task.ErrRun}
+	}
+	if len(run.Results) == 0 {
+		e.Warnf("No benchmarks matched pattern %q", tOpts.Pattern)
+	}
+	run.Commit = headCommit
+	run.Date = time.Now().UTC()
+
+	runFile, saveErr := run.Save(tOpts.OutputDir)
+	if saveErr != nil {
+		return nil, &task.ErrTask{Err: saveErr, Kind: task.ErrRun}
+	}
+	report := &taskGoBench.Report{Run: run, RunFile: runFile}
+
+	if base == nil {
+		return report, nil
+	}
+	report.Comparison = taskGoBench.Compare(base, run, tOpts.RegressionThreshold, tOpts.SignificanceLevel)
+	e.Infof("Benchmark comparison:\n%s", report.Comparison)
+
+	if regressions := report.Comparison.Regressions(); len(regressions) > 0 {
+		keys := make([]string, 0, len(regressions))
+		for _, r := range regressions {
+			keys = append(keys, fmt.Sprintf("%s %s (%+.2f%%)", r.Key, r.Unit, r.Change*100))
+		}
+		return report, &task.ErrTask{
+			Err: fmt.Errorf(
+				"%d benchmarks regressed beyond %.2f%% compared to %q:\n%s",
+				len(regressions), tOpts.RegressionThreshold*100, tOpts.BaseRev, strings.Join(keys, "\n"),
+			),
+			Kind: taskGoBench.ErrRegression,
+		}
+	}
+	return report, nil
+}
+
+// GoBuild is a task for the Go toolchain "build" command.
+// All files produced by the build are recorded as artifacts, including C header files that are generated for build
+// modes like taskGoBuild.ModeCShared.
+// When cgo is enabled the C toolchain of the target platform is validated before the build.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner, e.g. when a mixin can not be
+// applied or the C toolchain of a cgo-enabled target platform is not available.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/build" package for all available options.
+func (e *Elder) GoBuild(appName string, opts ...taskGoBuild.Option) error {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t, tErr := taskGoBuild.New(ac, opts...)
+	if tErr != nil {
+		return fmt.Errorf(`create "go/build" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoBuild.Options)
+	if !ok {
+		return fmt.Errorf(`convert task options to "%T"`, taskGoBuild.Options{})
+	}
+	if tOpts.Cgo != nil {
+		if err := tOpts.Cgo.ValidateToolchain(taskGo.TargetPlatform(tOpts.Env)); err != nil {
+			return &task.ErrTask{Err: err, Kind: task.ErrInvalidTaskOpts}
+		}
+	}
+	if err := e.goRunner.Run(t); err != nil {
+		return err
+	}
+
+	var artifacts []artifact.Artifact
+	for _, p := range t.OutputPaths() {
+		kind := artifact.KindBinary
+		if filepath.Ext(p) == ".h" {
+			kind = artifact.KindHeader
+		}
+		artifacts = append(artifacts, artifact.Artifact{
+			App:      ac.Name,
+			Kind:     kind,
+			Path:     p,
+			Platform: taskGo.TargetPlatform(tOpts.Env),
+		})
+	}
+	e.recordArtifacts(t.Name(), artifacts...)
+	return nil
+}
+
+// GoBuildFixture returns a test fixture for the process of the application executable that is built with the GoBuild
+// task when the fixture is started, e.g. to run integration tests against the built application.
+// Errors of the build are returned by the Start method of the fixture as error of type *fixture.ErrFixture.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/build" and "github.com/svengreb/wand/pkg/task/golang/test/fixture"
+// packages for all available options.
+func (e *Elder) GoBuildFixture(
+	appName, fixtureName string, buildOpts []taskGoBuild.Option, opts ...fixture.ProcessOption,
+) *fixture.Process {
+	return fixture.NewBuiltProcess(fixtureName, func() (string, error) {
+		ac, acErr := e.GetAppConfig(appName)
+		if acErr != nil {
+			return "", fmt.Errorf("get %q application configuration: %w", appName, acErr)
+		}
+		t, tErr := taskGoBuild.New(ac, buildOpts...)
+		if tErr != nil {
+			return "", fmt.Errorf(`create "go/build" task: %w`, tErr)
+		}
+		if err := e.GoBuild(appName, buildOpts...); err != nil {
+			return "", err
+		}
+
+		path := t.OutputPath()
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(e.project.Options().RootDirPathAbs, path)
+		}
+		return path, nil
+	}, opts...)
+}
+
+// GoBuildPGO is a task for profile-guided optimization (PGO) workflows.
+// The configured CPU profiles, e.g. collected from benchmarks run through the GoTest task or from the "net/http/pprof"
+// endpoint of a running application, are merged into a single profile with the Go toolchain "tool pprof" command. When
+// no profiles are configured explicitly the CPU profile with the default file name of the GoTest task is discovered
+// within the profile directory.
+// The application is then built with and without the merged profile to report the binary size delta and, when enabled,
+// the benchmarks are run with and without the merged profile to report the benchmark delta. Both binary artifacts are
+// recorded in the artifact registry.
+// The merged profile can optionally be copied into the main package directory so that the Go toolchain uses it for all
+// further builds through its default "-pgo=auto" mode.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/pgo" package for all available options.
+func (e *Elder) GoBuildPGO(appName string, opts ...taskGoPGO.Option) (*taskGoPGO.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskGoPGO.New(ac, opts...)
+	tOpts, ok := t.Options().(taskGoPGO.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoPGO.Options{})
+	}
+
+	profiles, profilesErr := pgoProfiles(&tOpts)
+	if profilesErr != nil {
+		return nil, &task.ErrTask{Err: profilesErr, Kind: task.ErrInvalidTaskOpts}
+	}
+	if err := os.MkdirAll(tOpts.OutputDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("create output directory %q: %w", tOpts.OutputDir, err)
+	}
+
+	mt := t.MergeTask(profiles...)
+	if out, err := e.goRunner.RunOut(mt); err != nil {
+		e.Errorf("%s", out)
+		return nil, err
+	}
+	report := &taskGoPGO.Report{MergedProfile: t.MergedProfile(), Profiles: profiles}
+	e.Successf("Merged %d CPU profiles into %s", len(profiles), report.MergedProfile)
+	e.recordArtifacts(t.Name(), artifact.Artifact{App: ac.Name, Kind: artifact.KindProfile, Path: report.MergedProfile})
+
+	if tOpts.EnableDefaultProfileUpdate {
+		if err := copyFile(report.MergedProfile, t.DefaultProfile()); err != nil {
+			return report, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+		report.DefaultProfileUpdated = true
+		e.Infof("Updated default profile %s", t.DefaultProfile())
+	}
+
+	var buildErr error
+	if report.Baseline, buildErr = e.pgoBuild(ac.Name, t, false); buildErr != nil {
+		return report, buildErr
+	}
+	if report.Optimized, buildErr = e.pgoBuild(ac.Name, t, true); buildErr != nil {
+		return report, buildErr
+	}
+
+	if tOpts.EnableBenchComparison {
+		base, _, baseErr := e.pgoBench(t, false)
+		if baseErr != nil {
+			return report, baseErr
+		}
+		head, btOpts, headErr := e.pgoBench(t, true)
+		if headErr != nil {
+			return report, headErr
+		}
+		report.BenchComparison = taskGoBench.Compare(base, head, btOpts.RegressionThreshold, btOpts.SignificanceLevel)
+	}
+
+	e.Infof("PGO report:\n%s", report)
+	return report, nil
+}
+
+// GoBuildProfile is a task for the Go toolchain "build" command using the build profile with the given name.
+// The options, mixins and environment variables of the build profile are applied before the given options and binary
+// artifacts are stored in the output subdirectory of the build profile unless another output directory is given.
+// When the name is empty the build profile is selected through the EnvVarBuildProfile environment variable.
+// The build profile is validated along with the given options so that mixins passed per call are checked for
+// conflicts with those of the build profile as well.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
+//
+// See [*Elder.BuildProfile] for more details about the selection of build profiles and the
+// "github.com/svengreb/wand/pkg/task/golang/build" package for all available options.
+func (e *Elder) GoBuildProfile(appName, profileName string, opts ...taskGoBuild.Option) error {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+	p, pErr := e.BuildProfile(profileName)
+	if pErr != nil {
+		return pErr
+	}
+	if err := validateBuildProfile(p, opts...); err != nil {
+		return err
+	}
+
+	e.Infof("Building %q with build profile %q", ac.Name, p.Name)
+	profileOpts := []taskGoBuild.Option{
+		taskGoBuild.WithGoOptions(p.TaskGoOptions()...),
+		taskGoBuild.WithOutputDir(filepath.Join(ac.BaseOutputDir, p.OutputSubDir)),
+	}
+	return e.GoBuild(ac.Name, append(profileOpts, opts...)...)
+}
+
+// GoBuildReproducible is a task to verify that the binary artifact of the application is reproducible.
+// The application is built twice with the Go toolchain "build" command, each time in a temporary "GOPATH" and "GOCACHE"
+// with trimmed paths, the configured "-buildvcs" setting and a fixed "SOURCE_DATE_EPOCH". The SHA-256 checksums of both
+// binary artifacts are compared and, when they differ, the differing sections of ELF binary artifacts are reported.
+// The binary artifacts of both builds are kept in the output directory for further inspection.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner. An error of kind
+// taskGoRepro.ErrNotReproducible is returned along with the report when the binary artifacts differ.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/repro" package for all available options.
+func (e *Elder) GoBuildReproducible(appName string, opts ...taskGoRepro.Option) (*taskGoRepro.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskGoRepro.New(ac, opts...)
+	tOpts, ok := t.Options().(taskGoRepro.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoRepro.Options{})
+	}
+
+	var goModCache string
+	if !tOpts.EnableIsolatedModCache {
+		var envErr error
+		if goModCache, envErr = e.goEnvVar(taskGo.DefaultEnvVarGOMODCACHE); envErr != nil {
+			return nil, envErr
+		}
+	}
+	if err := os.RemoveAll(tOpts.OutputDir); err != nil {
+		return nil, &task.ErrRunner{
+			Err:  fmt.Errorf("remove output directory %q: %w", tOpts.OutputDir, err),
+			Kind: task.ErrRun,
+		}
+	}
+
+	var artifacts [2]string
+	for i := range artifacts {
+		path, err := e.reproBuild(t, i+1, goModCache)
+		if err != nil {
+			return nil, err
+		}
+		artifacts[i] = path
+	}
+
+	report, cmpErr := taskGoRepro.Compare(artifacts[0], artifacts[1])
+	if cmpErr != nil {
+		return nil, &task.ErrRunner{Err: cmpErr, Kind: task.ErrRun}
+	}
+	if report.Reproducible() {
+		e.Successf("Build of %q is reproducible with SHA-256 checksum %s", ac.Name, report.Builds[0].SHA256)
+		return report, nil
+	}
+
+	details := "sections could not be compared since the binary artifacts are not ELF files"
+	if report.ELF {
+		diffs := make([]string, 0, len(report.Sections))
+		for _, d := range report.Sections {
+			diffs = append(diffs, d.String())
+		}
+		details = fmt.Sprintf("%d sections differ:\n%s", len(diffs), strings.Join(diffs, "\n"))
+	}
+	return report, &task.ErrTask{
+		Err: fmt.Errorf(
+			"SHA-256 checksums %s of %q and %s of %q differ, %s",
+			report.Builds[0].SHA256, artifacts[0], report.Builds[1].SHA256, artifacts[1], details,
+		),
+		Kind: taskGoRepro.ErrNotReproducible,
+	}
+}
+
+// GoFuzz is a task to discover and run Go native fuzz targets.
+// Fuzz targets are discovered per package and each target matching the configured pattern is run for the configured
+// amount of time, either sequentially or concurrently. Failing inputs that were added to the seed corpus of a package,
+// the "testdata/fuzz" directory, are copied into the output directory and kept in the seed corpus unless disabled.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner. An error of kind
+// taskGoFuzz.ErrCrashers is returned along with the report when any fuzz target found failing inputs.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/fuzz" package for all available options.
+func (e *Elder) GoFuzz(appName string, opts ...taskGoFuzz.Option) (*taskGoFuzz.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	// Discover fuzz targets of all packages by default.
+	fOpts, fOptsErr := taskGoFuzz.NewOptions(opts...)
+	if fOptsErr != nil {
+		return nil, fmt.Errorf(`create "go/fuzz" task options: %w`, fOptsErr)
+	}
+	if len(fOpts.Pkgs) == 0 {
+		opts = append(opts, taskGoFuzz.WithPkgs("./..."))
+	}
+	lt, ltErr := taskGoFuzz.NewList(ac, opts...)
+	if ltErr != nil {
+		return nil, fmt.Errorf(`create "go/fuzz" list task: %w`, ltErr)
+	}
+	tOpts, ok := lt.Options().(taskGoFuzz.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoFuzz.Options{})
+	}
+	pattern, patternErr := regexp.Compile(tOpts.Pattern)
+	if patternErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("invalid fuzz target pattern %q: %w", tOpts.Pattern, patternErr),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	out, runErr := e.goRunner.RunOut(lt)
+	if runErr != nil {
+		e.Errorf("%s", out)
+		return nil, runErr
+	}
+	listed, parseErr := taskGoTest.ParseList(strings.NewReader(out), "Fuzz")
+	if parseErr != nil {
+		return nil, &task.ErrTask{Err: fmt.Errorf("parse %q output: %w", lt.Name(), parseErr), Kind: task.ErrRun}
+	}
+	pkgs, pkgsErr := e.goListPackages(tOpts.Env, "", tOpts.Pkgs)
+	if pkgsErr != nil {
+		return nil, pkgsErr
+	}
+	pkgDirs := make(map[string]string, len(pkgs))
+	for _, p := range pkgs {
+		pkgDirs[p.ImportPath] = p.Dir
+	}
+
+	var targets []taskGoFuzz.Target
+	for _, l := range listed {
+		if pattern.MatchString(l.Name) {
+			targets = append(targets, taskGoFuzz.Target{Dir: pkgDirs[l.Pkg], Name: l.Name, Pkg: l.Pkg})
+		}
+	}
+	report := &taskGoFuzz.Report{Results: make([]taskGoFuzz.TargetResult, len(targets))}
+	if len(targets) == 0 {
+		e.Warnf("No fuzz targets matched pattern %q", tOpts.Pattern)
+		return report, nil
+	}
+
+	var wg sync.WaitGroup
+	var printMu sync.Mutex
+	sem := make(chan struct{}, tOpts.Parallelism)
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, target taskGoFuzz.Target) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			printMu.Lock()
+			e.Infof("Fuzzing %s for %s", target, tOpts.FuzzTime)
+			printMu.Unlock()
+
+			res := e.fuzzTarget(ac, target, &tOpts, opts)
+			report.Results[i] = res
+
+			printMu.Lock()
+			defer printMu.Unlock()
+			switch {
+			case len(res.Crashers) > 0, res.Error != "":
+				e.Errorf("%s\n%s", res, res.Output)
+			default:
+				e.Successf("%s", res)
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	if crashing := report.Crashing(); len(crashing) > 0 {
+		names := make([]string, 0, len(crashing))
+		for _, res := range crashing {
+			names = append(names, res.Target.String())
+		}
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d fuzz targets: %s", len(crashing), strings.Join(names, ", ")),
+			Kind: taskGoFuzz.ErrCrashers,
+		}
+	}
+	if errored := report.Errored(); len(errored) > 0 {
+		names := make([]string, 0, len(errored))
+		for _, res := range errored {
+			names = append(names, res.Target.String())
+		}
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d fuzz targets failed: %s", len(errored), strings.Join(names, ", ")),
+			Kind: task.ErrRun,
+		}
+	}
+	return report, nil
+}
+
+// GoGenerate is a task for the Go toolchain "generate" command.
+// All "go:generate" directives of the application are scanned first and filtered by the configured run and skip
+// patterns. Generators of directives that use the Go "run" command in module-aware mode, e.g.
+// "go run golang.org/x/tools/cmd/stringer@v0.1.7", are resolved through the Go module-based tool runner so that their
+// executables are cached and run directly. All other directives are processed by the Go "generate" command.
+// When the check mode is enabled the task fails with an error of kind taskGoGenerate.ErrOutdated when regenerating
+// changed files tracked by the project Git repository or created untracked files. All changes of the check mode are
+// reverted afterwards so that the working tree is left untouched.
+// It returns the processed directives along with an error of type *app.ErrApp, *task.ErrTask or *task.ErrRunner when
+// any error occurs.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/generate" package for all available options.
+func (e *Elder) GoGenerate(appName string, opts ...taskGoGenerate.Option) (*taskGoGenerate.Result, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t, tErr := taskGoGenerate.New(ac, opts...)
+	if tErr != nil {
+		return nil, fmt.Errorf("create %q task: %w", "go/generate", tErr)
+	}
+	tOpts, ok := t.Options().(taskGoGenerate.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoGenerate.Options{})
+	}
+
+	appDir := filepath.Join(e.project.Options().RootDirPathAbs, ac.PathRel)
+	directives, scanErr := taskGoGenerate.ScanDirectives(appDir)
+	if scanErr != nil {
+		return nil, &task.ErrTask{Err: scanErr, Kind: task.ErrTaskValidation}
+	}
+
+	res := &taskGoGenerate.Result{}
+	aliases := make(map[string][]string)
+	var resolvable bool
+	for _, d := range directives {
+		if d.IsCommandAlias() {
+			aliases[d.File] = append(aliases[d.File], quoteDirective(d))
+			continue
+		}
+		if !tOpts.Selects(d) {
+			continue
+		}
+		if _, _, isGoRun := d.GoRunModule(); isGoRun {
+			resolvable = true
+		}
+		res.Directives = append(res.Directives, d)
+	}
+
+	// Limit the check mode to files within the application directory.
+	pathspec := ac.PathRel
+	if pathspec == "" {
+		pathspec = "."
+	}
+	if tOpts.EnableCheck {
+		snapshot, snapshotErr := e.snapshotWorktree(pathspec)
+		if snapshotErr != nil {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("snapshot working tree for check mode: %w", snapshotErr),
+				Kind: task.ErrTaskValidation,
+			}
+		}
+		return res, e.checkGenerate(t, tOpts, res, aliases, resolvable, snapshot)
+	}
+
+	return res, e.generate(t, tOpts, res.Directives, aliases, resolvable)
+}
+
+// Gofumpt is a task for the "mvdan.cc/gofumpt" Go module command.
+// "gofumpt" enforce a stricter format than "https://pkg.go.dev/cmd/gofmt", while being backwards compatible,
+// and provides additional rules.
+// It is a modified fork of "https://pkg.go.dev/cmd/gofmt" so it can be used as a drop-in replacement.
+// When any error occurs it will be of type *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/gofumpt" package for all available options.
+// See https://github.com/mvdan/gofumpt#added-rules for more details about available rules.
+//
+// See https://pkg.go.dev/mvdan.cc/gofumpt for more details about "gofumpt".
+// The source code of "gofumpt" is available at https://github.com/mvdan/gofumpt.
+func (e *Elder) Gofumpt(opts ...taskGofumpt.Option) error {
+	t, tErr := taskGofumpt.New(opts...)
+	if tErr != nil {
+		return fmt.Errorf(`create "gofumpt" task: %w`, tErr)
+	}
+
+	return e.goToolRunner.Run(t)
+}
+
+// GofumptChanged runs the Gofumpt task only for the Go source files of the given change set.
+// The task is not run at all when no Go source file changed.
+// When any error occurs it will be of type *task.ErrRunner.
+func (e *Elder) GofumptChanged(cs *ChangeSet, opts ...taskGofumpt.Option) error {
+	files := e.changedGoFilesAbs(cs)
+	if len(files) == 0 {
+		return nil
+	}
+	return e.Gofumpt(append(opts, taskGofumpt.WithPaths(files...))...)
+}
+
+// Goimports is a task for the "golang.org/x/tools/cmd/goimports" Go module command.
+// "goimports" allows to update Go import lines, add missing ones and remove unreferenced ones. It also formats code in
+// the same style as "https://pkg.go.dev/cmd/gofmt" so it can be used as a replacement.
+// When any error occurs it will be of type *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/goimports" package for all available options.
+//
+// See https://pkg.go.dev/golang.org/x/tools/cmd/goimports for more details about "goimports".
+// The source code of "goimports" is available at https://github.com/golang/tools/tree/master/cmd/goimports.
+func (e *Elder) Goimports(opts ...taskGoimports.Option) error {
+	t, tErr := taskGoimports.New(opts...)
+	if tErr != nil {
+		return fmt.Errorf(`create "goimports" task: %w`, tErr)
+	}
+
+	return e.goToolRunner.Run(t)
+}
+
+// GoimportsChanged runs the Goimports task only for the Go source files of the given change set.
+// The task is not run at all when no Go source file changed.
+// When any error occurs it will be of type *task.ErrRunner.
+func (e *Elder) GoimportsChanged(cs *ChangeSet, opts ...taskGoimports.Option) error {
+	files := e.changedGoFilesAbs(cs)
+	if len(files) == 0 {
+		return nil
+	}
+	return e.Goimports(append(opts, taskGoimports.WithPaths(files...))...)
+}
+
+// GolangCILint is a task to run the "github.com/golangci/golangci-lint/cmd/golangci-lint" Go module
+// command.
+// "golangci-lint" is a fast, parallel runner for dozens of Go linters Go that uses caching, supports YAML
+// configurations and has integrations with all major IDEs.
+// When the JSON output is enabled the task is run through the GolangCILintReport task instead.
+// When any error occurs it will be of type *task.ErrRunner, or of any type returned by the GolangCILintReport task.
+//
+// See the "github.com/svengreb/wand/pkg/task/golangcilint" package for all available options.
+//
+// See https://pkg.go.dev/github.com/golangci/golangci-lint and the official website at https://golangci-lint.run for
+// more details about "golangci-lint".
+// The source code of "golangci-lint" is available at https://github.com/golangci/golangci-lint.
+func (e *Elder) GolangCILint(opts ...taskGolangCILint.Option) error {
+	t, tErr := taskGolangCILint.New(opts...)
+	if tErr != nil {
+		return fmt.Errorf(`create "golangci-lint" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGolangCILint.Options)
+	if !ok {
+		return fmt.Errorf(`convert task options to "%T"`, taskGolangCILint.Options{})
+	}
+
+	if tOpts.EnableJSONOutput {
+		_, err := e.GolangCILintReport(opts...)
+		return err
+	}
+
+	return e.goToolRunner.Run(t)
+}
+
+// GolangCILintChanged runs the GolangCILint task so that only issues in code that changed compared to the base
+// revision of the given change set are reported.
+// When the change set only includes staged files, only issues in staged changes are reported based on a patch of all
+// staged changes compared to "HEAD".
+// The task is not run at all when no Go source file changed.
+// When any error occurs it will be of the same type as for the GolangCILint task or of type *task.ErrTask.
+func (e *Elder) GolangCILintChanged(cs *ChangeSet, opts ...taskGolangCILint.Option) error {
+	if len(cs.GoFiles()) == 0 {
+		return nil
+	}
+	if !cs.Staged {
+		return e.GolangCILint(append(opts, taskGolangCILint.WithNewFromRev(cs.BaseRev))...)
+	}
+
+	patch, patchErr := e.writeStagedPatch()
+	if patchErr != nil {
+		return &task.ErrTask{Err: patchErr, Kind: task.ErrRun}
+	}
+	defer func() { _ = os.Remove(patch) }()
+	return e.GolangCILint(append(opts, taskGolangCILint.WithNewFromPatch(patch))...)
+}
+
+// GolangCILintReport runs the GolangCILint task with enabled JSON output.
+// The JSON output of the command is parsed into typed issues which are printed and returned. Report files are written
+// for all configured report formats into the configured report directory which defaults to the base output directory of
+// the project.
+// When any error occurs it will be of type *task.ErrTask, *task.ErrRunner or *os.PathError. An error of kind
+// taskGolangCILint.ErrIssues is returned along with the issues when any issue has been found.
+//
+// See the "github.com/svengreb/wand/pkg/task/golangcilint" package for all available options.
+func (e *Elder) GolangCILintReport(opts ...taskGolangCILint.Option) ([]taskGolangCILint.Issue, error) {
+	t, tErr := taskGolangCILint.New(append(opts, taskGolangCILint.WithJSONOutput(true))...)
+	if tErr != nil {
+		return nil, fmt.Errorf(`create "golangci-lint" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGolangCILint.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGolangCILint.Options{})
+	}
+
+	out, runErr := e.goToolRunner.RunOut(t)
+	report, parseErr := taskGolangCILint.ParseOutput(strings.NewReader(out))
+	if runErr != nil && (parseErr != nil || report.Error == "") {
+		return nil, runErr
+	}
+	if parseErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf(`parse "golangci-lint" output: %w`, parseErr),
+			Kind: task.ErrRun,
+		}
+	}
+	if report.Error != "" {
+		return report.Issues, &task.ErrTask{
+			Err:  fmt.Errorf(`run "golangci-lint": %s`, report.Error),
+			Kind: task.ErrRun,
+		}
+	}
+
+	for _, w := range report.Warnings {
+		e.Warnf("%s", w)
+	}
+	for _, i := range report.Issues {
+		e.Errorf("%s", i)
+	}
+
+	if len(tOpts.ReportFormats) > 0 {
+		dir := tOpts.ReportDir
+		if dir == "" {
+			dir = filepath.Join(e.project.Options().RootDirPathAbs, e.project.Options().BaseOutputDir)
+		}
+		version := t.ID().Version.Original()
+		if err := writeGolangCILintReports(dir, report.Issues, version, tOpts.ReportFormats...); err != nil {
+			return report.Issues, err
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		return report.Issues, &task.ErrTask{
+			Err:  fmt.Errorf("%d issues", len(report.Issues)),
+			Kind: taskGolangCILint.ErrIssues,
+		}
+	}
+	return report.Issues, nil
+}
+
+// GoModDownload is a task to run the Go toolchain "mod download" command for all Go modules of the project.
+// The JSON output of the command is parsed into typed module information for each Go module.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner. An error is also returned along with the
+// results when any module could not be downloaded.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/mod/download" package for all available options.
+func (e *Elder) GoModDownload(opts ...taskGoModDownload.Option) ([]taskGoModDownload.Result, error) {
+	dirs, dirsErr := e.goModuleDirs()
+	if dirsErr != nil {
+		return nil, &task.ErrTask{Err: dirsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	var results []taskGoModDownload.Result
+	var failed []string
+	for _, dir := range dirs {
+		t := taskGoModDownload.New(append(opts, taskGoModDownload.WithWorkingDir(dir))...)
+		out, runErr := e.goRunner.RunOut(t)
+		modules, parseErr := taskGoModDownload.ParseOutput(strings.NewReader(out))
+		if parseErr != nil {
+			if runErr != nil {
+				return results, runErr
+			}
+			return results, &task.ErrTask{
+				Err:  fmt.Errorf("parse %q output for module %q: %w", t.Name(), e.projectRelPath(dir), parseErr),
+				Kind: task.ErrRun,
+			}
+		}
+
+		var moduleFailed bool
+		for _, m := range modules {
+			if m.Error != "" {
+				failed = append(failed, fmt.Sprintf("%s: %s", m.Path, m.Error))
+				moduleFailed = true
+			}
+		}
+		// Errors of the command that are not explained by failed downloads of the current Go module must not be
+		// swallowed by failed downloads of previous Go modules.
+		if runErr != nil && !moduleFailed {
+			return results, runErr
+		}
+		results = append(results, taskGoModDownload.Result{ModuleDir: dir, Modules: modules})
+	}
+
+	if len(failed) > 0 {
+		return results, &task.ErrTask{
+			Err:  fmt.Errorf("download %d modules:\n%s", len(failed), strings.Join(failed, "\n")),
+			Kind: task.ErrRun,
+		}
+	}
+	return results, nil
+}
+
+// GoModGraph is a task to run the Go toolchain "mod graph" command for all Go modules of the project.
+// The output of the command is parsed into a typed module requirement graph for each Go module.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/mod/graph" package for all available options.
+func (e *Elder) GoModGraph(opts ...taskGoModGraph.Option) ([]*taskGoModGraph.Graph, error) {
+	dirs, dirsErr := e.goModuleDirs()
+	if dirsErr != nil {
+		return nil, &task.ErrTask{Err: dirsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	var graphs []*taskGoModGraph.Graph
+	for _, dir := range dirs {
+		t := taskGoModGraph.New(append(opts, taskGoModGraph.WithWorkingDir(dir))...)
+		out, runErr := e.goRunner.RunOut(t)
+		if runErr != nil {
+			return graphs, runErr
+		}
+		g, parseErr := taskGoModGraph.ParseOutput(strings.NewReader(out))
+		if parseErr != nil {
+			return graphs, &task.ErrTask{
+				Err:  fmt.Errorf("parse %q output for module %q: %w", t.Name(), e.projectRelPath(dir), parseErr),
+				Kind: task.ErrRun,
+			}
+		}
+		g.ModuleDir = dir
+		graphs = append(graphs, g)
+	}
+	return graphs, nil
+}
+
+// GoModTidy is a task to run the Go toolchain "mod tidy" command for all Go modules of the project.
+// The changes to the "go.mod" and "go.sum" files of each Go module are collected as unified diff.
+// In check mode the files are restored afterwards and a diff is printed for every Go module that is not tidy.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner. An error of kind taskGoModTidy.ErrUntidy
+// is returned along with the results when any Go module is not tidy in check mode.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/mod/tidy" package for all available options.
+func (e *Elder) GoModTidy(opts ...taskGoModTidy.Option) ([]taskGoModTidy.Result, error) {
+	dirs, dirsErr := e.goModuleDirs()
+	if dirsErr != nil {
+		return nil, &task.ErrTask{Err: dirsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	var results []taskGoModTidy.Result
+	var untidy []string
+	for _, dir := range dirs {
+		t := taskGoModTidy.New(append(opts, taskGoModTidy.WithWorkingDir(dir))...)
+		tOpts, ok := t.Options().(taskGoModTidy.Options)
+		if !ok {
+			return results, fmt.Errorf(`convert task options to "%T"`, taskGoModTidy.Options{})
+		}
+
+		before, readErr := readGoModFiles(dir)
+		if readErr != nil {
+			return results, &task.ErrTask{Err: readErr, Kind: task.ErrRun}
+		}
+		runErr := e.goRunner.Run(t)
+		after, readErr := readGoModFiles(dir)
+		if tOpts.EnableCheck {
+			if restoreErr := restoreGoModFiles(dir, before); restoreErr != nil {
+				return results, &task.ErrTask{Err: restoreErr, Kind: task.ErrRun}
+			}
+		}
+		if runErr != nil {
+			return results, runErr
+		}
+		if readErr != nil {
+			return results, &task.ErrTask{Err: readErr, Kind: task.ErrRun}
+		}
+
+		result := taskGoModTidy.Result{Diff: e.goModFilesDiff(dir, before, after), ModuleDir: dir}
+		result.Changed = result.Diff != ""
+		results = append(results, result)
+
+		if tOpts.EnableCheck && result.Changed {
+			untidy = append(untidy, e.projectRelPath(dir))
+			e.Warnf("Go module %q is not tidy:\n%s", e.projectRelPath(dir), result.Diff)
+		}
+	}
+
+	if len(untidy) > 0 {
+		return results, &task.ErrTask{
+			Err:  fmt.Errorf("%d modules: %s", len(untidy), strings.Join(untidy, ", ")),
+			Kind: taskGoModTidy.ErrUntidy,
+		}
+	}
+	return results, nil
+}
+
+// GoModUpgrade is a task for the "github.com/oligot/go-mod-upgrade" Go module command.
+// "go-mod-upgrade" allows to update outdated Go module dependencies interactively.
+// When any error occurs it will be of type *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/gomodupgrade" package for all available options.
+//
+// See https://pkg.go.dev/github.com/oligot/go-mod-upgrade for more details about "go-mod-upgrade".
+// The source code of "go-mod-upgrade" is available at https://github.com/oligot/go-mod-upgrade.
+func (e *Elder) GoModUpgrade(opts ...taskGoModUpgrade.Option) error {
+	t, tErr := taskGoModUpgrade.New(opts...)
+	if tErr != nil {
+		return fmt.Errorf(`create "gomodupgrade" task: %w`, tErr)
+	}
+
+	return e.goToolRunner.Run(t)
+}
+
+// GoModUpgradeReport is a task to create a non-interactive dependency update report using the Go toolchain
+// "list -m -u -json all" command.
+// Available updates are classified as patch, minor or major and can optionally be applied up to a chosen class using
+// the Go toolchain "get" command followed by "mod tidy". The report is written to the configured output file, if any.
+// When any error occurs it will be of type *task.ErrTask, *task.ErrRunner or *os.PathError.
+//
+// See the "github.com/svengreb/wand/pkg/task/gomodupgrade" package for all available options.
+func (e *Elder) GoModUpgradeReport(opts ...taskGoModUpgrade.ReportOption) (*taskGoModUpgrade.Report, error) {
+	tOpts := taskGoModUpgrade.NewReportOptions(opts...)
+
+	dir := tOpts.WorkingDir
+	if dir == "" {
+		dir = e.project.Options().RootDirPathAbs
+	}
+	env := make(map[string]string, len(tOpts.Env)+1)
+	for k, v := range tOpts.Env {
+		env[k] = v
+	}
+	if tOpts.GoProxy != "" {
+		env["GOPROXY"] = tOpts.GoProxy
+	}
+
+	modules, listErr := e.goListModules(env, dir, "all")
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	var probes []taskGoList.Module
+	if tOpts.EnableMajorProbe {
+		var queries []string
+		for _, m := range modules {
+			if m.Main || !tOpts.IncludesDependency(m.Indirect) {
+				continue
+			}
+			if next, ok := taskGoModUpgrade.NextMajorPath(m.Path, m.Version); ok {
+				queries = append(queries, next+project.GoModuleVersionSuffixSeparator+project.GoModuleVersionLatest)
+			}
+		}
+		if len(queries) > 0 {
+			var probeErr error
+			if probes, probeErr = e.goListModules(env, dir, queries...); probeErr != nil {
+				return nil, probeErr
+			}
+		}
+	}
+
+	report := taskGoModUpgrade.NewReport(modules, probes, tOpts)
+
+	if tOpts.EnableApply {
+		var queries []string
+		var applied []int
+		for i, u := range report.Updates {
+			if tOpts.Applies(u) {
+				queries = append(queries, u.Path+project.GoModuleVersionSuffixSeparator+u.UpdateVersion)
+				applied = append(applied, i)
+			}
+		}
+		if len(queries) > 0 {
+			getTask := taskGoGet.New(
+				taskGoGet.WithEnv(env),
+				taskGoGet.WithModules(queries...),
+				taskGoGet.WithWorkingDir(dir),
+			)
+			if runErr := e.goRunner.Run(getTask); runErr != nil {
+				return report, runErr
+			}
+			tidyTask := taskGoModTidy.New(taskGoModTidy.WithEnv(env), taskGoModTidy.WithWorkingDir(dir))
+			if runErr := e.goRunner.Run(tidyTask); runErr != nil {
+				return report, runErr
+			}
+			for _, i := range applied {
+				report.Updates[i].Applied = true
+			}
+		}
+	}
+
+	if tOpts.OutputFile != "" {
+		if err := os.MkdirAll(filepath.Dir(tOpts.OutputFile), os.ModePerm); err != nil {
+			return report, fmt.Errorf("create report directory: %w", err)
+		}
+		f, createErr := os.Create(tOpts.OutputFile)
+		if createErr != nil {
+			return report, fmt.Errorf("create report file: %w", createErr)
+		}
+		if err := report.Write(f, tOpts.Format); err != nil {
+			_ = f.Close()
+			return report, &task.ErrTask{
+				Err:  fmt.Errorf("write report file %q: %w", tOpts.OutputFile, err),
+				Kind: task.ErrRun,
+			}
+		}
+		if err := f.Close(); err != nil {
+			return report, &task.ErrTask{
+				Err:  fmt.Errorf("close report file %q: %w", tOpts.OutputFile, err),
+				Kind: task.ErrRun,
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// GoModVerify is a task to run the Go toolchain "mod verify" command for all Go modules of the project.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/mod/verify" package for all available options.
+func (e *Elder) GoModVerify(opts ...taskGoModVerify.Option) error {
+	dirs, dirsErr := e.goModuleDirs()
+	if dirsErr != nil {
+		return &task.ErrTask{Err: dirsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	for _, dir := range dirs {
+		t := taskGoModVerify.New(append(opts, taskGoModVerify.WithWorkingDir(dir))...)
+		if runErr := e.goRunner.Run(t); runErr != nil {
+			return runErr
+		}
+	}
+	return nil
+}
+
+// GoModWhy is a task to run the Go toolchain "mod why" command for all Go modules of the project.
+// The output of the command is parsed into typed explanations for each Go module.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/golang/mod/why" package for all available options.
+func (e *Elder) GoModWhy(opts ...taskGoModWhy.Option) ([]taskGoModWhy.Result, error) {
+	dirs, dirsErr := e.goModuleDirs()
+	if dirsErr != nil {
+		return nil, &task.ErrTask{Err: dirsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	var results []taskGoModWhy.Result
+	for _, dir := range dirs {
+		t := taskGoModWhy.New(append(opts, taskGoModWhy.WithWorkingDir(dir))...)
+		out, runErr := e.goRunner.RunOut(t)
+		if runErr != nil {
+			return results, runErr
+		}
+		explanations, parseErr := taskGoModWhy.ParseOutput(strings.NewReader(out))
+		if parseErr != nil {
+			return results, &task.ErrTask{
+				Err:  fmt.Errorf("parse %q output for module %q: %w", t.Name(), e.projectRelPath(dir), parseErr),
+				Kind: task.ErrRun,
+			}
+		}
+		results = append(results, taskGoModWhy.Result{Explanations: explanations, ModuleDir: dir})
+	}
+	return results, nil
 }
 
-// Clean is a task to remove filesystem paths, e.g. output data like artifacts and reports from previous development,
-// test, production and distribution builds.
-// It returns paths that have been cleaned along with an error when the task execution fails.
+// GoProfileReport is a task to analyze profiles, e.g. those written by the GoTest task, with the Go toolchain
+// "tool pprof" command.
+// For each profile a text table of the top entries, folded stacks compatible with flame graph tools and optionally a
+// SVG call graph are written into the output directory. A text summary of all profiles is written into the output
+// directory as well and printed.
+// When the project repository is a Git repository the analyzed profiles are stored for the commit of "HEAD" so that a
+// later run can show the differences of the hot paths compared to any stored commit through the configured base
+// revision. Alternatively a directory with profiles can be used as base. Profiles are matched by their file name.
+// When no profiles are configured explicitly all profiles with the default file names of the GoTest task are
+// discovered within the profile directory.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
 //
-// See the "github.com/svengreb/wand/pkg/task/fs/clean" package for all available options.
-func (e *Elder) Clean(appName string, opts ...taskFSClean.Option) ([]string, error) {
+// See the "github.com/svengreb/wand/pkg/task/golang/pprof" package for all available options.
+func (e *Elder) GoProfileReport(appName string, opts ...taskGoPprof.ReportOption) (*taskGoPprof.Report, error) {
 	ac, acErr := e.GetAppConfig(appName)
 	if acErr != nil {
-		return []string{}, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
 	}
 
-	t := taskFSClean.New(e.GetProjectMetadata(), ac, opts...)
-	return t.Clean()
-}
+	rOpts := taskGoPprof.NewReportOptions(opts...)
+	if rOpts.OutputDir == "" {
+		rOpts.OutputDir = filepath.Join(ac.BaseOutputDir, taskGoPprof.DefaultReportOutputDirName)
+	}
+	if rOpts.ProfileDir == "" {
+		rOpts.ProfileDir = filepath.Join(ac.BaseOutputDir, taskGoTest.DefaultOutputDirName)
+	}
 
-// ExitPrintf simplifies the logging for process exits with a suitable verbosity.
-//
-// References
-//
-//   - https://unix.stackexchange.com/questions/418784/what-is-the-min-and-max-values-of-exit-codes-in-linux
-func (e *Elder) ExitPrintf(code int, verb nib.Verbosity, format string, args ...interface{}) {
-	if code < 0 {
-		code = 1
+	profiles := rOpts.Profiles
+	if len(profiles) == 0 {
+		discovered, discoverErr := testProfiles(rOpts.ProfileDir)
+		if discoverErr != nil {
+			return nil, &task.ErrTask{Err: discoverErr, Kind: task.ErrInvalidTaskOpts}
+		}
+		if len(discovered) == 0 {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("no profiles found in %q", rOpts.ProfileDir),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		profiles = discovered
 	}
-	switch verb {
-	case nib.DebugVerbosity:
-		e.Debugf(format, args...)
-	case nib.ErrorVerbosity:
-		e.Errorf(format, args...)
-	case nib.FatalVerbosity:
-		e.Fatalf(format, args...)
-	case nib.InfoVerbosity:
-		e.Infof(format, args...)
-	case nib.SuccessVerbosity:
-		e.Successf(format, args...)
-	case nib.WarnVerbosity:
-		e.Warnf(format, args...)
-	case nib.SuppressVerbosity:
-		// noop
+
+	baseDir, baseErr := e.profileBaseDir(rOpts)
+	if baseErr != nil {
+		return nil, &task.ErrTask{Err: baseErr, Kind: task.ErrInvalidTaskOpts}
 	}
 
-	//nolint:revive // Exiting is an explicit logic of this method.
-	os.Exit(code)
-}
+	if err := os.MkdirAll(rOpts.OutputDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("create output directory %q: %w", rOpts.OutputDir, err)
+	}
 
-// GetAppConfig returns an application configuration.
-// An empty application configuration is returned along with an error of type *app.ErrApp when there is no configuration
-// in the store for the given name.
-func (e *Elder) GetAppConfig(name string) (app.Config, error) {
-	ac, acErr := e.as.Get(name)
-	if acErr != nil {
-		return app.Config{}, fmt.Errorf("get %q application configuration: %w", name, acErr)
+	report := &taskGoPprof.Report{}
+	for _, profile := range profiles {
+		pr, prErr := e.profileReport(profile, baseDir, rOpts)
+		if prErr != nil {
+			return report, prErr
+		}
+		report.Profiles = append(report.Profiles, pr)
 	}
 
-	return *ac, nil
-}
+	// Store the analyzed profiles to allow to use them as base for later runs.
+	if repo, repoErr := e.gitRepository(); repoErr == nil {
+		headCommit, headErr := repo.Commit("HEAD")
+		if headErr != nil {
+			return report, &task.ErrTask{Err: headErr, Kind: task.ErrRun}
+		}
+		if err := storeProfiles(rOpts.OutputDir, headCommit, profiles); err != nil {
+			return report, &task.ErrTask{Err: fmt.Errorf("store profiles: %w", err), Kind: task.ErrRun}
+		}
+	}
 
-// GetProjectMetadata returns metadata of the project.
-func (e *Elder) GetProjectMetadata() project.Metadata {
-	return *e.project
+	summary := report.String()
+	report.SummaryFile = filepath.Join(rOpts.OutputDir, "summary.txt")
+	if err := os.WriteFile(report.SummaryFile, []byte(summary), 0o600); err != nil {
+		return report, &task.ErrTask{Err: fmt.Errorf("write %q: %w", report.SummaryFile, err), Kind: task.ErrRun}
+	}
+	e.Infof("Profile report:\n%s", summary)
+
+	return report, nil
 }
 
-// GoBuild is a task for the Go toolchain "build" command.
-// When any error occurs it will be of type *app.ErrApp or *task.ErrRunner.
+// GoTest is a task to run the Go toolchain "test" command.
+// The configured output directory for reports like coverage or benchmark profiles will be created recursively when it
+// does not exist yet.
+// When sharding or retries of failed tests are configured the tests are run through the GoTestReport task instead.
+// Configured fixtures are started before and stopped after the tests run, even when the tests failed or the process
+// received an interrupt signal.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask, *task.ErrRunner, *fixture.ErrFixture or
+// os.PathError.
 //
-// See the "github.com/svengreb/wand/pkg/task/golang/build" package for all available options.
-func (e *Elder) GoBuild(appName string, opts ...taskGoBuild.Option) error {
+// See the "github.com/svengreb/wand/pkg/task/param/golang/test" package for all available options.
+func (e *Elder) GoTest(appName string, opts ...taskGoTest.Option) error {
 	ac, acErr := e.GetAppConfig(appName)
 	if acErr != nil {
 		return fmt.Errorf("get %q application configuration: %w", appName, acErr)
 	}
 
-	return e.goRunner.Run(taskGoBuild.New(ac, opts...))
-}
-
-// Gofumpt is a task for the "mvdan.cc/gofumpt" Go module command.
-// "gofumpt" enforce a stricter format than "https://pkg.go.dev/cmd/gofmt", while being backwards compatible,
-// and provides additional rules.
-// It is a modified fork of "https://pkg.go.dev/cmd/gofmt" so it can be used as a drop-in replacement.
-// When any error occurs it will be of type *task.ErrRunner.
-//
-// See the "github.com/svengreb/wand/pkg/task/gofumpt" package for all available options.
-// See https://github.com/mvdan/gofumpt#added-rules for more details about available rules.
-//
-// See https://pkg.go.dev/mvdan.cc/gofumpt for more details about "gofumpt".
-// The source code of "gofumpt" is available at https://github.com/mvdan/gofumpt.
-func (e *Elder) Gofumpt(opts ...taskGofumpt.Option) error {
-	t, tErr := taskGofumpt.New(opts...)
+	t, tErr := taskGoTest.New(ac, opts...)
 	if tErr != nil {
-		return fmt.Errorf(`create "gofumpt" task: %w`, tErr)
+		return fmt.Errorf(`create "go/test" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoTest.Options)
+	if !ok {
+		return fmt.Errorf(`convert task options to "%T"`, taskGoTest.Options{})
 	}
 
-	return e.goToolRunner.Run(t)
-}
+	if tOpts.ShardCount > 1 || tOpts.RetryCount > 0 {
+		_, err := e.GoTestReport(appName, opts...)
+		return err
+	}
 
-// Goimports is a task for the "golang.org/x/tools/cmd/goimports" Go module command.
-// "goimports" allows to update Go import lines, add missing ones and remove unreferenced ones. It also formats code in
-// the same style as "https://pkg.go.dev/cmd/gofmt" so it can be used as a replacement.
-// When any error occurs it will be of type *task.ErrRunner.
-//
-// See the "github.com/svengreb/wand/pkg/task/goimports" package for all available options.
-//
-// See https://pkg.go.dev/golang.org/x/tools/cmd/goimports for more details about "goimports".
-// The source code of "goimports" is available at https://github.com/golang/tools/tree/master/cmd/goimports.
-func (e *Elder) Goimports(opts ...taskGoimports.Option) error {
-	t, tErr := taskGoimports.New(opts...)
-	if tErr != nil {
-		return fmt.Errorf(`create "goimports" task: %w`, tErr)
+	if err := os.MkdirAll(tOpts.OutputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create output directory %q: %w", tOpts.OutputDir, err)
 	}
 
-	return e.goToolRunner.Run(t)
+	runErr := runWithTestFixtures(tOpts.Fixtures, tOpts.FixturesTimeout, func(
+		_ context.Context, fixtureOpts ...taskGoTest.Option,
+	) error {
+		ft, ftErr := taskGoTest.New(ac, append(opts, fixtureOpts...)...)
+		if ftErr != nil {
+			return fmt.Errorf(`create "go/test" task: %w`, ftErr)
+		}
+		return e.goRunner.Run(ft)
+	})
+	// Profiles are also written when tests fail which helps to analyze the failures.
+	e.recordTestArtifacts(ac.Name, t.Name(), &tOpts)
+	return runErr
 }
 
-// GolangCILint is a task to run the "github.com/golangci/golangci-lint/cmd/golangci-lint" Go module
-// command.
-// "golangci-lint" is a fast, parallel runner for dozens of Go linters Go that uses caching, supports YAML
-// configurations and has integrations with all major IDEs.
-// When any error occurs it will be of type *task.ErrRunner.
-//
-// See the "github.com/svengreb/wand/pkg/task/golangcilint" package for all available options.
+// GoTestChanged runs the GoTest task only for the packages that are affected by the given change set.
+// A package is affected when any of its files, including embedded files and files in its "testdata" directory, changed
+// or when it depends on a package with changed files, either directly, transitively or through the imports of its
+// tests. The dependencies are resolved with the Go toolchain "list" command. When any Go module file of the main
+// modules or any Go workspace file in the project root directory changed all packages are tested.
+// The affected packages are selected from the configured packages which default to all packages within the root
+// directory of the application. The task is not run at all when no package is affected.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask, *task.ErrRunner or os.PathError.
 //
-// See https://pkg.go.dev/github.com/golangci/golangci-lint and the official website at https://golangci-lint.run for
-// more details about "golangci-lint".
-// The source code of "golangci-lint" is available at https://github.com/golangci/golangci-lint.
-func (e *Elder) GolangCILint(opts ...taskGolangCILint.Option) error {
-	t, tErr := taskGolangCILint.New(opts...)
+// See the "github.com/svengreb/wand/pkg/task/golang/test" package for all available options.
+func (e *Elder) GoTestChanged(appName string, cs *ChangeSet, opts ...taskGoTest.Option) error {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t, tErr := taskGoTest.New(ac, opts...)
 	if tErr != nil {
-		return fmt.Errorf(`create "golangci-lint" task: %w`, tErr)
+		return fmt.Errorf(`create "go/test" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoTest.Options)
+	if !ok {
+		return fmt.Errorf(`convert task options to "%T"`, taskGoTest.Options{})
+	}
+	patterns := tOpts.Pkgs
+	if len(patterns) == 0 {
+		patterns = []string{"./" + filepath.ToSlash(filepath.Join(ac.PathRel, "..."))}
 	}
 
-	return e.goToolRunner.Run(t)
+	pkgs, all, affectedErr := e.affectedGoPackages(tOpts.Env, tOpts.Tags, cs, patterns...)
+	if affectedErr != nil {
+		return affectedErr
+	}
+	switch {
+	case all:
+		e.Infof("Go module files changed since %s, testing all packages", cs.BaseRev)
+	case len(pkgs) == 0:
+		e.Infof("No packages affected by changes since %s", cs.BaseRev)
+		return nil
+	default:
+		e.Infof("Testing %d packages affected by changes since %s", len(pkgs), cs.BaseRev)
+	}
+	return e.GoTest(appName, append(opts, taskGoTest.WithPkgsReplaced(pkgs...))...)
 }
 
-// GoModUpgrade is a task for the "github.com/oligot/go-mod-upgrade" Go module command.
-// "go-mod-upgrade" allows to update outdated Go module dependencies interactively.
-// When any error occurs it will be of type *task.ErrRunner.
-//
-// See the "github.com/svengreb/wand/pkg/task/gomodupgrade" package for all available options.
+// GoTestMergeShards is a task to merge the reports of all shards of a GoTestReport run, stored at the given paths, and
+// update the history file with the merged report. Running this task once after all shards finished ensures that the
+// history file is updated exactly once per run while every shard is partitioned from the same snapshot.
+// When any error occurs it will be of type *app.ErrApp or *task.ErrTask. An error of kind taskGoTest.ErrFailed is
+// returned along with the merged report when any test or package failed on every attempt.
 //
-// See https://pkg.go.dev/github.com/oligot/go-mod-upgrade for more details about "go-mod-upgrade".
-// The source code of "go-mod-upgrade" is available at https://github.com/oligot/go-mod-upgrade.
-func (e *Elder) GoModUpgrade(opts ...taskGoModUpgrade.Option) error {
-	t, tErr := taskGoModUpgrade.New(opts...)
+// See the "github.com/svengreb/wand/pkg/task/golang/test" package for all available options.
+func (e *Elder) GoTestMergeShards(
+	appName string, reportFiles []string, opts ...taskGoTest.Option,
+) (*taskGoTest.Report, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t, tErr := taskGoTest.New(ac, opts...)
 	if tErr != nil {
-		return fmt.Errorf(`create "gomodupgrade" task: %w`, tErr)
+		return nil, fmt.Errorf(`create "go/test" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoTest.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoTest.Options{})
+	}
+	if tOpts.HistoryFile == "" {
+		tOpts.HistoryFile = filepath.Join(
+			e.project.Options().WandDataDir, taskGoTest.DefaultOutputDirName, taskGoTest.DefaultHistoryFileName,
+		)
 	}
 
-	return e.goToolRunner.Run(t)
+	reports := make([]*taskGoTest.Report, 0, len(reportFiles))
+	for _, path := range reportFiles {
+		r, err := taskGoTest.LoadReport(path)
+		if err != nil {
+			return nil, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+		reports = append(reports, r)
+	}
+	report := taskGoTest.MergeReports(reports...)
+	report.HistoryFile = tOpts.HistoryFile
+
+	history, historyErr := taskGoTest.LoadHistory(tOpts.HistoryFile)
+	if historyErr != nil {
+		return nil, &task.ErrTask{Err: historyErr, Kind: task.ErrRun}
+	}
+	history.Update(report, time.Now().UTC())
+	if err := history.Save(tOpts.HistoryFile); err != nil {
+		return report, &task.ErrTask{Err: err, Kind: task.ErrRun}
+	}
+
+	failed := len(report.Failed())
+	if failed > 0 || len(report.PkgFailures) > 0 {
+		e.Errorf("%s", report)
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d tests and %d packages failed", failed, len(report.PkgFailures)),
+			Kind: taskGoTest.ErrFailed,
+		}
+	}
+	e.Successf("%s", report)
+	return report, nil
 }
 
-// GoTest is a task to run the Go toolchain "test" command.
-// The configured output directory for reports like coverage or benchmark profiles will be created recursively when it
-// does not exist yet.
-// When any error occurs it will be of type *app.ErrApp, *task.ErrRunner or os.PathError.
+// GoTestReport is a task to run the Go toolchain "test" command with output in JSON format that is parsed into a typed
+// report with the results of all top-level tests.
+// When sharding is configured all tests are listed and split deterministically into shards that are balanced by the
+// durations of previous runs. Only the tests of the configured shard are run, separately for each package.
+// Failed tests are run again up to the configured number of retries and classified as flaky when they pass on any
+// retry. Packages that failed without any failing test, e.g. because they do not compile, are not retried.
+// The durations of the tests and the flaky tests are stored in the history file that defaults to a file within the wand
+// specific data directory. Sharded runs only read the history file so that all shards are partitioned from the same
+// snapshot and store their report in the output directory instead. The reports of all shards must be merged with the
+// GoTestMergeShards task afterwards to update the history file.
+// Configured fixtures are started before the first and stopped after the last run of the Go toolchain "test" command.
+// Note that profiles, like the coverage profile, are overwritten by each run of the Go toolchain "test" command.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask, *task.ErrRunner or *fixture.ErrFixture. An
+// error of kind taskGoTest.ErrFailed is returned along with the report when any test or package failed on every
+// attempt.
 //
-// See the "github.com/svengreb/wand/pkg/task/param/golang/test" package for all available options.
-func (e *Elder) GoTest(appName string, opts ...taskGoTest.Option) error {
+// See the "github.com/svengreb/wand/pkg/task/golang/test" package for all available options.
+func (e *Elder) GoTestReport(appName string, opts ...taskGoTest.Option) (*taskGoTest.Report, error) {
 	ac, acErr := e.GetAppConfig(appName)
 	if acErr != nil {
-		return fmt.Errorf("get %q application configuration: %w", appName, acErr)
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
 	}
 
-	t := taskGoTest.New(ac, opts...)
+	t, tErr := taskGoTest.New(ac, opts...)
+	if tErr != nil {
+		return nil, fmt.Errorf(`create "go/test" task: %w`, tErr)
+	}
 	tOpts, ok := t.Options().(taskGoTest.Options)
 	if !ok {
-		return fmt.Errorf(`convert task options to "%T"`, taskGoTest.Options{})
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoTest.Options{})
+	}
+	if tOpts.HistoryFile == "" {
+		tOpts.HistoryFile = filepath.Join(
+			e.project.Options().WandDataDir, taskGoTest.DefaultOutputDirName, taskGoTest.DefaultHistoryFileName,
+		)
 	}
 
 	if err := os.MkdirAll(tOpts.OutputDir, os.ModePerm); err != nil {
-		return fmt.Errorf("create output directory %q: %w", tOpts.OutputDir, err)
+		return nil, fmt.Errorf("create output directory %q: %w", tOpts.OutputDir, err)
+	}
+
+	history, historyErr := taskGoTest.LoadHistory(tOpts.HistoryFile)
+	if historyErr != nil {
+		return nil, &task.ErrTask{Err: historyErr, Kind: task.ErrRun}
+	}
+
+	report := &taskGoTest.Report{HistoryFile: tOpts.HistoryFile}
+	sels := []testSelection{{pkgs: tOpts.Pkgs, runPattern: tOpts.RunPattern}}
+	if tOpts.ShardCount > 1 {
+		report.ShardCount, report.ShardIndex = tOpts.ShardCount, tOpts.ShardIndex
+		var selsErr error
+		sels, selsErr = e.testShardSelections(ac, tOpts.ShardIndex, tOpts.ShardCount, history.Durations, opts...)
+		if selsErr != nil {
+			return nil, selsErr
+		}
+		if len(sels) == 0 {
+			e.Warnf("No tests assigned to shard %d/%d", tOpts.ShardIndex+1, tOpts.ShardCount)
+		}
+	}
+
+	runErr := runWithTestFixtures(tOpts.Fixtures, tOpts.FixturesTimeout, func(
+		ctx context.Context, fixtureOpts ...taskGoTest.Option,
+	) error {
+		runOpts := append(opts, fixtureOpts...)
+		for _, sel := range sels {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("run tests: %w", err)
+			}
+			results, pkgFailures, err := e.runTestSelection(ac, sel, runOpts...)
+			if err != nil {
+				return err
+			}
+			report.Tests = append(report.Tests, results...)
+			report.PkgFailures = append(report.PkgFailures, pkgFailures...)
+		}
+		return e.retryFailedTests(ctx, ac, report.Tests, tOpts.RetryCount, runOpts...)
+	})
+	e.recordTestArtifacts(ac.Name, t.Name(), &tOpts)
+	if runErr != nil {
+		return report, runErr
+	}
+
+	// Shards only store their report so that the history stays the same snapshot for all shards of a run and is
+	// updated once when the reports of all shards are merged.
+	if tOpts.ShardCount > 1 {
+		reportFile := filepath.Join(tOpts.OutputDir, taskGoTest.ShardReportFileName(tOpts.ShardIndex, tOpts.ShardCount))
+		if err := report.Save(reportFile); err != nil {
+			return report, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+		e.recordArtifacts(t.Name(), artifact.Artifact{
+			App:      ac.Name,
+			Kind:     artifact.KindReport,
+			Path:     reportFile,
+			Platform: taskGo.TargetPlatform(tOpts.Env),
+		})
+	} else {
+		history.Update(report, time.Now().UTC())
+		if err := history.Save(tOpts.HistoryFile); err != nil {
+			return report, &task.ErrTask{Err: err, Kind: task.ErrRun}
+		}
+	}
+
+	for _, res := range report.Failed() {
+		e.Errorf("%s", res.Output)
+	}
+	for _, pf := range report.PkgFailures {
+		e.Errorf("%s", pf.Output)
+	}
+	failed := len(report.Failed())
+	if failed > 0 || len(report.PkgFailures) > 0 {
+		e.Errorf("%s", report)
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d tests and %d packages failed", failed, len(report.PkgFailures)),
+			Kind: taskGoTest.ErrFailed,
+		}
+	}
+	e.Successf("%s", report)
+	return report, nil
+}
+
+// GoVulnCheck is a task for the "golang.org/x/vuln/cmd/govulncheck" Go module command.
+// "govulncheck" reports known vulnerabilities that affect Go code by using static analysis to narrow down reports to
+// only those that could affect the application.
+// The findings are parsed into a typed report and evaluated against the configured policy. Vulnerabilities that
+// violate the policy are stored in the Violations field of the returned report.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner. An error of kind
+// taskGoVulnCheck.ErrPolicyViolation is returned along with the report when any vulnerability violates the policy.
+//
+// See the "github.com/svengreb/wand/pkg/task/govulncheck" package for all available options.
+//
+// See https://pkg.go.dev/golang.org/x/vuln/cmd/govulncheck for more details about "govulncheck".
+// The source code of "govulncheck" is available at https://go.googlesource.com/vuln.
+func (e *Elder) GoVulnCheck(opts ...taskGoVulnCheck.Option) (*taskGoVulnCheck.Report, error) {
+	t, tErr := taskGoVulnCheck.New(opts...)
+	if tErr != nil {
+		return nil, fmt.Errorf(`create "govulncheck" task: %w`, tErr)
+	}
+	tOpts, ok := t.Options().(taskGoVulnCheck.Options)
+	if !ok {
+		return nil, fmt.Errorf(`convert task options to "%T"`, taskGoVulnCheck.Options{})
+	}
+
+	out, runErr := e.goToolRunner.RunOut(t)
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	report, parseErr := taskGoVulnCheck.ParseOutput(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf(`parse "govulncheck" output: %w`, parseErr),
+			Kind: task.ErrRun,
+		}
+	}
+
+	report.Violations = tOpts.Policy.Evaluate(report)
+	if len(report.Violations) > 0 {
+		ids := make([]string, 0, len(report.Violations))
+		for _, v := range report.Violations {
+			ids = append(ids, v.ID)
+		}
+		return report, &task.ErrTask{
+			Err:  fmt.Errorf("%d vulnerabilities: %s", len(ids), strings.Join(ids, ", ")),
+			Kind: taskGoVulnCheck.ErrPolicyViolation,
+		}
 	}
 
-	return e.goRunner.Run(t)
+	return report, nil
 }
 
 // Gox is a task to run the "github.com/mitchellh/gox" Go module command.
 // "gox" is a dead simple, no frills Go cross compile tool that behaves a lot like the standard Go toolchain "build"
 // command.
-// When any error occurs it will be of type *app.ErrApp or *task.ErrRunner.
+// When cgo is enabled the C toolchains of all cross-compile platform targets are validated before "gox" is run once per
+// platform target so that each uses its own C toolchain.
+// The run stops at the first platform target that fails to build and the binaries are only recorded as artifacts when
+// all platform targets have been built successfully.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner, e.g. when no C
+// cross-compiler is configured for a cgo-enabled platform target.
 //
 // See the "github.com/svengreb/wand/pkg/task/gox" package for all available options.
 //
@@ -286,8 +1890,66 @@ func (e *Elder) Gox(appName string, opts ...taskGox.Option) error {
 	if tErr != nil {
 		return fmt.Errorf(`create "gox" task: %w`, tErr)
 	}
+	tOpts, ok := t.Options().(taskGox.Options)
+	if !ok {
+		return fmt.Errorf(`convert task options to "%T"`, taskGox.Options{})
+	}
+	if tOpts.Cgo != nil && tOpts.Cgo.IsEnabled() {
+		for _, platform := range tOpts.CrossCompileTargetPlatforms {
+			if err := tOpts.Cgo.ValidateToolchain(platform); err != nil {
+				return &task.ErrTask{Err: err, Kind: task.ErrInvalidTaskOpts}
+			}
+		}
+		for _, platform := range tOpts.CrossCompileTargetPlatforms {
+			if err := e.goToolRunner.Run(t.ForPlatform(platform)); err != nil {
+				return err
+			}
+		}
+	} else if err := e.goToolRunner.Run(t); err != nil {
+		return err
+	}
 
-	return e.goToolRunner.Run(t)
+	var artifacts []artifact.Artifact
+	for _, platform := range tOpts.CrossCompileTargetPlatforms {
+		p, err := t.OutputPath(platform)
+		if err != nil {
+			e.Warnf("Failed to determine binary artifact of platform %q: %v", platform, err)
+			continue
+		}
+		artifacts = append(artifacts, artifact.Artifact{
+			App:      ac.Name,
+			Kind:     artifact.KindBinary,
+			Path:     p,
+			Platform: platform,
+		})
+	}
+	e.recordArtifacts(t.Name(), artifacts...)
+	return nil
+}
+
+// OCIImage is a task to assemble an OCI image from the binary artifacts of an application and write it as OCI image
+// layout to disk without the need for a container engine like a Docker daemon.
+// The binary artifacts must have been built before, e.g. with [*Elder.GoBuild] or, for multi-platform images, with
+// [*Elder.Gox]. The image labels for the version and revision are populated from the project metadata.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/oci" package for all available options.
+func (e *Elder) OCIImage(appName string, opts ...taskOCI.Option) (*taskOCI.Result, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskOCI.New(e.GetProjectMetadata(), ac, opts...)
+	res, err := t.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	e.Successf("Assembled OCI image %s (%s) for %d platforms in %s", res.RefName, res.Descriptor.Digest,
+		len(res.Manifests), res.OutputDir)
+	e.recordArtifacts(t.Name(), artifact.Artifact{App: ac.Name, Kind: artifact.KindArchive, Path: res.OutputDir})
+	return res, nil
 }
 
 // RegisterApp creates and stores a new application configuration.
@@ -340,6 +2002,88 @@ func (e *Elder) RegisterApp(name, displayName, pathRel string) error {
 	return nil
 }
 
+// RunGoTool is a task to run an arbitrary Go module command, e.g. "golang.org/x/tools/cmd/stringer" or
+// "github.com/golang/mock/mockgen", through the Go module-based tool runner.
+// The import path must be a valid Go module import path, that can optionally include the version suffix in the
+// "pkg@version" format, while the given arguments are passed to the command.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See [*Elder.RunGoToolWith] to also set the environment and working directory of the command.
+func (e *Elder) RunGoTool(importPath string, args ...string) error {
+	return e.RunGoToolWith(importPath, taskGoToolGeneric.WithArgs(args...))
+}
+
+// RunGoToolOut is like [*Elder.RunGoTool] but captures and returns the output of the command.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+func (e *Elder) RunGoToolOut(importPath string, args ...string) (string, error) {
+	return e.RunGoToolOutWith(importPath, taskGoToolGeneric.WithArgs(args...))
+}
+
+// RunGoToolOutWith is like [*Elder.RunGoToolWith] but captures and returns the output of the command.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/gotool/generic" package for all available options.
+func (e *Elder) RunGoToolOutWith(importPath string, opts ...taskGoToolGeneric.Option) (string, error) {
+	t, tErr := taskGoToolGeneric.New(importPath, opts...)
+	if tErr != nil {
+		return "", fmt.Errorf("create task for %q: %w", importPath, tErr)
+	}
+
+	return e.goToolRunner.RunOut(t)
+}
+
+// RunGoToolWith is a task to run an arbitrary Go module command through the Go module-based tool runner.
+// The import path must be a valid Go module import path, that can optionally include the version suffix in the
+// "pkg@version" format.
+// When any error occurs it will be of type *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/gotool/generic" package for all available options.
+func (e *Elder) RunGoToolWith(importPath string, opts ...taskGoToolGeneric.Option) error {
+	t, tErr := taskGoToolGeneric.New(importPath, opts...)
+	if tErr != nil {
+		return fmt.Errorf("create task for %q: %w", importPath, tErr)
+	}
+
+	return e.goToolRunner.Run(t)
+}
+
+// SBOM is a task to generate software bills of materials in the CycloneDX and SPDX JSON formats for the binary
+// artifacts of an application.
+// The binary artifacts must have been built before, e.g. with [*Elder.GoBuild] or [*Elder.Gox]. The documents are
+// written next to the binary artifacts and include the modules compiled into them with their versions and checksums
+// as well as the project version.
+// When any error occurs it will be of type *app.ErrApp, *task.ErrTask or *task.ErrRunner.
+//
+// See the "github.com/svengreb/wand/pkg/task/sbom" package for all available options.
+func (e *Elder) SBOM(appName string, opts ...taskSBOM.Option) ([]taskSBOM.Result, error) {
+	ac, acErr := e.GetAppConfig(appName)
+	if acErr != nil {
+		return nil, fmt.Errorf("get %q application configuration: %w", appName, acErr)
+	}
+
+	t := taskSBOM.New(e.GetProjectMetadata(), ac, opts...)
+	results, err := t.Generate()
+	if err != nil {
+		return results, err
+	}
+
+	var artifacts []artifact.Artifact
+	for _, res := range results {
+		e.Successf("Generated software bill of materials for %s with %d modules", res.Artifact.Name,
+			len(res.Artifact.Deps))
+		for _, file := range res.Files {
+			artifacts = append(artifacts, artifact.Artifact{
+				App:      ac.Name,
+				Kind:     artifact.KindReport,
+				Path:     file,
+				Platform: res.Artifact.Platform(),
+			})
+		}
+	}
+	e.recordArtifacts(t.Name(), artifacts...)
+	return results, nil
+}
+
 // Validate ensures that the wand is properly initialized and operational.
 // Optionally pass the [task.Runner] that should be validated or nothing to validate all currently supported runners.
 // It returns a slice of errors that occurred during the execution.
@@ -417,3 +2161,4 @@ func New(opts ...Option) (*Elder, error) {
 
 	return e, nil
 }
+
@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrNonCompliantFormat indicates that the formatting of files is not compliant to the style guide.
+const ErrNonCompliantFormat = wErr.ErrString("formatting is not compliant")
@@ -0,0 +1,171 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/svengreb/wand/internal/support/diff"
+	"github.com/svengreb/wand/pkg/task"
+	taskGofumpt "github.com/svengreb/wand/pkg/task/gofumpt"
+	taskGoimports "github.com/svengreb/wand/pkg/task/goimports"
+)
+
+// FormatOption is a option for the orchestration of formatters.
+type FormatOption func(*FormatOptions)
+
+// FormatOptions are options for the orchestration of formatters.
+type FormatOptions struct {
+	// EnableFix indicates whether non-compliant files should be fixed and verified again.
+	EnableFix bool
+
+	// GofumptOptions are additional options for the "gofumpt" task.
+	GofumptOptions []taskGofumpt.Option
+
+	// GoimportsOptions are additional options for the "goimports" task.
+	GoimportsOptions []taskGoimports.Option
+
+	// Paths are the paths to search for Go source files.
+	// By default all directories are scanned recursively starting from the working directory of the current process.
+	Paths []string
+}
+
+// NewFormatOptions creates new options for the orchestration of formatters.
+func NewFormatOptions(opts ...FormatOption) *FormatOptions {
+	opt := &FormatOptions{}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithFormatFix indicates whether non-compliant files should be fixed and verified again.
+func WithFormatFix(enableFix bool) FormatOption {
+	return func(o *FormatOptions) {
+		o.EnableFix = enableFix
+	}
+}
+
+// WithFormatGofumptOptions sets additional options for the "gofumpt" task.
+func WithFormatGofumptOptions(opts ...taskGofumpt.Option) FormatOption {
+	return func(o *FormatOptions) {
+		o.GofumptOptions = append(o.GofumptOptions, opts...)
+	}
+}
+
+// WithFormatGoimportsOptions sets additional options for the "goimports" task.
+func WithFormatGoimportsOptions(opts ...taskGoimports.Option) FormatOption {
+	return func(o *FormatOptions) {
+		o.GoimportsOptions = append(o.GoimportsOptions, opts...)
+	}
+}
+
+// WithFormatPaths sets the paths to search for Go source files.
+func WithFormatPaths(paths ...string) FormatOption {
+	return func(o *FormatOptions) {
+		o.Paths = append(o.Paths, paths...)
+	}
+}
+
+// FormatFile is a file whose formatting is not compliant to at least one formatter.
+type FormatFile struct {
+	// Diffs are the unified diffs of the changes required by each formatter, keyed by the formatter name.
+	Diffs map[string]string
+
+	// Path is the path to the file.
+	Path string
+}
+
+// Formatters returns the names of all formatters the file is not compliant to, sorted by name.
+func (f FormatFile) Formatters() []string {
+	names := make([]string, 0, len(f.Diffs))
+	for name := range f.Diffs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatReport is the report of the orchestration of formatters.
+type FormatReport struct {
+	// Files are all files whose formatting is not compliant, sorted by path.
+	// When fixing is enabled these are the files that are still not compliant after fixing.
+	Files []FormatFile
+
+	// Fixed are the paths of all files that have been fixed.
+	Fixed []string
+}
+
+// Paths returns the paths of all non-compliant files.
+func (r *FormatReport) Paths() []string {
+	paths := make([]string, 0, len(r.Files))
+	for _, f := range r.Files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+// formatDiffs runs all formatters in diff mode and returns all files whose formatting is not compliant.
+func (e *Elder) formatDiffs(fOpts *FormatOptions) ([]FormatFile, error) {
+	gofumptTask, gofumptErr := taskGofumpt.New(append(
+		fOpts.GofumptOptions, taskGofumpt.WithDiff(true), taskGofumpt.WithPaths(fOpts.Paths...),
+	)...)
+	if gofumptErr != nil {
+		return nil, gofumptErr
+	}
+	goimportsTask, goimportsErr := taskGoimports.New(append(
+		fOpts.GoimportsOptions, taskGoimports.WithDiff(true), taskGoimports.WithPaths(fOpts.Paths...),
+	)...)
+	if goimportsErr != nil {
+		return nil, goimportsErr
+	}
+
+	files := make(map[string]*FormatFile)
+	for _, t := range []task.GoModule{gofumptTask, goimportsTask} {
+		out, runErr := e.goToolRunner.RunOut(t)
+		diffs := diff.SplitFiles(out)
+		// Some formatters indicate diffs through a non-zero exit code so only fail when no diff has been printed.
+		if runErr != nil && len(diffs) == 0 {
+			return nil, runErr
+		}
+		for _, d := range diffs {
+			f, ok := files[d.Path]
+			if !ok {
+				f = &FormatFile{Diffs: make(map[string]string), Path: d.Path}
+				files[d.Path] = f
+			}
+			f.Diffs[t.Name()] = d.Diff
+		}
+	}
+
+	result := make([]FormatFile, 0, len(files))
+	for _, f := range files {
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// formatFix fixes the formatting of the given files by running all formatters with persisted changes.
+// The "goimports" task runs first so that "gofumpt", which enforces a stricter format, has the final say.
+func (e *Elder) formatFix(fOpts *FormatOptions, paths []string) error {
+	if err := e.Goimports(append(
+		fOpts.GoimportsOptions, taskGoimports.WithPersistedChanges(true), taskGoimports.WithPaths(paths...),
+	)...); err != nil {
+		return err
+	}
+	return e.Gofumpt(append(
+		fOpts.GofumptOptions, taskGofumpt.WithPersistedChanges(true), taskGofumpt.WithPaths(paths...),
+	)...)
+}
+
+// formatSummary returns a human-readable summary of all non-compliant files.
+func formatSummary(files []FormatFile) string {
+	lines := make([]string, 0, len(files))
+	for _, f := range files {
+		lines = append(lines, f.Path+" ("+strings.Join(f.Formatters(), ", ")+")")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/svengreb/wand/pkg/app"
+	taskGoFuzz "github.com/svengreb/wand/pkg/task/golang/fuzz"
+)
+
+// fuzzTarget runs the given fuzz target and copies all failing inputs that have been added to the seed corpus of the
+// package into the output directory. The inputs are only removed from the seed corpus when corpus copies are disabled.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func (e *Elder) fuzzTarget(
+	ac app.Config, target taskGoFuzz.Target, tOpts *taskGoFuzz.Options, opts []taskGoFuzz.Option,
+) taskGoFuzz.TargetResult {
+	res := taskGoFuzz.TargetResult{Target: target}
+	corpusDir := target.CorpusDir()
+
+	before, beforeErr := corpusFiles(corpusDir)
+	if beforeErr != nil {
+		res.Error = beforeErr.Error()
+		return res
+	}
+
+	t, tErr := taskGoFuzz.New(ac, target, opts...)
+	if tErr != nil {
+		res.Error = tErr.Error()
+		return res
+	}
+	start := time.Now()
+	out, runErr := e.goRunner.RunOut(t)
+	res.Duration = time.Since(start)
+	res.Output = out
+
+	after, afterErr := corpusFiles(corpusDir)
+	if afterErr != nil {
+		res.Error = afterErr.Error()
+		return res
+	}
+	var added []string
+	for name := range after {
+		if !before[name] {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+
+	outputDir := filepath.Join(tOpts.OutputDir, filepath.FromSlash(target.Pkg), target.Name)
+	for _, name := range added {
+		c := taskGoFuzz.Crasher{CorpusPath: filepath.Join(corpusDir, name), Path: filepath.Join(outputDir, name)}
+		if err := copyFile(c.CorpusPath, c.Path); err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		if !tOpts.EnableCorpusCopy {
+			if err := os.Remove(c.CorpusPath); err != nil {
+				res.Error = fmt.Sprintf("remove failing input from seed corpus: %v", err)
+				return res
+			}
+		}
+		res.Crashers = append(res.Crashers, c)
+	}
+
+	// Remove seed corpus directories that only have been created for failing inputs which are not kept.
+	// Directories that are not empty are not removed so errors can be safely ignored.
+	if !tOpts.EnableCorpusCopy && len(before) == 0 {
+		for dir := corpusDir; dir != target.Dir; dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+
+	if runErr != nil && len(res.Crashers) == 0 {
+		res.Error = runErr.Error()
+	}
+	return res
+}
+
+// copyFile copies the file at the given source path to the given destination path and creates all parent directories.
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(dst), err)
+	}
+
+	in, openErr := os.Open(src)
+	if openErr != nil {
+		return fmt.Errorf("open %q: %w", src, openErr)
+	}
+	defer func() { _ = in.Close() }()
+
+	//nolint:gosec // Copied files, like seed corpus entries or profiles, are meant to be committed and shared.
+	out, createErr := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if createErr != nil {
+		return fmt.Errorf("create %q: %w", dst, createErr)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copy %q to %q: %w", src, dst, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("close %q: %w", dst, err)
+	}
+	return nil
+}
+
+// corpusFiles returns the names of all files within the given seed corpus directory.
+// An empty set is returned when the directory does not exist.
+func corpusFiles(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("read seed corpus directory %q: %w", dir, err)
+	}
+
+	files := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files[entry.Name()] = true
+		}
+	}
+	return files, nil
+}
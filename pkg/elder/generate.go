@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	shSupport "github.com/svengreb/wand/internal/support/sh"
+	"github.com/svengreb/wand/pkg/task"
+	taskGoGenerate "github.com/svengreb/wand/pkg/task/golang/generate"
+)
+
+// checkGenerate processes the given directives and fails with an error of kind taskGoGenerate.ErrOutdated when
+// regenerating changed tracked files or created untracked files within the working tree of the given snapshot.
+// All changes are reverted afterwards to leave the working tree untouched, also when processing the directives failed.
+func (e *Elder) checkGenerate(
+	t *taskGoGenerate.Task,
+	tOpts taskGoGenerate.Options,
+	res *taskGoGenerate.Result,
+	aliases map[string][]string,
+	resolvable bool,
+	snapshot *worktreeSnapshot,
+) error {
+	genErr := e.generate(t, tOpts, res.Directives, aliases, resolvable)
+
+	changed, changesErr := snapshot.changes()
+	if changesErr != nil {
+		_ = snapshot.restore(nil)
+		return &task.ErrTask{
+			Err:  fmt.Errorf("compare working tree for check mode: %w", changesErr),
+			Kind: task.ErrTaskValidation,
+		}
+	}
+	if err := snapshot.restore(changed); err != nil {
+		return &task.ErrTask{
+			Err:  fmt.Errorf("revert changes of check mode: %w", err),
+			Kind: task.ErrTaskValidation,
+		}
+	}
+	if genErr != nil {
+		return genErr
+	}
+
+	res.Changed = changed
+	if len(res.Changed) > 0 {
+		return &task.ErrTask{
+			Err:  fmt.Errorf("regenerating changed %d file(s): %s", len(res.Changed), strings.Join(res.Changed, ", ")),
+			Kind: taskGoGenerate.ErrOutdated,
+		}
+	}
+	return nil
+}
+
+// directiveRunPattern returns a regular expression for the run pattern of the Go "generate" command that matches the
+// full original source text of the given directive and command alias directives.
+// Note that the pattern also matches all directives of the same file with an identical source text.
+func directiveRunPattern(aliases []string, d taskGoGenerate.Directive) string {
+	return fmt.Sprintf("^(%s)$", strings.Join(append(append([]string{}, aliases...), quoteDirective(d)), "|"))
+}
+
+// generate processes the given directives.
+// The Go toolchain processes all directives at once when there are no generators to resolve, otherwise directives are
+// processed one by one to keep their order while running resolved generators directly.
+func (e *Elder) generate(
+	t *taskGoGenerate.Task,
+	tOpts taskGoGenerate.Options,
+	directives []taskGoGenerate.Directive,
+	aliases map[string][]string,
+	resolvable bool,
+) error {
+	if !resolvable {
+		return e.goRunner.Run(t)
+	}
+
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+	if v, ok := tOpts.Env["GOOS"]; ok {
+		goos = v
+	}
+	if v, ok := tOpts.Env["GOARCH"]; ok {
+		goarch = v
+	}
+
+	// The run pattern of a directive also matches all identical directives of the same file which are therefore
+	// processed by a single run of the Go toolchain.
+	processed := make(map[[2]string]bool)
+	for _, d := range directives {
+		gm, args, isGoRun := d.GoRunModule()
+		if !isGoRun {
+			if processed[[2]string{d.File, d.Text}] {
+				continue
+			}
+			processed[[2]string{d.File, d.Text}] = true
+			if err := e.goRunner.Run(t.ForFile(d.File, directiveRunPattern(aliases[d.File], d))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tOpts.EnableDryRun || tOpts.EnablePrintCommands {
+			e.Infof("%s", strings.Join(d.Args, " "))
+		}
+		if tOpts.EnableDryRun {
+			continue
+		}
+
+		execPath, execErr := e.goToolRunner.Executable(gm)
+		if execErr != nil {
+			return execErr
+		}
+		env := d.Env(goos, goarch)
+		for k, v := range tOpts.Env {
+			env[k] = v
+		}
+		if err := shSupport.RunWith(env, filepath.Dir(d.File), execPath, args...); err != nil {
+			return &task.ErrRunner{
+				Err:  fmt.Errorf("run generator of %s:%d: %w", d.File, d.Line, err),
+				Kind: task.ErrRun,
+			}
+		}
+	}
+	return nil
+}
+
+// quoteDirective returns a regular expression that matches the full original source text of the given directive.
+// The dollar sign is escaped using its hexadecimal representation since runners expand environment variables in
+// command arguments.
+func quoteDirective(d taskGoGenerate.Directive) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(d.Text), `\$`, `\x24`)
+}
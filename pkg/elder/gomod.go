@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/svengreb/wand/internal/support/diff"
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+)
+
+// goModFileNames are the names of the files that are managed by Go module commands.
+var goModFileNames = []string{project.GoModuleDefaultFileName, "go.sum"}
+
+// goModuleDirs returns the paths of all Go module directories of the project.
+func (e *Elder) goModuleDirs() ([]string, error) {
+	dirs, err := project.GoModuleDirs(e.project.Options().RootDirPathAbs)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no Go modules in project root directory %q", e.project.Options().RootDirPathAbs)
+	}
+	return dirs, nil
+}
+
+// goListModules lists the modules matching the given patterns with information about available updates.
+// Erroneous modules, e.g. when a module proxy does not provide information about a module, are reported in the Error
+// field of the module instead of failing the command.
+func (e *Elder) goListModules(env map[string]string, dir string, patterns ...string) ([]taskGoList.Module, error) {
+	t := taskGoList.New(
+		taskGoList.WithEnv(env),
+		taskGoList.WithErrors(true),
+		taskGoList.WithJSON(true),
+		taskGoList.WithModules(true),
+		taskGoList.WithPatterns(patterns...),
+		taskGoList.WithUpdates(true),
+		taskGoList.WithWorkingDir(dir),
+	)
+	out, runErr := e.goRunner.RunOut(t)
+	if runErr != nil {
+		return nil, runErr
+	}
+	modules, parseErr := taskGoList.ParseModules(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("parse %q output: %w", t.Name(), parseErr),
+			Kind: task.ErrRun,
+		}
+	}
+	return modules, nil
+}
+
+// goListPackages lists the packages matching the given patterns with the given additional options.
+// Erroneous packages are reported in the Error field of the package instead of failing the command.
+func (e *Elder) goListPackages(
+	env map[string]string, dir string, patterns []string, opts ...taskGoList.Option,
+) ([]taskGoList.Package, error) {
+	t := taskGoList.New(append([]taskGoList.Option{
+		taskGoList.WithEnv(env),
+		taskGoList.WithErrors(true),
+		taskGoList.WithJSON(true),
+		taskGoList.WithPatterns(patterns...),
+		taskGoList.WithWorkingDir(dir),
+	}, opts...)...)
+	out, runErr := e.goRunner.RunOut(t)
+	if runErr != nil {
+		return nil, runErr
+	}
+	pkgs, parseErr := taskGoList.ParsePackages(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("parse %q output: %w", t.Name(), parseErr),
+			Kind: task.ErrRun,
+		}
+	}
+	return pkgs, nil
+}
+
+// projectRelPath returns the given path relative to the project root directory or the path itself when it cannot be
+// made relative.
+func (e *Elder) projectRelPath(path string) string {
+	rel, err := filepath.Rel(e.project.Options().RootDirPathAbs, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// goModFilesDiff returns the unified diff of the Go module files between the given snapshots.
+func (e *Elder) goModFilesDiff(dir string, before, after map[string][]byte) string {
+	var d string
+	for _, name := range goModFileNames {
+		path := e.projectRelPath(filepath.Join(dir, name))
+		d += diff.Unified("a/"+path, "b/"+path, string(before[name]), string(after[name]))
+	}
+	return d
+}
+
+// readGoModFiles returns a snapshot of the Go module files in the given directory.
+// Files that do not exist are not included.
+func readGoModFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, name := range goModFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("read Go module file: %w", err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// restoreGoModFiles restores the Go module files in the given directory from the given snapshot.
+// Files that are not part of the snapshot are removed.
+func restoreGoModFiles(dir string, files map[string][]byte) error {
+	for _, name := range goModFileNames {
+		path := filepath.Join(dir, name)
+		data, ok := files[name]
+		if !ok {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("remove Go module file: %w", err)
+			}
+			continue
+		}
+		// Keep the permissions of the existing file and only fall back to restrictive ones when it has been removed.
+		perm := os.FileMode(0o600)
+		if fi, statErr := os.Stat(path); statErr == nil {
+			perm = fi.Mode().Perm()
+		}
+		if err := os.WriteFile(path, data, perm); err != nil {
+			return fmt.Errorf("restore Go module file: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+	"github.com/svengreb/wand/pkg/task/golang/test/fixture"
+)
+
+// testSelection is a selection of tests that is run with a single Go toolchain "test" command.
+type testSelection struct {
+	pkgs       []string
+	runPattern string
+}
+
+// runWithTestFixtures runs the given function while the given fixtures are running.
+// The fixtures are started before and stopped after the function has been run, even when it failed, the given timeout
+// has been exceeded or the process received an interrupt signal. The function is called with a context that is
+// canceled in these cases and an option that passes the environment variables of the fixtures to the tests. It is run
+// right away when no fixtures are given. There is no timeout when the given timeout is zero.
+func runWithTestFixtures(
+	fixtures []fixture.Fixture, timeout time.Duration, fn func(ctx context.Context, opts ...taskGoTest.Option) error,
+) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if len(fixtures) == 0 {
+		return fn(ctx)
+	}
+	return fixture.NewGroup(fixtures...).Run(ctx, func(ctx context.Context, env map[string]string) error {
+		return fn(ctx, taskGoTest.WithGoOptions(taskGo.WithEnv(env)))
+	})
+}
+
+// runTestSelection runs the given selection of tests and returns the results of all top-level tests and the packages
+// that failed without any failing test.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func (e *Elder) runTestSelection(
+	ac app.Config, sel testSelection, opts ...taskGoTest.Option,
+) ([]taskGoTest.TestResult, []taskGoTest.PkgFailure, error) {
+	t, tErr := taskGoTest.NewAttempt(ac, sel.pkgs, sel.runPattern, opts...)
+	if tErr != nil {
+		return nil, nil, fmt.Errorf(`create "go/test" task: %w`, tErr)
+	}
+	out, runErr := e.goRunner.RunOut(t)
+	events, parseErr := taskGoTest.ParseEvents(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, nil, &task.ErrTask{Err: fmt.Errorf("parse %q output: %w", t.Name(), parseErr), Kind: task.ErrRun}
+	}
+	// Failing tests are expected to cause an error, but without any event the command itself failed to run.
+	if runErr != nil && len(events) == 0 {
+		e.Errorf("%s", out)
+		return nil, nil, runErr
+	}
+	results, pkgFailures := taskGoTest.Results(events)
+	return results, pkgFailures, nil
+}
+
+// retryFailedTests runs all failed tests of the given results again, grouped by package, until they pass or the given
+// maximum number of retries is reached. Tests that pass on any retry are classified as flaky.
+// No further retries are started when the given context is done.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func (e *Elder) retryFailedTests(
+	ctx context.Context, ac app.Config, results []taskGoTest.TestResult, retryCount int, opts ...taskGoTest.Option,
+) error {
+	for retry := 1; retry <= retryCount; retry++ {
+		failed := make(map[string][]string)
+		var pkgs []string
+		for _, res := range results {
+			if res.Status != taskGoTest.StatusFail {
+				continue
+			}
+			if _, ok := failed[res.Pkg]; !ok {
+				pkgs = append(pkgs, res.Pkg)
+			}
+			failed[res.Pkg] = append(failed[res.Pkg], res.Name)
+		}
+		if len(pkgs) == 0 {
+			return nil
+		}
+
+		retried := make(map[string]taskGoTest.TestResult)
+		for _, pkg := range pkgs {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("retry failed tests: %w", err)
+			}
+			e.Infof("Retrying %d failed tests of %s (%d/%d)", len(failed[pkg]), pkg, retry, retryCount)
+			sel := testSelection{pkgs: []string{pkg}, runPattern: taskGoTest.RunPatternOf(failed[pkg]...)}
+			pkgResults, _, err := e.runTestSelection(ac, sel, opts...)
+			if err != nil {
+				return err
+			}
+			for _, res := range pkgResults {
+				retried[res.Key()] = res
+			}
+		}
+
+		for i, res := range results {
+			if res.Status != taskGoTest.StatusFail {
+				continue
+			}
+			results[i].Attempts++
+			r, ok := retried[res.Key()]
+			switch {
+			case !ok:
+				continue
+			case r.Status == taskGoTest.StatusPass:
+				results[i].Elapsed = r.Elapsed
+				results[i].Status = taskGoTest.StatusFlaky
+			case r.Status == taskGoTest.StatusFail:
+				results[i].Elapsed = r.Elapsed
+				results[i].Output = r.Output
+			}
+		}
+	}
+	return nil
+}
+
+// testShardSelections returns the selections of tests of the shard with the given index.
+// All tests are listed and split deterministically into shards balanced by the given durations. The tests of the shard
+// are grouped by package since a run pattern applies to all packages of a single Go toolchain "test" command.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func (e *Elder) testShardSelections(
+	ac app.Config, index, count int, durations map[string]time.Duration, opts ...taskGoTest.Option,
+) ([]testSelection, error) {
+	t, tErr := taskGoTest.NewList(ac, opts...)
+	if tErr != nil {
+		return nil, fmt.Errorf(`create "go/test" list task: %w`, tErr)
+	}
+	out, runErr := e.goRunner.RunOut(t)
+	if runErr != nil {
+		e.Errorf("%s", out)
+		return nil, runErr
+	}
+	tests, parseErr := taskGoTest.ParseList(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, &task.ErrTask{Err: fmt.Errorf("parse %q output: %w", t.Name(), parseErr), Kind: task.ErrRun}
+	}
+
+	shard := taskGoTest.Shard(tests, durations, count)[index]
+	var sels []testSelection
+	names := make(map[string][]string)
+	for _, test := range shard {
+		if _, ok := names[test.Pkg]; !ok {
+			sels = append(sels, testSelection{pkgs: []string{test.Pkg}})
+		}
+		names[test.Pkg] = append(names[test.Pkg], test.Name)
+	}
+	for i := range sels {
+		sels[i].runPattern = taskGoTest.RunPatternOf(names[sels[i].pkgs[0]]...)
+	}
+	return sels, nil
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	glFS "github.com/svengreb/golib/pkg/io/fs"
+)
+
+const (
+	// DefaultGitHookMageExec is the default Mage executable that is invoked by Git hooks.
+	DefaultGitHookMageExec = "mage"
+
+	// GitHookPreCommit is the name of the Git "pre-commit" hook.
+	GitHookPreCommit = "pre-commit"
+
+	// GitHookPrePush is the name of the Git "pre-push" hook.
+	GitHookPrePush = "pre-push"
+
+	// gitHookChainedSuffix is the file name suffix of existing user hooks that are chained by hooks managed by wand.
+	gitHookChainedSuffix = ".wand-chained"
+
+	// gitHookMarker is the line that identifies hooks managed by wand.
+	gitHookMarker = "# Managed by wand, changes are overwritten when the hook is installed again."
+
+	// gitHookTargetsPrefix is the line prefix for the Mage targets that are run by hooks managed by wand.
+	gitHookTargetsPrefix = "# wand:targets="
+)
+
+// gitHookNames are the names of all supported Git hooks.
+var gitHookNames = []string{GitHookPreCommit, GitHookPrePush}
+
+// GitHookOption is a option for Git hooks.
+type GitHookOption func(*GitHookOptions)
+
+// GitHookOptions are options for Git hooks.
+type GitHookOptions struct {
+	// MageExec is the Mage executable that is invoked by Git hooks.
+	MageExec string
+
+	// Targets are the Mage targets that are run by Git hooks mapped by the hook name.
+	Targets map[string][]string
+}
+
+// NewGitHookOptions creates new options for Git hooks.
+func NewGitHookOptions(opts ...GitHookOption) *GitHookOptions {
+	opt := &GitHookOptions{
+		MageExec: DefaultGitHookMageExec,
+		Targets:  make(map[string][]string),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithGitHookMageExec sets the Mage executable that is invoked by Git hooks.
+// Defaults to DefaultGitHookMageExec.
+func WithGitHookMageExec(mageExec string) GitHookOption {
+	return func(o *GitHookOptions) {
+		if mageExec != "" {
+			o.MageExec = mageExec
+		}
+	}
+}
+
+// WithGitHookTargets adds Mage targets that are run in the given order by the Git hook with the given name, e.g.
+// GitHookPreCommit.
+func WithGitHookTargets(hook string, targets ...string) GitHookOption {
+	return func(o *GitHookOptions) {
+		o.Targets[hook] = append(o.Targets[hook], targets...)
+	}
+}
+
+// GitHookStatus is the status of a Git hook.
+type GitHookStatus struct {
+	// Chained indicates whether a existing user hook is chained by the hook managed by wand.
+	Chained bool
+
+	// Exists indicates whether the hook file exists.
+	Exists bool
+
+	// Managed indicates whether the hook is managed by wand.
+	Managed bool
+
+	// Name is the name of the hook.
+	Name string
+
+	// Path is the path to the hook file.
+	Path string
+
+	// Targets are the Mage targets that are run by the hook when it is managed by wand.
+	Targets []string
+}
+
+// String returns a human-readable representation of the hook status.
+func (s GitHookStatus) String() string {
+	switch {
+	case s.Managed && s.Chained:
+		return fmt.Sprintf("%s: managed by wand, runs %s after chained user hook", s.Name, strings.Join(s.Targets, ", "))
+	case s.Managed:
+		return fmt.Sprintf("%s: managed by wand, runs %s", s.Name, strings.Join(s.Targets, ", "))
+	case s.Exists:
+		return fmt.Sprintf("%s: not managed by wand", s.Name)
+	default:
+		return fmt.Sprintf("%s: not installed", s.Name)
+	}
+}
+
+// gitHooksDir returns the absolute path to the Git hooks directory and the path of the project root directory relative
+// to the root directory of the Git working tree.
+func (e *Elder) gitHooksDir() (string, string, error) {
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return "", "", repoErr
+	}
+
+	hooksDir, hooksDirErr := repo.HooksDir()
+	if hooksDirErr != nil {
+		return "", "", hooksDirErr
+	}
+	rootDir, rootDirErr := repo.RootDir()
+	if rootDirErr != nil {
+		return "", "", rootDirErr
+	}
+	projectDir, projectDirErr := filepath.EvalSymlinks(e.project.Options().RootDirPathAbs)
+	if projectDirErr != nil {
+		return "", "", fmt.Errorf("resolve project root directory: %w", projectDirErr)
+	}
+	rel, relErr := filepath.Rel(rootDir, projectDir)
+	if relErr != nil || isOutsideDir(rel) {
+		return "", "", fmt.Errorf("project root directory %q is not part of Git working tree %q", projectDir, rootDir)
+	}
+	return hooksDir, rel, nil
+}
+
+// gitHookScript returns the content of a Git hook that runs the given Mage targets from within the project root
+// directory after running the chained user hook, if any.
+// The standard input of hooks that receive data from Git, like the refs to push for the "pre-push" hook, is read once
+// and passed to both the chained user hook and the Mage targets.
+func gitHookScript(name, projectRelDir, mageExec string, targets []string) string {
+	readsStdin := gitHookReadsStdin(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#!/bin/sh\n%s\n%s%s\nset -e\n\n", gitHookMarker, gitHookTargetsPrefix, strings.Join(targets, " "))
+	if readsStdin {
+		b.WriteString("stdin=\"$(cat)\"\n")
+		b.WriteString("replay_stdin() {\n\tif [ -n \"$stdin\" ]; then\n\t\tprintf '%s\\n' \"$stdin\"\n\tfi\n}\n\n")
+	}
+	fmt.Fprintf(&b, "chained=\"$(dirname \"$0\")/%s%s\"\n", name, gitHookChainedSuffix)
+	if readsStdin {
+		b.WriteString("if [ -x \"$chained\" ]; then\n\treplay_stdin | \"$chained\" \"$@\"\nfi\n\n")
+	} else {
+		b.WriteString("if [ -x \"$chained\" ]; then\n\t\"$chained\" \"$@\"\nfi\n\n")
+	}
+	b.WriteString("cd \"$(git rev-parse --show-toplevel)\"\n")
+	if projectRelDir != "." {
+		fmt.Fprintf(&b, "cd %s\n", shQuote(filepath.ToSlash(projectRelDir)))
+	}
+	quoted := make([]string, 0, len(targets))
+	for _, t := range targets {
+		quoted = append(quoted, shQuote(t))
+	}
+	if readsStdin {
+		fmt.Fprintf(&b, "replay_stdin | exec %s %s\n", shQuote(mageExec), strings.Join(quoted, " "))
+	} else {
+		fmt.Fprintf(&b, "exec %s %s\n", shQuote(mageExec), strings.Join(quoted, " "))
+	}
+	return b.String()
+}
+
+// gitHookReadsStdin checks whether Git passes data through the standard input to the hook with the given name.
+func gitHookReadsStdin(name string) bool {
+	return name == GitHookPrePush
+}
+
+// gitHookStatus returns the status of the Git hook with the given name within the given hooks directory.
+func gitHookStatus(hooksDir, name string) (GitHookStatus, error) {
+	status := GitHookStatus{Name: name, Path: filepath.Join(hooksDir, name)}
+
+	chained, chainedErr := glFS.RegularFileExists(status.Path + gitHookChainedSuffix)
+	if chainedErr != nil {
+		return status, fmt.Errorf("check chained Git hook %q: %w", name, chainedErr)
+	}
+	status.Chained = chained
+
+	data, readErr := os.ReadFile(status.Path)
+	if readErr != nil {
+		if errors.Is(readErr, os.ErrNotExist) {
+			return status, nil
+		}
+		return status, fmt.Errorf("read Git hook %q: %w", name, readErr)
+	}
+	status.Exists = true
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == gitHookMarker:
+			status.Managed = true
+		case strings.HasPrefix(line, gitHookTargetsPrefix):
+			status.Targets = strings.Fields(strings.TrimPrefix(line, gitHookTargetsPrefix))
+		}
+	}
+	// Only report chained user hooks for managed hooks since others do not run them.
+	status.Chained = status.Chained && status.Managed
+	return status, nil
+}
+
+// installGitHook writes the given Git hook script and chains an existing user hook by renaming it.
+func installGitHook(status GitHookStatus, script string) error {
+	if err := os.MkdirAll(filepath.Dir(status.Path), 0o750); err != nil {
+		return fmt.Errorf("create Git hooks directory: %w", err)
+	}
+
+	if status.Exists && !status.Managed {
+		chainedPath := status.Path + gitHookChainedSuffix
+		chainedExists, chainedErr := glFS.RegularFileExists(chainedPath)
+		if chainedErr != nil {
+			return fmt.Errorf("check chained Git hook %q: %w", status.Name, chainedErr)
+		}
+		if chainedExists {
+			return fmt.Errorf("chain Git hook %q: %q already exists", status.Name, chainedPath)
+		}
+		if err := os.Rename(status.Path, chainedPath); err != nil {
+			return fmt.Errorf("chain Git hook %q: %w", status.Name, err)
+		}
+	}
+
+	//nolint:gosec // Git hooks must be executable.
+	if err := os.WriteFile(status.Path, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("write Git hook %q: %w", status.Name, err)
+	}
+	// Ensure the hook is executable even when the file already existed with other permissions.
+	//nolint:gosec // Git hooks must be executable.
+	if err := os.Chmod(status.Path, 0o755); err != nil {
+		return fmt.Errorf("make Git hook %q executable: %w", status.Name, err)
+	}
+	return nil
+}
+
+// uninstallGitHook removes the given Git hook managed by wand and restores the chained user hook, if any.
+func uninstallGitHook(status GitHookStatus) error {
+	if !status.Managed {
+		return nil
+	}
+	if err := os.Remove(status.Path); err != nil {
+		return fmt.Errorf("remove Git hook %q: %w", status.Name, err)
+	}
+	if status.Chained {
+		if err := os.Rename(status.Path+gitHookChainedSuffix, status.Path); err != nil {
+			return fmt.Errorf("restore chained Git hook %q: %w", status.Name, err)
+		}
+	}
+	return nil
+}
+
+// isGitHookName checks whether the given name is a supported Git hook.
+func isGitHookName(name string) bool {
+	for _, n := range gitHookNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// shQuote quotes the given string for the usage as single word in POSIX shell scripts.
+func shQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
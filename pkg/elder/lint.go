@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGolangCILint "github.com/svengreb/wand/pkg/task/golangcilint"
+)
+
+// writeGolangCILintReports writes report files for the given issues in all given formats into the given directory.
+func writeGolangCILintReports(dir string, issues []taskGolangCILint.Issue, version string,
+	formats ...taskGolangCILint.ReportFormat,
+) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create report directory: %w", err)
+	}
+
+	for _, format := range formats {
+		path := filepath.Join(dir, format.FileName())
+		f, createErr := os.Create(path)
+		if createErr != nil {
+			return fmt.Errorf("create report file: %w", createErr)
+		}
+
+		var writeErr error
+		switch format {
+		case taskGolangCILint.ReportFormatCheckstyle:
+			writeErr = taskGolangCILint.WriteCheckstyle(f, issues)
+		case taskGolangCILint.ReportFormatSARIF:
+			writeErr = taskGolangCILint.WriteSARIF(f, issues, version)
+		default:
+			writeErr = fmt.Errorf("not a valid report format %d", format)
+		}
+		closeErr := f.Close()
+		if writeErr != nil {
+			return &task.ErrTask{
+				Err:  fmt.Errorf("write %s report file %q: %w", format, path, writeErr),
+				Kind: task.ErrRun,
+			}
+		}
+		if closeErr != nil {
+			return fmt.Errorf("close report file: %w", closeErr)
+		}
+	}
+	return nil
+}
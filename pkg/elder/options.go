@@ -33,6 +33,9 @@ type Option func(*Options)
 
 // Options are wand options.
 type Options struct {
+	// buildProfiles are additional build profiles that override default build profiles with the same name.
+	buildProfiles []BuildProfile
+
 	// disableAutoGenWandDataDir indicates whether the auto-generation of the directory for wand specific data should be
 	// disabled.
 	disableAutoGenWandDataDir bool
@@ -60,6 +63,15 @@ func NewOptions(opts ...Option) *Options {
 	return opt
 }
 
+// WithBuildProfiles sets additional build profiles that override the default build profiles with the same name.
+//
+// See DefaultBuildProfiles for all default build profiles.
+func WithBuildProfiles(profiles ...BuildProfile) Option {
+	return func(o *Options) {
+		o.buildProfiles = append(o.buildProfiles, profiles...)
+	}
+}
+
 // WithDisableAutoGenWandDataDir indicates whether the auto-generation of the directory for wand specific data should be
 // disabled.
 func WithDisableAutoGenWandDataDir(disableAutoGenWandDataDir bool) Option {
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	glFS "github.com/svengreb/golib/pkg/io/fs"
+
+	"github.com/svengreb/wand/pkg/artifact"
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+	taskGoPGO "github.com/svengreb/wand/pkg/task/golang/pgo"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+)
+
+// pgoBench runs the benchmarks of the given profile-guided optimization task with or without profile-guided
+// optimization and returns the parsed run along with the task options.
+func (e *Elder) pgoBench(t *taskGoPGO.Task, optimized bool) (*taskGoBench.Run, *taskGoBench.Options, error) {
+	bt, btErr := t.BenchTask(optimized)
+	if btErr != nil {
+		return nil, nil, fmt.Errorf(`create "go/bench" task: %w`, btErr)
+	}
+	btOpts, ok := bt.Options().(taskGoBench.Options)
+	if !ok {
+		return nil, nil, fmt.Errorf(`convert task options to "%T"`, taskGoBench.Options{})
+	}
+
+	out, runErr := e.goRunner.RunOut(bt)
+	if runErr != nil {
+		e.Errorf("%s", out)
+		return nil, nil, runErr
+	}
+	run, parseErr := taskGoBench.ParseOutput(strings.NewReader(out))
+	if parseErr != nil {
+		return nil, nil, &task.ErrTask{Err: fmt.Errorf("parse %q output: %w", bt.Name(), parseErr), Kind: task.ErrRun}
+	}
+	run.Commit = taskGoPGO.BenchRunNameBaseline
+	if optimized {
+		run.Commit = taskGoPGO.BenchRunNameOptimized
+	}
+	run.Date = time.Now().UTC()
+	return run, &btOpts, nil
+}
+
+// pgoBuild builds the given application of the given profile-guided optimization task with or without profile-guided
+// optimization and records the binary artifact.
+func (e *Elder) pgoBuild(appName string, t *taskGoPGO.Task, optimized bool) (taskGoPGO.Build, error) {
+	bt, btErr := t.BuildTask(optimized)
+	if btErr != nil {
+		return taskGoPGO.Build{}, fmt.Errorf(`create "go/build" task: %w`, btErr)
+	}
+	btOpts, ok := bt.Options().(taskGoBuild.Options)
+	if !ok {
+		return taskGoPGO.Build{}, fmt.Errorf(`convert task options to "%T"`, taskGoBuild.Options{})
+	}
+	if err := e.goRunner.Run(bt); err != nil {
+		return taskGoPGO.Build{}, err
+	}
+
+	b := taskGoPGO.Build{Path: bt.OutputPath()}
+	fi, statErr := os.Stat(b.Path)
+	if statErr != nil {
+		return b, &task.ErrTask{Err: fmt.Errorf("stat binary artifact %q: %w", b.Path, statErr), Kind: task.ErrRun}
+	}
+	b.Size = fi.Size()
+	e.recordArtifacts(t.Name(), artifact.Artifact{
+		App:      appName,
+		Kind:     artifact.KindBinary,
+		Path:     b.Path,
+		Platform: taskGo.TargetPlatform(btOpts.Env),
+	})
+	return b, nil
+}
+
+// pgoProfiles returns the CPU profiles of the given task options or, when none are set explicitly, the CPU profile with
+// the default file name of the GoTest task within the profile directory.
+func pgoProfiles(tOpts *taskGoPGO.Options) ([]string, error) {
+	if len(tOpts.Profiles) > 0 {
+		return tOpts.Profiles, nil
+	}
+
+	p := filepath.Join(tOpts.ProfileDir, taskGoTest.DefaultCPUProfileOutputFileName)
+	exists, err := glFS.RegularFileExists(p)
+	if err != nil {
+		return nil, fmt.Errorf("check profile %q: %w", p, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("no CPU profile found in %q", tOpts.ProfileDir)
+	}
+	return []string{p}, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	taskGoPGO "github.com/svengreb/wand/pkg/task/golang/pgo"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+)
+
+func TestPGOProfiles(t *testing.T) {
+	withProfile := t.TempDir()
+	profile := filepath.Join(withProfile, taskGoTest.DefaultCPUProfileOutputFileName)
+	require.NoError(t, os.WriteFile(profile, []byte("profile"), 0o600))
+	withDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(withDir, taskGoTest.DefaultCPUProfileOutputFileName), 0o700))
+
+	tests := []struct {
+		name    string
+		opts    taskGoPGO.Options
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "explicit profiles",
+			opts: taskGoPGO.Options{ProfileDir: withProfile, Profiles: []string{"a.pprof", "http://localhost/debug/pprof"}},
+			want: []string{"a.pprof", "http://localhost/debug/pprof"},
+		},
+		{name: "discovered profile", opts: taskGoPGO.Options{ProfileDir: withProfile}, want: []string{profile}},
+		{name: "no profile", opts: taskGoPGO.Options{ProfileDir: t.TempDir()}, wantErr: true},
+		{name: "directory instead of profile", opts: taskGoPGO.Options{ProfileDir: withDir}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles, err := pgoProfiles(&tc.opts)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, profiles)
+		})
+	}
+}
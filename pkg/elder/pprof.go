@@ -0,0 +1,175 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	glFS "github.com/svengreb/golib/pkg/io/fs"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGoPprof "github.com/svengreb/wand/pkg/task/golang/pprof"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+)
+
+// profileStoreDirName is the name of the directory within the profile report output directory where analyzed profiles
+// are stored keyed by Git commit.
+const profileStoreDirName = "profiles"
+
+// profileBaseDir returns the directory with the profiles that are used as base to show differences or an empty string
+// when no base has been configured.
+func (e *Elder) profileBaseDir(rOpts *taskGoPprof.ReportOptions) (string, error) {
+	if rOpts.BaseDir != "" || rOpts.BaseRev == "" {
+		return rOpts.BaseDir, nil
+	}
+
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return "", repoErr
+	}
+	commit, commitErr := repo.Commit(rOpts.BaseRev)
+	if commitErr != nil {
+		return "", commitErr
+	}
+	dir := filepath.Join(rOpts.OutputDir, profileStoreDirName, commit)
+	exists, existsErr := glFS.DirExists(dir)
+	if existsErr != nil {
+		return "", fmt.Errorf("check stored profiles of %q: %w", rOpts.BaseRev, existsErr)
+	}
+	if !exists {
+		return "", fmt.Errorf("no stored profiles of base revision %q in %q", rOpts.BaseRev, dir)
+	}
+	return dir, nil
+}
+
+// profileReport analyzes the given profile and writes the text table of the top entries, the folded stacks and,
+// when enabled, the SVG call graph into the output directory. When the given base directory contains a profile with
+// the same file name the differences are analyzed as well.
+func (e *Elder) profileReport(
+	profile, baseDir string, rOpts *taskGoPprof.ReportOptions,
+) (taskGoPprof.ProfileReport, error) {
+	pr := taskGoPprof.ProfileReport{Profile: profile}
+	name := strings.TrimSuffix(filepath.Base(profile), filepath.Ext(profile))
+	common := []taskGoPprof.Option{
+		taskGoPprof.WithEnv(rOpts.Env),
+		taskGoPprof.WithNodeCount(rOpts.NodeCount),
+		taskGoPprof.WithProfile(profile),
+		taskGoPprof.WithSampleIndex(rOpts.SampleIndex),
+	}
+
+	top, topErr := e.runPprof(append(common, taskGoPprof.WithFormat(taskGoPprof.FormatTop))...)
+	if topErr != nil {
+		return pr, topErr
+	}
+	if pr.Top, topErr = taskGoPprof.ParseTop(strings.NewReader(top)); topErr != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("parse top entries of %q: %w", profile, topErr), Kind: task.ErrRun}
+	}
+	pr.TopFile = filepath.Join(rOpts.OutputDir, name+".top.txt")
+	if err := os.WriteFile(pr.TopFile, []byte(top), 0o600); err != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("write %q: %w", pr.TopFile, err), Kind: task.ErrRun}
+	}
+
+	traces, tracesErr := e.runPprof(append(common, taskGoPprof.WithFormat(taskGoPprof.FormatTraces))...)
+	if tracesErr != nil {
+		return pr, tracesErr
+	}
+	samples, parseErr := taskGoPprof.ParseTraces(strings.NewReader(traces))
+	if parseErr != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("parse traces of %q: %w", profile, parseErr), Kind: task.ErrRun}
+	}
+	var folded bytes.Buffer
+	if err := taskGoPprof.WriteFolded(&folded, samples); err != nil {
+		return pr, &task.ErrTask{Err: err, Kind: task.ErrRun}
+	}
+	pr.FoldedFile = filepath.Join(rOpts.OutputDir, name+".folded")
+	if err := os.WriteFile(pr.FoldedFile, folded.Bytes(), 0o600); err != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("write %q: %w", pr.FoldedFile, err), Kind: task.ErrRun}
+	}
+
+	if rOpts.EnableSVG {
+		pr.SVGFile = filepath.Join(rOpts.OutputDir, name+".svg")
+		if _, err := e.runPprof(append(common,
+			taskGoPprof.WithFormat(taskGoPprof.FormatSVG), taskGoPprof.WithOutputFile(pr.SVGFile),
+		)...); err != nil {
+			return pr, err
+		}
+	}
+
+	if baseDir == "" {
+		return pr, nil
+	}
+	base := filepath.Join(baseDir, filepath.Base(profile))
+	baseExists, baseErr := glFS.RegularFileExists(base)
+	if baseErr != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("check base profile %q: %w", base, baseErr), Kind: task.ErrRun}
+	}
+	if !baseExists {
+		e.Warnf("No base profile %q to compare %q with", base, profile)
+		return pr, nil
+	}
+	diff, diffErr := e.runPprof(append(common,
+		taskGoPprof.WithDiffBase(base), taskGoPprof.WithFormat(taskGoPprof.FormatTop),
+	)...)
+	if diffErr != nil {
+		return pr, diffErr
+	}
+	if pr.Diff, diffErr = taskGoPprof.ParseTop(strings.NewReader(diff)); diffErr != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("parse differences of %q: %w", profile, diffErr), Kind: task.ErrRun}
+	}
+	pr.DiffBase = base
+	pr.DiffFile = filepath.Join(rOpts.OutputDir, name+".diff.txt")
+	if err := os.WriteFile(pr.DiffFile, []byte(diff), 0o600); err != nil {
+		return pr, &task.ErrTask{Err: fmt.Errorf("write %q: %w", pr.DiffFile, err), Kind: task.ErrRun}
+	}
+	return pr, nil
+}
+
+// runPprof runs the "pprof" task with the given options and returns its output.
+func (e *Elder) runPprof(opts ...taskGoPprof.Option) (string, error) {
+	t := taskGoPprof.New(opts...)
+	out, err := e.goRunner.RunOut(t)
+	if err != nil {
+		e.Errorf("%s", out)
+		return "", err
+	}
+	return out, nil
+}
+
+// storeProfiles copies the given profiles into the directory of the given commit within the profile store so that
+// they can be used as base to show differences later on.
+func storeProfiles(outputDir, commit string, profiles []string) error {
+	dir := filepath.Join(outputDir, profileStoreDirName, commit)
+	for _, p := range profiles {
+		if err := copyFile(p, filepath.Join(dir, filepath.Base(p))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testProfiles returns the paths of all profiles with default file names that exist in the given directory.
+// Execution trace profiles are not included since they are not supported by "pprof".
+func testProfiles(dir string) ([]string, error) {
+	var profiles []string
+	for _, name := range []string{
+		taskGoTest.DefaultCPUProfileOutputFileName,
+		taskGoTest.DefaultMemoryProfileOutputFileName,
+		taskGoTest.DefaultBlockProfileOutputFileName,
+		taskGoTest.DefaultMutexProfileOutputFileName,
+	} {
+		p := filepath.Join(dir, name)
+		exists, err := glFS.RegularFileExists(p)
+		if err != nil {
+			return nil, fmt.Errorf("check profile %q: %w", p, err)
+		}
+		if exists {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+)
+
+const (
+	// BuildProfileNameDebug is the name of the build profile for binary artifacts that are optimized for debuggers.
+	BuildProfileNameDebug = "debug"
+
+	// BuildProfileNameDev is the name of the build profile for development binary artifacts built with the default
+	// options of the Go toolchain.
+	BuildProfileNameDev = "dev"
+
+	// BuildProfileNameRace is the name of the build profile for binary artifacts with enabled race detector.
+	BuildProfileNameRace = "race"
+
+	// BuildProfileNameRelease is the name of the build profile for production and distribution binary artifacts.
+	BuildProfileNameRelease = "release"
+
+	// DefaultBuildProfileName is the name of the build profile that is used when no build profile has been selected.
+	DefaultBuildProfileName = BuildProfileNameDev
+
+	// EnvVarBuildProfile is the name of the environment variable to select a build profile when no build profile has
+	// been passed explicitly.
+	EnvVarBuildProfile = "WAND_PROFILE"
+)
+
+// BuildProfile is a named preset of shared Go toolchain options, mixins and environment variables for recurring build
+// patterns.
+type BuildProfile struct {
+	// Env are additional environment variables for the Go toolchain.
+	Env map[string]string
+
+	// GoOptions are shared Go toolchain task options.
+	GoOptions []taskGo.Option
+
+	// Mixins are parameter mixins for the shared Go toolchain task options.
+	// Mixins are checked for conflicting combinations, like debugging improvements along with stripped debug metadata,
+	// when the build profile is validated.
+	Mixins []task.Mixin
+
+	// Name is the unique name of the build profile.
+	Name string
+
+	// OutputSubDir is the subdirectory within the application output directory for binary artifacts built with the
+	// build profile. Binary artifacts are stored in the application output directory when empty.
+	OutputSubDir string
+}
+
+// TaskGoOptions returns the shared Go toolchain task options of the build profile including its mixins and environment
+// variables. They can be passed to all Go toolchain tasks, e.g. through the "WithGoOptions" option of the
+// "github.com/svengreb/wand/pkg/task/golang/build" package.
+func (p *BuildProfile) TaskGoOptions() []taskGo.Option {
+	opts := append([]taskGo.Option{}, p.GoOptions...)
+	if len(p.Mixins) > 0 {
+		opts = append(opts, taskGo.WithMixins(p.Mixins...))
+	}
+	if len(p.Env) > 0 {
+		opts = append(opts, taskGo.WithEnv(p.Env))
+	}
+	return opts
+}
+
+// Validate checks the build profile for conflicting mixin combinations and options.
+// It returns an error of type *task.ErrTask, or an error wrapping it, when any conflict has been detected or any mixin
+// can not be applied.
+func (p *BuildProfile) Validate() error {
+	return validateBuildProfile(p)
+}
+
+// DefaultBuildProfiles returns the default build profiles.
+//
+//   - BuildProfileNameDebug — disables compiler optimizations and inlining through taskGo.MixinImproveDebugging.
+//   - BuildProfileNameDev — uses the default options of the Go toolchain.
+//   - BuildProfileNameRace — enables the race detector which requires cgo.
+//   - BuildProfileNameRelease — removes file system paths and strips debug metadata through
+//     taskGo.MixinStripDebugMetadata.
+func DefaultBuildProfiles() []BuildProfile {
+	return []BuildProfile{
+		{
+			Mixins:       []task.Mixin{taskGo.MixinImproveDebugging{}},
+			Name:         BuildProfileNameDebug,
+			OutputSubDir: BuildProfileNameDebug,
+		},
+		{
+			Name:         BuildProfileNameDev,
+			OutputSubDir: BuildProfileNameDev,
+		},
+		{
+			Env:          map[string]string{taskGo.DefaultEnvVarCGOENABLED: "1"},
+			GoOptions:    []taskGo.Option{taskGo.WithRaceDetector(true)},
+			Name:         BuildProfileNameRace,
+			OutputSubDir: BuildProfileNameRace,
+		},
+		{
+			GoOptions:    []taskGo.Option{taskGo.WithTrimmedPath(true)},
+			Mixins:       []task.Mixin{taskGo.MixinStripDebugMetadata{}},
+			Name:         BuildProfileNameRelease,
+			OutputSubDir: BuildProfileNameRelease,
+		},
+	}
+}
+
+// buildProfiles returns all build profiles mapped by their name where configured profiles override the default
+// build profiles with the same name.
+func (e *Elder) buildProfiles() map[string]BuildProfile {
+	profiles := make(map[string]BuildProfile)
+	for _, p := range append(DefaultBuildProfiles(), e.opts.buildProfiles...) {
+		profiles[p.Name] = p
+	}
+	return profiles
+}
+
+// selectBuildProfileName returns the given build profile name, the value of the EnvVarBuildProfile environment
+// variable or DefaultBuildProfileName, in this order, whichever is not empty first.
+func selectBuildProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvVarBuildProfile)); v != "" {
+		return v
+	}
+	return DefaultBuildProfileName
+}
+
+// sortedBuildProfileNames returns the sorted names of the given build profiles.
+func sortedBuildProfileNames(profiles map[string]BuildProfile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateBuildProfile checks the given build profile along with the given task options, e.g. those passed per call
+// that can contain additional mixins, for conflicting mixin combinations and options.
+// It returns an error of type *task.ErrTask, or an error wrapping it, when any conflict has been detected or any mixin
+// can not be applied.
+func validateBuildProfile(p *BuildProfile, opts ...taskGoBuild.Option) error {
+	bOpts, bOptsErr := taskGoBuild.NewOptions(
+		append([]taskGoBuild.Option{taskGoBuild.WithGoOptions(p.TaskGoOptions()...)}, opts...)...,
+	)
+	if bOptsErr != nil {
+		return fmt.Errorf("build profile %q: %w", p.Name, bOptsErr)
+	}
+	if bOpts.EnableRaceDetector && bOpts.Env[taskGo.DefaultEnvVarCGOENABLED] == "0" {
+		return &task.ErrTask{
+			Err:  fmt.Errorf("build profile %q: race detector requires cgo but %s=0", p.Name, taskGo.DefaultEnvVarCGOENABLED),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+	return nil
+}
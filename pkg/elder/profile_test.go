@@ -0,0 +1,63 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+)
+
+func TestValidateBuildProfile(t *testing.T) {
+	profiles := make(map[string]BuildProfile)
+	for _, p := range DefaultBuildProfiles() {
+		profiles[p.Name] = p
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		opts    []taskGoBuild.Option
+		wantErr bool
+	}{
+		{name: "profile only", profile: BuildProfileNameRelease},
+		{
+			name:    "compatible mixin per call",
+			profile: BuildProfileNameDev,
+			opts:    []taskGoBuild.Option{taskGoBuild.WithGoOptions(taskGo.WithMixins(taskGo.MixinImproveDebugging{}))},
+		},
+		{
+			name:    "mixin per call conflicts with profile mixin",
+			profile: BuildProfileNameRelease,
+			opts:    []taskGoBuild.Option{taskGoBuild.WithGoOptions(taskGo.WithMixins(taskGo.MixinImproveDebugging{}))},
+			wantErr: true,
+		},
+		{
+			name:    "cgo disabled per call conflicts with race detector of profile",
+			profile: BuildProfileNameRace,
+			opts: []taskGoBuild.Option{
+				taskGoBuild.WithGoOptions(taskGo.WithEnv(map[string]string{taskGo.DefaultEnvVarCGOENABLED: "0"})),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := profiles[tc.profile]
+			err := validateBuildProfile(&p, tc.opts...)
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var errTask *task.ErrTask
+			require.ErrorAs(t, err, &errTask)
+			require.ErrorIs(t, err, task.ErrInvalidTaskOpts)
+		})
+	}
+}
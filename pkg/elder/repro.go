@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGoEnv "github.com/svengreb/wand/pkg/task/golang/env"
+	taskGoRepro "github.com/svengreb/wand/pkg/task/golang/repro"
+)
+
+// goEnvVar returns the value of the Go environment variable with the given name as reported by the Go toolchain "env"
+// command.
+func (e *Elder) goEnvVar(name string) (string, error) {
+	out, err := e.goRunner.RunOut(taskGoEnv.New(taskGoEnv.WithEnvVars(name)))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// reproBuild runs the build with the given number of the given reproducible build verification task in a temporary
+// "GOPATH" and "GOCACHE" that are removed afterwards.
+// The module cache of the current environment is used unless the task is configured to use an isolated one.
+func (e *Elder) reproBuild(t *taskGoRepro.Task, n int, goModCache string) (string, error) {
+	tOpts, ok := t.Options().(taskGoRepro.Options)
+	if !ok {
+		return "", fmt.Errorf(`convert task options to "%T"`, taskGoRepro.Options{})
+	}
+
+	tmpDir, tmpErr := os.MkdirTemp("", fmt.Sprintf("wand-repro-%d-", n))
+	if tmpErr != nil {
+		return "", &task.ErrRunner{Err: fmt.Errorf("create temporary directory: %w", tmpErr), Kind: task.ErrRun}
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			e.Warnf("Failed to remove temporary directory %q of build %d: %v", tmpDir, n, err)
+		}
+	}()
+
+	goPath := filepath.Join(tmpDir, "gopath")
+	if tOpts.EnableIsolatedModCache {
+		goModCache = filepath.Join(goPath, "pkg", "mod")
+	}
+	bt, btErr := t.BuildTask(n, goPath, filepath.Join(tmpDir, "gocache"), goModCache)
+	if btErr != nil {
+		return "", fmt.Errorf(`create "go/build" task: %w`, btErr)
+	}
+	if err := e.goRunner.Run(bt); err != nil {
+		return "", err
+	}
+	return bt.OutputPath(), nil
+}
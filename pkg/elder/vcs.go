@@ -0,0 +1,216 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package elder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/svengreb/wand/pkg/project/vcs"
+	vcsGit "github.com/svengreb/wand/pkg/project/vcs/git"
+)
+
+// gitRepository returns the project repository when it is of kind vcs.KindGit.
+func (e *Elder) gitRepository() (*vcsGit.Git, error) {
+	repo, ok := e.project.Options().Repository.(*vcsGit.Git)
+	if !ok {
+		return nil, fmt.Errorf(
+			"expected project repository of kind %q but got %q",
+			vcs.KindGit, e.project.Options().Repository.Kind(),
+		)
+	}
+	return repo, nil
+}
+
+// worktreeSnapshot is a snapshot of the files within pathspecs of the project Git repository working tree.
+// It allows to detect changes of tracked files, new untracked files, and to revert them afterwards.
+type worktreeSnapshot struct {
+	backupDir string
+	checksums map[string]string
+	modes     map[string]os.FileMode
+	pathspecs []string
+	repo      *vcsGit.Git
+	untracked map[string]bool
+}
+
+// snapshotWorktree creates a snapshot of all tracked and untracked, but not ignored, files within the given pathspecs.
+// The content of tracked files is backed up into a temporary directory that is removed when the snapshot is restored.
+func (e *Elder) snapshotWorktree(pathspecs ...string) (*worktreeSnapshot, error) {
+	repo, repoErr := e.gitRepository()
+	if repoErr != nil {
+		return nil, repoErr
+	}
+
+	checksums, sumsErr := trackedFileChecksums(repo, pathspecs...)
+	if sumsErr != nil {
+		return nil, sumsErr
+	}
+	untracked, untrackedErr := untrackedFiles(repo, pathspecs...)
+	if untrackedErr != nil {
+		return nil, untrackedErr
+	}
+
+	backupDir, tmpErr := os.MkdirTemp("", "wand-worktree-")
+	if tmpErr != nil {
+		return nil, fmt.Errorf("create temporary backup directory: %w", tmpErr)
+	}
+	s := &worktreeSnapshot{
+		backupDir: backupDir,
+		checksums: checksums,
+		modes:     make(map[string]os.FileMode, len(checksums)),
+		pathspecs: pathspecs,
+		repo:      repo,
+		untracked: untracked,
+	}
+	for p, sum := range checksums {
+		if sum == "" {
+			continue
+		}
+		fi, statErr := os.Stat(filepath.Join(repo.Path(), p))
+		if statErr != nil {
+			_ = os.RemoveAll(backupDir)
+			return nil, fmt.Errorf("stat %q: %w", p, statErr)
+		}
+		if err := writeFileFrom(filepath.Join(repo.Path(), p), filepath.Join(backupDir, p), 0o600); err != nil {
+			_ = os.RemoveAll(backupDir)
+			return nil, fmt.Errorf("back up %q: %w", p, err)
+		}
+		s.modes[p] = fi.Mode().Perm()
+	}
+	return s, nil
+}
+
+// changes returns the sorted paths, relative to the project root directory, of tracked files that have been changed
+// and untracked files that have been created since the snapshot was taken.
+func (s *worktreeSnapshot) changes() ([]string, error) {
+	checksums, sumsErr := trackedFileChecksums(s.repo, s.pathspecs...)
+	if sumsErr != nil {
+		return nil, sumsErr
+	}
+	untracked, untrackedErr := untrackedFiles(s.repo, s.pathspecs...)
+	if untrackedErr != nil {
+		return nil, untrackedErr
+	}
+
+	changed := changedChecksums(s.checksums, checksums)
+	for p := range untracked {
+		if !s.untracked[p] {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// restore reverts the given changed paths to the state of the snapshot and removes the backup directory.
+// Tracked files are restored from the backup while files that did not exist when the snapshot was taken are removed.
+func (s *worktreeSnapshot) restore(changed []string) error {
+	defer func() { _ = os.RemoveAll(s.backupDir) }()
+
+	for _, p := range changed {
+		path := filepath.Join(s.repo.Path(), p)
+		if s.checksums[p] == "" {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("remove %q: %w", p, err)
+			}
+			continue
+		}
+		if err := writeFileFrom(filepath.Join(s.backupDir, p), path, s.modes[p]); err != nil {
+			return fmt.Errorf("restore %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// changedChecksums returns the sorted paths whose checksums differ between the given checksum maps.
+func changedChecksums(before, after map[string]string) []string {
+	var changed []string
+	for p, sum := range after {
+		if before[p] != sum {
+			changed = append(changed, p)
+		}
+	}
+	for p := range before {
+		if _, ok := after[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of the file at the given path or an empty string when the file
+// does not exist.
+func fileChecksum(path string) (string, error) {
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		if errors.Is(openErr, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("open %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("compute checksum of %q: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// trackedFileChecksums returns the SHA-256 checksums of all files tracked by Git within the given pathspecs mapped by
+// their path relative to the project root directory. Tracked files that do not exist are mapped to an empty checksum.
+func trackedFileChecksums(repo *vcsGit.Git, pathspecs ...string) (map[string]string, error) {
+	paths, pathsErr := repo.TrackedFiles(pathspecs...)
+	if pathsErr != nil {
+		return nil, pathsErr
+	}
+
+	checksums := make(map[string]string, len(paths))
+	for _, p := range paths {
+		sum, sumErr := fileChecksum(filepath.Join(repo.Path(), p))
+		if sumErr != nil {
+			return nil, sumErr
+		}
+		checksums[p] = sum
+	}
+	return checksums, nil
+}
+
+// untrackedFiles returns the set of paths, relative to the project root directory, of all untracked files within the
+// given pathspecs that are not ignored.
+func untrackedFiles(repo *vcsGit.Git, pathspecs ...string) (map[string]bool, error) {
+	paths, pathsErr := repo.UntrackedFiles(pathspecs...)
+	if pathsErr != nil {
+		return nil, pathsErr
+	}
+
+	untracked := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		untracked[p] = true
+	}
+	return untracked, nil
+}
+
+// writeFileFrom writes the content of the file at the given source path to the given destination path with the given
+// permissions, creating all missing parent directories.
+func writeFileFrom(src, dst string, perm os.FileMode) error {
+	data, readErr := os.ReadFile(src)
+	if readErr != nil {
+		return readErr
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, data, perm); err != nil {
+		return err
+	}
+	return os.Chmod(dst, perm)
+}
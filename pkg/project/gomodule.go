@@ -152,3 +152,40 @@ func GoModuleFromFile(dirAbs string) (*GoModuleID, error) {
 	gm.Version = version
 	return gm, nil
 }
+
+// GoModuleDirs returns the absolute paths of all directories below and including the given root directory that contain
+// a Go module file.
+// Like the Go toolchain, directories named "testdata" or "vendor" as well as directories whose name starts with "." or
+// "_" are ignored.
+func GoModuleDirs(rootDirAbs string) ([]string, error) {
+	var dirs []string
+	walkErr := filepath.WalkDir(rootDirAbs, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != rootDirAbs {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+		}
+		hasModFile, fsErr := glFS.RegularFileExists(filepath.Join(path, GoModuleDefaultFileName))
+		if fsErr != nil {
+			return fsErr
+		}
+		if hasModFile {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, &ErrProject{
+			Err:  fmt.Errorf("find Go modules in %q: %w", rootDirAbs, walkErr),
+			Kind: ErrDetermineGoModuleInformation,
+		}
+	}
+	return dirs, nil
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"path/filepath"
 
+	glGit "github.com/svengreb/golib/pkg/vcs/git"
+
 	"github.com/svengreb/wand/pkg/project/vcs"
 	vcsGit "github.com/svengreb/wand/pkg/project/vcs/git"
 )
@@ -23,6 +25,29 @@ func (m Metadata) Options() Options {
 	return *m.opts
 }
 
+// Revision returns the full hash of the commit "HEAD" of the project Repository resolves to.
+// An empty string is returned when the Repository is not of kind vcs.KindGit or has no commits yet.
+func (m Metadata) Revision() string {
+	repo, ok := m.opts.Repository.(*vcsGit.Git)
+	if !ok {
+		return ""
+	}
+	commit, err := repo.Commit("HEAD")
+	if err != nil {
+		return ""
+	}
+	return commit
+}
+
+// Version returns the project version derived from the Repository or the default version when it is not of kind
+// vcs.KindGit.
+func (m Metadata) Version() string {
+	if v, ok := m.opts.Repository.Version().(*glGit.Version); ok && v != nil && v.Version != nil {
+		return v.Version.String()
+	}
+	return m.opts.DefaultVersion
+}
+
 // New creates new project metadata.
 //
 // The absolute path to the root directory is automatically set based on the current working directory while the Go
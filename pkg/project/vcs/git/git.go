@@ -8,7 +8,10 @@ package git
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/magefile/mage/sh"
 	glGit "github.com/svengreb/golib/pkg/vcs/git"
 
 	"github.com/svengreb/wand/pkg/project/vcs"
@@ -38,11 +41,142 @@ func (g *Git) DeriveVersion() error {
 	return nil
 }
 
+// ChangedFiles returns the paths of all files that were added, copied, modified or renamed in the working tree compared
+// to the given revision.
+// The returned paths are relative to the root directory of the working tree, see RootDir, which differs from the
+// repository path when it is a subdirectory of the working tree.
+// Optionally pass pathspecs, relative to the repository path, see Path, to limit the files to the given paths.
+//
+// See https://git-scm.com/docs/git-diff for more details.
+func (g *Git) ChangedFiles(rev string, pathspecs ...string) ([]string, error) {
+	args := append([]string{"diff", "--name-only", "-z", "--diff-filter=ACMR", rev, "--"}, pathspecs...)
+	out, err := g.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("list files changed compared to %q: %w", rev, err)
+	}
+	return splitNullTerminated(out), nil
+}
+
+// Commit returns the full hash of the commit the given revision, e.g. "HEAD" or a branch name, resolves to.
+//
+// See https://git-scm.com/docs/git-rev-parse for more details.
+func (g *Git) Commit(rev string) (string, error) {
+	out, err := g.run("rev-parse", "--verify", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("resolve commit of %q: %w", rev, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// HooksDir returns the absolute path to the directory of the repository Git hooks.
+// The "core.hooksPath" configuration is respected when set, otherwise the default hooks directory within the Git
+// directory is used which also supports linked working trees.
+//
+// See https://git-scm.com/docs/githooks and https://git-scm.com/docs/git-rev-parse for more details.
+func (g *Git) HooksDir() (string, error) {
+	out, err := g.run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("determine Git hooks directory: %w", err)
+	}
+
+	// The path is relative to the directory Git has been run in unless it is already absolute.
+	hooksDir := strings.TrimSpace(out)
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(g.opts.path, hooksDir)
+	}
+	return filepath.Clean(hooksDir), nil
+}
+
 // Kind returns the repository Kind.
 func (g *Git) Kind() vcs.Kind {
 	return vcs.KindGit
 }
 
+// MergeBase returns the commit hash of the best common ancestor of the given revision and "HEAD".
+//
+// See https://git-scm.com/docs/git-merge-base for more details.
+func (g *Git) MergeBase(rev string) (string, error) {
+	out, err := g.run("merge-base", rev, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("determine merge base of %q and HEAD: %w", rev, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Path returns the absolute repository path that Git commands are run in.
+// It can be a subdirectory of the root directory of the working tree, see RootDir.
+func (g *Git) Path() string {
+	return g.opts.path
+}
+
+// RootDir returns the absolute path to the root directory of the working tree the repository path is part of.
+//
+// See https://git-scm.com/docs/git-rev-parse for more details.
+func (g *Git) RootDir() (string, error) {
+	out, err := g.run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("determine working tree root directory: %w", err)
+	}
+	return filepath.Clean(strings.TrimSpace(out)), nil
+}
+
+// StagedDiff returns the unified diff of all changes in the index compared to "HEAD".
+// The paths in the diff are relative to the root directory of the working tree, see RootDir.
+// Optionally pass pathspecs, relative to the repository path, see Path, to limit the diff to the given paths.
+//
+// See https://git-scm.com/docs/git-diff for more details.
+func (g *Git) StagedDiff(pathspecs ...string) (string, error) {
+	args := append([]string{"diff", "--cached", "--no-color", "--no-ext-diff", "--"}, pathspecs...)
+	out, err := g.run(args...)
+	if err != nil {
+		return "", fmt.Errorf("diff staged changes: %w", err)
+	}
+	return out, nil
+}
+
+// StagedFiles returns the paths of all files that were added, copied, modified or renamed in the index compared to
+// "HEAD".
+// The returned paths are relative to the root directory of the working tree, see RootDir, which differs from the
+// repository path when it is a subdirectory of the working tree.
+// Optionally pass pathspecs, relative to the repository path, see Path, to limit the files to the given paths.
+//
+// See https://git-scm.com/docs/git-diff for more details.
+func (g *Git) StagedFiles(pathspecs ...string) ([]string, error) {
+	args := append([]string{"diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR", "--"}, pathspecs...)
+	out, err := g.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("list staged files: %w", err)
+	}
+	return splitNullTerminated(out), nil
+}
+
+// TrackedFiles returns the paths of all files tracked by Git within the repository path, see Path.
+// The returned paths are relative to the repository path.
+// Optionally pass pathspecs, relative to the repository path, to limit the files to the given paths.
+//
+// See https://git-scm.com/docs/git-ls-files for more details.
+func (g *Git) TrackedFiles(pathspecs ...string) ([]string, error) {
+	out, err := g.run(append([]string{"ls-files", "-z", "--"}, pathspecs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("list tracked files: %w", err)
+	}
+	return splitNullTerminated(out), nil
+}
+
+// UntrackedFiles returns the paths of all untracked files within the repository path, see Path, that are not ignored,
+// like the untracked entries reported by `git status --porcelain --untracked-files=all`.
+// The returned paths are relative to the repository path.
+// Optionally pass pathspecs, relative to the repository path, to limit the files to the given paths.
+//
+// See https://git-scm.com/docs/git-ls-files for more details.
+func (g *Git) UntrackedFiles(pathspecs ...string) ([]string, error) {
+	out, err := g.run(append([]string{"ls-files", "-z", "--others", "--exclude-standard", "--"}, pathspecs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("list untracked files: %w", err)
+	}
+	return splitNullTerminated(out), nil
+}
+
 // Version returns the repository version as type *Version.
 func (g *Git) Version() interface{} {
 	return g.opts.version
@@ -52,3 +186,20 @@ func (g *Git) Version() interface{} {
 func New(opts ...Option) *Git {
 	return &Git{opts: newOptions(opts...)}
 }
+
+// run runs the Git command with the given arguments within the repository and returns its output.
+func (g *Git) run(args ...string) (string, error) {
+	return sh.Output("git", append([]string{"-C", g.opts.path}, args...)...)
+}
+
+// splitNullTerminated splits the output of Git commands that use NUL as terminator for paths, e.g. when using the "-z"
+// flag.
+func splitNullTerminated(out string) []string {
+	var paths []string
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
@@ -3,6 +3,10 @@
 
 package clean
 
+import (
+	"github.com/svengreb/wand/pkg/artifact"
+)
+
 const (
 	// taskName is the name of the task.
 	taskName = "fs/clean"
@@ -38,6 +42,16 @@ func NewOptions(opts ...Option) *Options {
 	return opt
 }
 
+// WithArtifacts adds the paths of the given artifacts, e.g. queried from an artifact.Registry, to the paths to remove.
+// This allows to remove exactly the artifacts tasks produced instead of whole output directories.
+func WithArtifacts(artifacts ...artifact.Artifact) Option {
+	return func(o *Options) {
+		for _, a := range artifacts {
+			o.paths = append(o.paths, a.Path)
+		}
+	}
+}
+
 // WithLimitToAppOutputDir indicates whether only paths within the configured application output directory should be
 // allowed.
 func WithLimitToAppOutputDir(limitToAppOutputDir bool) Option {
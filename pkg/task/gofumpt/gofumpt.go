@@ -44,6 +44,11 @@ func (t *Task) BuildParams() []string {
 		params = append(params, "-l")
 	}
 
+	// Print diffs of files whose formatting are non-compliant to gofumpt's styles.
+	if t.opts.enableDiff {
+		params = append(params, "-d")
+	}
+
 	// Write result to source files instead of stdout.
 	if t.opts.persistChanges {
 		params = append(params, "-w")
@@ -28,6 +28,9 @@ type Option func(*Options)
 
 // Options are task options.
 type Options struct {
+	// enableDiff indicates whether diffs of files whose formatting are not conform to the style guide should be printed.
+	enableDiff bool
+
 	// env is the task specific environment.
 	env map[string]string
 
@@ -84,6 +87,14 @@ func NewOptions(opts ...Option) (*Options, error) {
 	return opt, nil
 }
 
+// WithDiff indicates whether diffs of files whose formatting are not conform to the style guide should be printed
+// instead of the formatted source.
+func WithDiff(enableDiff bool) Option {
+	return func(o *Options) {
+		o.enableDiff = enableDiff
+	}
+}
+
 // WithEnv sets the task specific environment.
 func WithEnv(env map[string]string) Option {
 	return func(o *Options) {
@@ -46,6 +46,11 @@ func (t *Task) BuildParams() []string {
 		params = append(params, "-e")
 	}
 
+	// Print diffs of files whose formatting are non-compliant to the style guide.
+	if t.opts.enableDiff {
+		params = append(params, "-d")
+	}
+
 	// Write result to source files instead of stdout.
 	if t.opts.persistChanges {
 		params = append(params, "-w")
@@ -0,0 +1,94 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package bench provides a task to run benchmarks with the Go toolchain "test" command, to store runs keyed by Git
+// commit and to compare runs statistically.
+//
+// See https://pkg.go.dev/testing#hdr-Benchmarks and https://golang.org/design/14313-benchmark-format for more details.
+package bench
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Task is a task to run benchmarks with the Go toolchain "test" command.
+type Task struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+// All tests are skipped so that only benchmarks are run.
+// Note that configured flags are applied after the "GOFLAGS" environment variable and could overwrite already defined
+// flags.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func (t *Task) BuildParams() []string {
+	params := []string{"test"}
+
+	params = append(params, t.opts.Params()...)
+
+	params = append(params,
+		"-run=^$",
+		fmt.Sprintf("-bench=%s", t.opts.Pattern),
+		fmt.Sprintf("-count=%d", t.opts.Count),
+	)
+
+	if t.opts.BenchTime != "" {
+		params = append(params, fmt.Sprintf("-benchtime=%s", t.opts.BenchTime))
+	}
+
+	if t.opts.EnableMemoryStats {
+		params = append(params, "-benchmem")
+	}
+
+	if len(t.opts.Flags) > 0 {
+		params = append(params, t.opts.Flags...)
+	}
+
+	params = append(params, t.opts.Pkgs...)
+
+	return params
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.Env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the unique task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// New creates a new task to run benchmarks with the Go toolchain "test" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(ac app.Config, opts ...Option) (*Task, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+
+	// Store benchmark runs within the application specific subdirectory.
+	if opt.OutputDir == "" {
+		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
+	}
+
+	return &Task{ac: ac, opts: opt}, nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// exactTestMaxSamples is the maximum number of total samples for which the exact distribution of the Mann-Whitney U
+// statistic is computed instead of using the normal approximation.
+const exactTestMaxSamples = 50
+
+// Comparison is the statistical comparison of two benchmark runs.
+type Comparison struct {
+	// BaseCommit is the Git commit of the base run.
+	BaseCommit string `json:"baseCommit"`
+
+	// Deltas are the changes of all benchmark units that exist in both runs sorted by benchmark key and unit.
+	Deltas []Delta `json:"deltas"`
+
+	// HeadCommit is the Git commit of the head run.
+	HeadCommit string `json:"headCommit"`
+}
+
+// Regressions returns all deltas that are considered to be regressions.
+func (c *Comparison) Regressions() []Delta {
+	var regressions []Delta
+	for _, d := range c.Deltas {
+		if d.Regression {
+			regressions = append(regressions, d)
+		}
+	}
+	return regressions
+}
+
+// String returns a human-readable table of all deltas.
+// Changes that are not statistically significant are indicated with "~" like the "benchstat" tool does.
+func (c *Comparison) String() string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "benchmark\tunit\t%s\t%s\tdelta\tp-value\n",
+		shortCommit(c.BaseCommit), shortCommit(c.HeadCommit),
+	)
+	for _, d := range c.Deltas {
+		delta := "~"
+		if d.Significant {
+			delta = fmt.Sprintf("%+.2f%%", d.Change*100)
+		}
+		if d.Regression {
+			delta += " (regression)"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%.4g (n=%d)\t%.4g (n=%d)\t%s\t%.3f\n",
+			d.Key, d.Unit, d.Base.Mean, d.Base.N, d.Head.Mean, d.Head.N, delta, d.PValue,
+		)
+	}
+	_ = tw.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// Delta is the change of a benchmark unit between two runs.
+type Delta struct {
+	// Base is the summary of the samples of the base run.
+	Base Summary `json:"base"`
+
+	// Change is the relative change of the mean from the base to the head run, e.g. 0.1 for an increase of 10%.
+	Change float64 `json:"change"`
+
+	// Head is the summary of the samples of the head run.
+	Head Summary `json:"head"`
+
+	// Key is the key of the benchmark.
+	Key string `json:"key"`
+
+	// PValue is the p-value of the two-sided Mann-Whitney U-test.
+	PValue float64 `json:"pValue"`
+
+	// Regression indicates whether the change is statistically significant and worse than the regression threshold.
+	Regression bool `json:"regression"`
+
+	// Significant indicates whether the change is statistically significant.
+	Significant bool `json:"significant"`
+
+	// Unit is the unit of the measured values, e.g. "ns/op".
+	Unit string `json:"unit"`
+}
+
+// Summary is the statistical summary of samples.
+type Summary struct {
+	// Mean is the arithmetic mean of the samples.
+	Mean float64 `json:"mean"`
+
+	// N is the number of samples.
+	N int `json:"n"`
+}
+
+// Compare compares the given base and head runs statistically.
+// A change is statistically significant when the p-value of the two-sided Mann-Whitney U-test is below the given
+// significance level (alpha) and it is a regression when the relative change of the mean is also worse than the given
+// threshold. For units that are rates, e.g. "MB/s", higher values are better while lower values are better for all
+// other units like "ns/op".
+func Compare(base, head *Run, threshold, alpha float64) *Comparison {
+	c := &Comparison{BaseCommit: base.Commit, HeadCommit: head.Commit}
+	baseSamples := base.Samples()
+
+	for key, units := range head.Samples() {
+		for unit, headValues := range units {
+			baseValues, ok := baseSamples[key][unit]
+			if !ok {
+				continue
+			}
+
+			d := Delta{
+				Base:   summarize(baseValues),
+				Head:   summarize(headValues),
+				Key:    key,
+				PValue: MannWhitneyUTest(baseValues, headValues),
+				Unit:   unit,
+			}
+			if d.Base.Mean != 0 {
+				d.Change = (d.Head.Mean - d.Base.Mean) / math.Abs(d.Base.Mean)
+			}
+			d.Significant = d.PValue < alpha
+			worse := d.Change > threshold
+			if isHigherBetter(unit) {
+				worse = -d.Change > threshold
+			}
+			d.Regression = d.Significant && worse
+			c.Deltas = append(c.Deltas, d)
+		}
+	}
+
+	sort.Slice(c.Deltas, func(i, j int) bool {
+		if c.Deltas[i].Key != c.Deltas[j].Key {
+			return c.Deltas[i].Key < c.Deltas[j].Key
+		}
+		return c.Deltas[i].Unit < c.Deltas[j].Unit
+	})
+	return c
+}
+
+// MannWhitneyUTest returns the p-value of the two-sided Mann-Whitney U-test for the given samples.
+// The exact distribution of the U statistic is used for small samples without ties, otherwise the normal
+// approximation with tie and continuity correction. A p-value of 1 is returned when any of the samples is empty.
+//
+// See https://en.wikipedia.org/wiki/Mann%E2%80%93Whitney_U_test for more details.
+func MannWhitneyUTest(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	ranks, tieSum := rank(x, y)
+	var r1 float64
+	for i := 0; i < n1; i++ {
+		r1 += ranks[i]
+	}
+	u := r1 - float64(n1*(n1+1))/2
+
+	if tieSum == 0 && n1+n2 <= exactTestMaxSamples {
+		return exactPValue(n1, n2, u)
+	}
+
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactPValue returns the two-sided p-value of the given U statistic using the exact distribution of the U statistic
+// for samples of the given sizes without ties.
+func exactPValue(n1, n2 int, u float64) float64 {
+	// counts[i][j][k] is the number of arrangements of i and j samples with a U statistic of k and is computed through
+	// the recurrence relation f(i, j, k) = f(i-1, j, k-j) + f(i, j-1, k).
+	counts := make([][][]float64, n1+1)
+	for i := 0; i <= n1; i++ {
+		counts[i] = make([][]float64, n2+1)
+		for j := 0; j <= n2; j++ {
+			counts[i][j] = make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for k := 0; k <= i*j; k++ {
+				if k-j >= 0 && k-j <= (i-1)*j {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				if k <= i*(j-1) {
+					counts[i][j][k] += counts[i][j-1][k]
+				}
+			}
+		}
+	}
+
+	dist := counts[n1][n2]
+	var total, lower, upper float64
+	for k, c := range dist {
+		total += c
+		if float64(k) <= u {
+			lower += c
+		}
+		if float64(k) >= u {
+			upper += c
+		}
+	}
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
+
+// isHigherBetter checks whether higher values of the given unit are better, e.g. for rates like "MB/s".
+func isHigherBetter(unit string) bool {
+	return strings.HasSuffix(unit, "/s")
+}
+
+// rank returns the ranks of the concatenated given samples where tied values are assigned the average of their ranks.
+// It also returns the sum of t^3-t over all groups of t tied values that is used for the tie correction.
+func rank(x, y []float64) ([]float64, float64) {
+	type sample struct {
+		idx   int
+		value float64
+	}
+	all := make([]sample, 0, len(x)+len(y))
+	for i, v := range x {
+		all = append(all, sample{idx: i, value: v})
+	}
+	for i, v := range y {
+		all = append(all, sample{idx: len(x) + i, value: v})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	ranks := make([]float64, len(all))
+	var tieSum float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		// Ranks are 1-based so the average rank of the group from i to j-1 is the mean of i+1 and j.
+		avg := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			ranks[all[k].idx] = avg
+		}
+		if t := float64(j - i); t > 1 {
+			tieSum += t*t*t - t
+		}
+		i = j
+	}
+	return ranks, tieSum
+}
+
+// shortCommit returns the abbreviated form of the given Git commit hash.
+func shortCommit(commit string) string {
+	const shortLen = 12
+	if len(commit) > shortLen {
+		return commit[:shortLen]
+	}
+	return commit
+}
+
+// summarize returns the statistical summary of the given samples.
+func summarize(values []float64) Summary {
+	s := Summary{N: len(values)}
+	for _, v := range values {
+		s.Mean += v
+	}
+	if s.N > 0 {
+		s.Mean /= float64(s.N)
+	}
+	return s
+}
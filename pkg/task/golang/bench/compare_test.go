@@ -0,0 +1,90 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMannWhitneyUTest(t *testing.T) {
+	tests := []struct {
+		name   string
+		x      []float64
+		y      []float64
+		pValue float64
+		delta  float64
+	}{
+		{name: "empty sample", x: nil, y: []float64{1, 2, 3}, pValue: 1},
+		{name: "identical samples", x: []float64{1, 2, 3, 4}, y: []float64{1, 2, 3, 4}, pValue: 1},
+		// The exact distribution of U for 3 and 3 samples has 20 arrangements of which 1 has U=0.
+		{name: "exact separated samples", x: []float64{1, 2, 3}, y: []float64{4, 5, 6}, pValue: 0.1},
+		{name: "exact interleaved samples", x: []float64{1, 3, 5}, y: []float64{2, 4, 6}, pValue: 0.7},
+		{
+			name:   "approximation with ties",
+			x:      []float64{1, 1, 2, 2, 3, 3, 4, 4, 5, 5},
+			y:      []float64{6, 6, 7, 7, 8, 8, 9, 9, 10, 10},
+			pValue: 0.0002,
+			delta:  0.0001,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delta := tc.delta
+			if delta == 0 {
+				delta = 1e-9
+			}
+			require.InDelta(t, tc.pValue, MannWhitneyUTest(tc.x, tc.y), delta)
+			require.InDelta(t, tc.pValue, MannWhitneyUTest(tc.y, tc.x), delta)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	newRun := func(commit string, nsPerOp ...float64) *Run {
+		r := &Run{Commit: commit}
+		for _, v := range nsPerOp {
+			r.Results = append(r.Results, Result{Name: "BenchmarkA-8", Values: map[string]float64{"ns/op": v}})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name        string
+		base        *Run
+		head        *Run
+		significant bool
+		regression  bool
+	}{
+		{
+			name:        "regression",
+			base:        newRun("base", 100, 101, 102, 103, 104),
+			head:        newRun("head", 200, 201, 202, 203, 204),
+			significant: true,
+			regression:  true,
+		},
+		{
+			name:        "improvement",
+			base:        newRun("base", 200, 201, 202, 203, 204),
+			head:        newRun("head", 100, 101, 102, 103, 104),
+			significant: true,
+		},
+		{
+			name: "noise",
+			base: newRun("base", 100, 102, 104, 106, 108),
+			head: newRun("head", 101, 103, 105, 107, 109),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Compare(tc.base, tc.head, 0.05, 0.05)
+			require.Len(t, c.Deltas, 1)
+			require.Equal(t, tc.significant, c.Deltas[0].Significant)
+			require.Equal(t, tc.regression, c.Deltas[0].Regression)
+		})
+	}
+}
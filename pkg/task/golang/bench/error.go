@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrRegression indicates that at least one benchmark regressed beyond the configured threshold.
+const ErrRegression = wErr.ErrString("benchmark regression")
@@ -0,0 +1,224 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+)
+
+const (
+	// DefaultCount is the default number of times each benchmark is run.
+	// Statistically meaningful comparisons require multiple samples, the exact Mann-Whitney U-test used for comparisons
+	// can not report a significant change for less than 4 samples per run at the default significance level.
+	DefaultCount = 10
+
+	// DefaultOutputDirName is the default output directory name for stored benchmark runs.
+	DefaultOutputDirName = "bench"
+
+	// DefaultPattern is the default regular expression to select benchmarks which matches all benchmarks.
+	DefaultPattern = "."
+
+	// DefaultRegressionThreshold is the default relative change of a benchmark, e.g. 0.05 for 5%, above which a
+	// statistically significant change is considered to be a regression.
+	DefaultRegressionThreshold = 0.05
+
+	// DefaultSignificanceLevel is the default significance level (alpha) below which the p-value of a change is
+	// considered to be statistically significant.
+	DefaultSignificanceLevel = 0.05
+
+	// taskName is the name of the task.
+	taskName = "go/bench"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	*taskGo.Options
+
+	// BaseRev is the Git revision whose stored run is used as base to compare the current run with.
+	// No comparison is done when empty.
+	BaseRev string
+
+	// BenchTime is the amount of time or iterations each benchmark runs, e.g. "1s" or "100x".
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	BenchTime string
+
+	// Count is the number of times each benchmark is run.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	Count int
+
+	// EnableMemoryStats indicates whether memory allocation statistics should be reported.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	EnableMemoryStats bool
+
+	// Flags are additional flags that are passed to the Go "test" command along with the shared Go flags.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	Flags []string
+
+	// name is the task name.
+	name string
+
+	// OutputDir is the output directory, relative to the project root, for stored benchmark runs.
+	OutputDir string
+
+	// Pattern is the regular expression to select the benchmarks to run.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	Pattern string
+
+	// Pkgs is a list of packages to benchmark.
+	Pkgs []string
+
+	// RegressionThreshold is the relative change of a benchmark above which a statistically significant change is
+	// considered to be a regression.
+	RegressionThreshold float64
+
+	// SignificanceLevel is the significance level (alpha) below which the p-value of a change is considered to be
+	// statistically significant.
+	SignificanceLevel float64
+
+	// taskGoOpts are shared Go toolchain task options.
+	taskGoOpts []taskGo.Option
+}
+
+// NewOptions creates new task options.
+// It returns an error of type *task.ErrTask when the shared Go toolchain task options are invalid.
+func NewOptions(opts ...Option) (*Options, error) {
+	opt := &Options{
+		Count:               DefaultCount,
+		name:                taskName,
+		Pattern:             DefaultPattern,
+		RegressionThreshold: DefaultRegressionThreshold,
+		SignificanceLevel:   DefaultSignificanceLevel,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	goOpts, goOptsErr := taskGo.NewOptions(opt.taskGoOpts...)
+	if goOptsErr != nil {
+		return nil, goOptsErr
+	}
+	opt.Options = goOpts
+
+	return opt, nil
+}
+
+// WithBaseRev sets the Git revision whose stored run is used as base to compare the current run with.
+func WithBaseRev(baseRev string) Option {
+	return func(o *Options) {
+		o.BaseRev = baseRev
+	}
+}
+
+// WithBenchTime sets the amount of time or iterations each benchmark runs, e.g. "1s" or "100x".
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithBenchTime(benchTime string) Option {
+	return func(o *Options) {
+		o.BenchTime = benchTime
+	}
+}
+
+// WithCount sets the number of times each benchmark is run.
+// Defaults to DefaultCount.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithCount(count int) Option {
+	return func(o *Options) {
+		if count > 0 {
+			o.Count = count
+		}
+	}
+}
+
+// WithFlags sets additional flags that are passed to the Go "test" command along with the shared Go flags.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.Flags = append(o.Flags, flags...)
+	}
+}
+
+// WithGoOptions sets shared Go toolchain task options.
+func WithGoOptions(goOpts ...taskGo.Option) Option {
+	return func(o *Options) {
+		o.taskGoOpts = append(o.taskGoOpts, goOpts...)
+	}
+}
+
+// WithMemoryStats indicates whether memory allocation statistics should be reported.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithMemoryStats(enableMemoryStats bool) Option {
+	return func(o *Options) {
+		o.EnableMemoryStats = enableMemoryStats
+	}
+}
+
+// WithOutputDir sets the output directory, relative to the project root, for stored benchmark runs.
+// Defaults to DefaultOutputDirName within the application specific output directory.
+func WithOutputDir(outputDir string) Option {
+	return func(o *Options) {
+		o.OutputDir = outputDir
+	}
+}
+
+// WithPattern sets the regular expression to select the benchmarks to run.
+// Defaults to DefaultPattern.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithPattern(pattern string) Option {
+	return func(o *Options) {
+		if pattern != "" {
+			o.Pattern = pattern
+		}
+	}
+}
+
+// WithPkgs sets the list of packages to benchmark.
+func WithPkgs(pkgs ...string) Option {
+	return func(o *Options) {
+		o.Pkgs = append(o.Pkgs, pkgs...)
+	}
+}
+
+// WithRegressionThreshold sets the relative change, e.g. 0.05 for 5%, above which a statistically significant change
+// is considered to be a regression.
+// Defaults to DefaultRegressionThreshold.
+func WithRegressionThreshold(threshold float64) Option {
+	return func(o *Options) {
+		if threshold >= 0 {
+			o.RegressionThreshold = threshold
+		}
+	}
+}
+
+// WithSignificanceLevel sets the significance level (alpha) below which the p-value of a change is considered to be
+// statistically significant.
+// Defaults to DefaultSignificanceLevel.
+func WithSignificanceLevel(alpha float64) Option {
+	return func(o *Options) {
+		if alpha > 0 && alpha < 1 {
+			o.SignificanceLevel = alpha
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// benchmarkPrefix is the prefix of all benchmark result lines.
+	benchmarkPrefix = "Benchmark"
+
+	// configKeyPkg is the configuration key of the package the following benchmark results belong to.
+	configKeyPkg = "pkg"
+
+	// runFileExt is the file extension of stored benchmark runs.
+	runFileExt = ".json"
+)
+
+// Report is the report of a benchmark task execution.
+type Report struct {
+	// Comparison is the comparison of the run with the stored run of the base revision.
+	// It is nil when no base revision has been configured.
+	Comparison *Comparison
+
+	// Run is the benchmark run.
+	Run *Run
+
+	// RunFile is the path of the stored run.
+	RunFile string
+}
+
+// Result is the result of a single benchmark execution.
+type Result struct {
+	// Iterations is the number of iterations the benchmark has been run.
+	Iterations int64 `json:"iterations"`
+
+	// Name is the name of the benchmark including the "GOMAXPROCS" suffix, e.g. "BenchmarkEncode-8".
+	Name string `json:"name"`
+
+	// Pkg is the import path of the package the benchmark belongs to.
+	Pkg string `json:"pkg,omitempty"`
+
+	// Values are the measured values mapped by their unit, e.g. "ns/op", "B/op" or "allocs/op".
+	Values map[string]float64 `json:"values"`
+}
+
+// Key returns the key that identifies the benchmark across runs.
+func (r Result) Key() string {
+	if r.Pkg == "" {
+		return r.Name
+	}
+	return r.Pkg + "." + r.Name
+}
+
+// Run is a benchmark run.
+type Run struct {
+	// Commit is the Git commit the benchmarks have been run for.
+	Commit string `json:"commit"`
+
+	// Config is the configuration reported by the benchmark output, e.g. "goos", "goarch" or "cpu".
+	// The package specific "pkg" configuration is stored in the results instead.
+	Config map[string]string `json:"config,omitempty"`
+
+	// Date is the date and time the benchmarks have been run.
+	Date time.Time `json:"date"`
+
+	// Results are all benchmark results in the order they have been reported.
+	Results []Result `json:"results"`
+}
+
+// Samples returns all measured values of all benchmarks mapped by the benchmark key and unit.
+func (r *Run) Samples() map[string]map[string][]float64 {
+	samples := make(map[string]map[string][]float64)
+	for _, res := range r.Results {
+		units, ok := samples[res.Key()]
+		if !ok {
+			units = make(map[string][]float64)
+			samples[res.Key()] = units
+		}
+		for unit, v := range res.Values {
+			units[unit] = append(units[unit], v)
+		}
+	}
+	return samples
+}
+
+// Save stores the run as JSON file named by its commit within the given directory.
+// It returns the path of the stored file.
+func (r *Run) Save(dir string) (string, error) {
+	if r.Commit == "" {
+		return "", errors.New("store benchmark run: missing commit")
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("create directory %q: %w", dir, err)
+	}
+
+	data, marshalErr := json.MarshalIndent(r, "", "  ")
+	if marshalErr != nil {
+		return "", fmt.Errorf("encode benchmark run: %w", marshalErr)
+	}
+	path := RunFilePath(dir, r.Commit)
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("write benchmark run %q: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadRun loads the stored run of the given commit from the given directory.
+func LoadRun(dir, commit string) (*Run, error) {
+	path := RunFilePath(dir, commit)
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("read benchmark run %q: %w", path, readErr)
+	}
+
+	r := &Run{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("decode benchmark run %q: %w", path, err)
+	}
+	return r, nil
+}
+
+// ParseOutput parses the output of the Go toolchain "test" command in benchmark format into a run.
+// The commit and date of the returned run are not set.
+//
+// See https://golang.org/design/14313-benchmark-format for more details.
+func ParseOutput(r io.Reader) (*Run, error) {
+	run := &Run{Config: make(map[string]string)}
+	var pkg string
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+
+		if strings.HasPrefix(line, benchmarkPrefix) {
+			if res, ok := parseResultLine(line); ok {
+				res.Pkg = pkg
+				run.Results = append(run.Results, res)
+			}
+			continue
+		}
+
+		if key, value, ok := parseConfigLine(line); ok {
+			if key == configKeyPkg {
+				pkg = value
+				continue
+			}
+			run.Config[key] = value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read benchmark output: %w", err)
+	}
+	return run, nil
+}
+
+// RunFilePath returns the path of the stored run of the given commit within the given directory.
+func RunFilePath(dir, commit string) string {
+	return filepath.Join(dir, commit+runFileExt)
+}
+
+// parseConfigLine parses a configuration line in the "key: value" format.
+// Keys must start with a lowercase letter and must not contain whitespaces.
+func parseConfigLine(line string) (string, string, bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found || key == "" || key[0] < 'a' || key[0] > 'z' || strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// parseResultLine parses a benchmark result line in the "BenchmarkName iterations value unit..." format.
+// It returns false when the line is not a result line, e.g. when it is a log line of a benchmark that starts with the
+// benchmark name or contains values that are not numbers.
+func parseResultLine(line string) (Result, bool) {
+	fields := strings.Fields(line)
+	// A result line consists of the name, the number of iterations and at least one pair of value and unit.
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return Result{}, false
+	}
+
+	iterations, itErr := strconv.ParseInt(fields[1], 10, 64)
+	if itErr != nil {
+		return Result{}, false
+	}
+
+	res := Result{Iterations: iterations, Name: fields[0], Values: make(map[string]float64)}
+	for i := 2; i < len(fields); i += 2 {
+		v, vErr := strconv.ParseFloat(fields[i], 64)
+		if vErr != nil {
+			return Result{}, false
+		}
+		res.Values[fields[i+1]] = v
+	}
+	return res, true
+}
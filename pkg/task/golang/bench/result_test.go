@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResultLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		res  Result
+		ok   bool
+	}{
+		{
+			name: "single value",
+			line: "BenchmarkEncode-8   	 1000000	      1052 ns/op",
+			res:  Result{Iterations: 1000000, Name: "BenchmarkEncode-8", Values: map[string]float64{"ns/op": 1052}},
+			ok:   true,
+		},
+		{
+			name: "multiple values",
+			line: "BenchmarkEncode-8 500 2.5 ns/op 64 B/op 2 allocs/op",
+			res: Result{
+				Iterations: 500,
+				Name:       "BenchmarkEncode-8",
+				Values:     map[string]float64{"ns/op": 2.5, "B/op": 64, "allocs/op": 2},
+			},
+			ok: true,
+		},
+		{name: "missing unit", line: "BenchmarkEncode-8 500 2.5", ok: false},
+		{name: "odd number of fields", line: "BenchmarkEncode-8 500 2.5 ns/op 64", ok: false},
+		{name: "log line", line: "BenchmarkEncode-8: encoding took too long", ok: false},
+		{name: "invalid iterations", line: "BenchmarkEncode-8 many 2.5 ns/op", ok: false},
+		{name: "invalid value", line: "BenchmarkEncode-8 500 fast ns/op", ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, ok := parseResultLine(tc.line)
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				require.Equal(t, tc.res, res)
+			}
+		})
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		config  map[string]string
+		results []Result
+	}{
+		{
+			name: "multiple packages",
+			output: strings.Join([]string{
+				"goos: linux",
+				"goarch: amd64",
+				"pkg: example.com/a",
+				"BenchmarkA-8 100 10 ns/op",
+				"BenchmarkA-8 a log line with 3 words",
+				"pkg: example.com/b",
+				"BenchmarkB-8 200 20 ns/op",
+				"PASS",
+				"ok  	example.com/b	1.234s",
+			}, "\n"),
+			config: map[string]string{"goos": "linux", "goarch": "amd64"},
+			results: []Result{
+				{Iterations: 100, Name: "BenchmarkA-8", Pkg: "example.com/a", Values: map[string]float64{"ns/op": 10}},
+				{Iterations: 200, Name: "BenchmarkB-8", Pkg: "example.com/b", Values: map[string]float64{"ns/op": 20}},
+			},
+		},
+		{
+			name:   "no results",
+			output: "PASS\nok  	example.com/a	0.001s\n",
+			config: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			run, err := ParseOutput(strings.NewReader(tc.output))
+			require.NoError(t, err)
+			require.Equal(t, tc.config, run.Config)
+			require.Equal(t, tc.results, run.Results)
+		})
+	}
+}
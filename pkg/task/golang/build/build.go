@@ -5,7 +5,9 @@
 package build
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/svengreb/wand/pkg/app"
 	"github.com/svengreb/wand/pkg/task"
@@ -27,18 +29,21 @@ type Task struct {
 func (t *Task) BuildParams() []string {
 	params := []string{"build"}
 
-	params = append(params, taskGo.BuildGoOptions(t.opts.taskGoOpts...)...)
+	params = append(params, t.opts.Params()...)
+
+	if t.opts.Mode != ModeDefault {
+		params = append(params, fmt.Sprintf("-buildmode=%s", t.opts.Mode))
+	}
+
+	if t.opts.PGOProfile != "" {
+		params = append(params, fmt.Sprintf("-pgo=%s", t.opts.PGOProfile))
+	}
 
 	if len(t.opts.Flags) > 0 {
 		params = append(params, t.opts.Flags...)
 	}
 
-	params = append(
-		params,
-		"-o",
-		filepath.Join(t.opts.OutputDir, t.opts.BinaryArtifactName),
-		t.ac.PkgImportPath,
-	)
+	params = append(params, "-o", t.OutputPath(), t.ac.PkgImportPath)
 
 	return params
 }
@@ -63,10 +68,38 @@ func (t *Task) Options() task.Options {
 	return *t.opts
 }
 
+// OutputPath returns the path, relative to the project root, to the build artifact.
+// The file name is suffixed based on the explicitly requested build mode and target operating system, e.g. ".exe" for
+// Windows executables or ".so" for C shared libraries, unless the binary artifact name already ends with the suffix.
+// The binary artifact name is used as is for ModeDefault.
+func (t *Task) OutputPath() string {
+	goos, _, _ := strings.Cut(taskGo.TargetPlatform(t.opts.Env), "/")
+	name := t.opts.BinaryArtifactName
+	if suffix := t.opts.Mode.FileSuffix(goos); !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+	return filepath.Join(t.opts.OutputDir, name)
+}
+
+// OutputPaths returns the paths, relative to the project root, to all files produced by the build.
+// This includes the C header file that is generated for build modes like ModeCArchive and ModeCShared.
+func (t *Task) OutputPaths() []string {
+	p := t.OutputPath()
+	paths := []string{p}
+	if t.opts.Mode.HasHeader() {
+		paths = append(paths, strings.TrimSuffix(p, filepath.Ext(p))+".h")
+	}
+	return paths
+}
+
 // New creates a new task for the Go toolchain "build" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
 //nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
-func New(ac app.Config, opts ...Option) *Task {
-	opt := NewOptions(opts...)
+func New(ac app.Config, opts ...Option) (*Task, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
 
 	if opt.BinaryArtifactName == "" {
 		opt.BinaryArtifactName = ac.Name
@@ -77,5 +110,5 @@ func New(ac app.Config, opts ...Option) *Task {
 		opt.OutputDir = ac.BaseOutputDir
 	}
 
-	return &Task{ac: ac, opts: opt}
+	return &Task{ac: ac, opts: opt}, nil
 }
@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package build
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/svengreb/wand/pkg/app"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+)
+
+func TestTaskOutputPaths(t *testing.T) {
+	ac := app.Config{BaseOutputDir: filepath.Join("out", "app"), Name: "app", PkgImportPath: "example.com/app"}
+	windows := taskGo.WithEnv(map[string]string{taskGo.DefaultEnvVarGOOS: "windows", taskGo.DefaultEnvVarGOARCH: "amd64"})
+	linux := taskGo.WithEnv(map[string]string{taskGo.DefaultEnvVarGOOS: "linux", taskGo.DefaultEnvVarGOARCH: "amd64"})
+	out := func(name string) string { return filepath.Join(ac.BaseOutputDir, name) }
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{name: "default", opts: []Option{WithGoOptions(linux)}, want: []string{out("app")}},
+		{name: "default for windows", opts: []Option{WithGoOptions(windows)}, want: []string{out("app")}},
+		{
+			name: "executable for windows",
+			opts: []Option{WithGoOptions(windows), WithMode(ModeExe)},
+			want: []string{out("app.exe")},
+		},
+		{
+			name: "executable for windows with suffix",
+			opts: []Option{WithGoOptions(windows), WithMode(ModeExe), WithBinaryArtifactName("app.exe")},
+			want: []string{out("app.exe")},
+		},
+		{
+			name: "C archive",
+			opts: []Option{WithGoOptions(linux), WithMode(ModeCArchive), WithBinaryArtifactName("libapp")},
+			want: []string{out("libapp.a"), out("libapp.h")},
+		},
+		{
+			name: "C shared library for windows",
+			opts: []Option{WithGoOptions(windows), WithMode(ModeCShared)},
+			want: []string{out("app.dll"), out("app.h")},
+		},
+		{
+			name: "plugin in custom output directory",
+			opts: []Option{WithGoOptions(linux), WithMode(ModePlugin), WithOutputDir("plugins")},
+			want: []string{filepath.Join("plugins", "app.so")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := New(ac, tc.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, task.OutputPaths())
+			require.Equal(t, tc.want[0], task.OutputPath())
+		})
+	}
+}
+
+func TestTaskBuildParamsPGO(t *testing.T) {
+	ac := app.Config{BaseOutputDir: "out", Name: "app", PathRel: ".", PkgImportPath: "example.com/app"}
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: ""},
+		{name: "disabled", opts: []Option{WithPGOProfile(PGOProfileOff)}, want: "-pgo=off"},
+		{name: "profile", opts: []Option{WithPGOProfile("merged.pgo")}, want: "-pgo=merged.pgo"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := New(ac, tc.opts...)
+			require.NoError(t, err)
+			var pgo string
+			for _, p := range task.BuildParams() {
+				if strings.HasPrefix(p, "-pgo") {
+					pgo = p
+				}
+			}
+			require.Equal(t, tc.want, pgo, `the "auto" mode of the Go toolchain must apply by default`)
+		})
+	}
+}
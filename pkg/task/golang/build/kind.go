@@ -0,0 +1,142 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package build
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ModeNameCArchive is the Mode name for C archives.
+	ModeNameCArchive = "c-archive"
+	// ModeNameCShared is the Mode name for C shared libraries.
+	ModeNameCShared = "c-shared"
+	// ModeNameDefault is the Mode name for the default build mode of the Go toolchain.
+	ModeNameDefault = "default"
+	// ModeNameExe is the Mode name for executables.
+	ModeNameExe = "exe"
+	// ModeNamePIE is the Mode name for position independent executables.
+	ModeNamePIE = "pie"
+	// ModeNamePlugin is the Mode name for Go plugins.
+	ModeNamePlugin = "plugin"
+	// ModeNameUnknown is the name for a unknown Mode.
+	ModeNameUnknown = "unknown"
+)
+
+const (
+	// ModeDefault is the Mode for the default build mode of the Go toolchain where the "-buildmode" flag is omitted.
+	ModeDefault Mode = iota
+	// ModeExe is the Mode for executables.
+	ModeExe
+	// ModePIE is the Mode for position independent executables.
+	ModePIE
+	// ModeCArchive is the Mode for C archives, including a C header file, that can be linked into C programs.
+	ModeCArchive
+	// ModeCShared is the Mode for C shared libraries, including a C header file, that can be loaded by C programs.
+	ModeCShared
+	// ModePlugin is the Mode for Go plugins that can be loaded by Go programs through the "plugin" package.
+	ModePlugin
+)
+
+// Mode defines a build mode of the Go toolchain "build" command.
+//
+// See `go help buildmode` for more details.
+type Mode uint32
+
+// FileSuffix returns the suffix that is appended to the file name of a build artifact of the mode for the given target
+// operating system.
+// The suffix is empty for ModeDefault so that the file name of the build artifact is used as is when no build mode has
+// been requested explicitly.
+func (m Mode) FileSuffix(goos string) string {
+	switch m {
+	case ModeDefault:
+		return ""
+	case ModeCArchive:
+		return ".a"
+	case ModeCShared:
+		switch goos {
+		case "darwin", "ios":
+			return ".dylib"
+		case "windows":
+			return ".dll"
+		}
+		return ".so"
+	case ModePlugin:
+		return ".so"
+	}
+	if goos == "windows" {
+		return ".exe"
+	}
+	return ""
+}
+
+// HasHeader indicates whether the mode generates a C header file along with the build artifact.
+func (m Mode) HasHeader() bool {
+	return m == ModeCArchive || m == ModeCShared
+}
+
+// MarshalText returns the textual representation of itself.
+func (m Mode) MarshalText() ([]byte, error) {
+	switch m {
+	case ModeDefault:
+		return []byte(ModeNameDefault), nil
+	case ModeExe:
+		return []byte(ModeNameExe), nil
+	case ModePIE:
+		return []byte(ModeNamePIE), nil
+	case ModeCArchive:
+		return []byte(ModeNameCArchive), nil
+	case ModeCShared:
+		return []byte(ModeNameCShared), nil
+	case ModePlugin:
+		return []byte(ModeNamePlugin), nil
+	}
+
+	return nil, fmt.Errorf("not a valid mode %d", m)
+}
+
+// RequiresCgo indicates whether the mode requires cgo to be enabled.
+func (m Mode) RequiresCgo() bool {
+	return m == ModeCArchive || m == ModeCShared || m == ModePlugin
+}
+
+func (m Mode) String() string {
+	if b, err := m.MarshalText(); err == nil {
+		return string(b)
+	}
+	return ModeNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (m *Mode) UnmarshalText(text []byte) error {
+	parsed, err := ParseMode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// ParseMode takes a mode name and returns the Mode constant.
+func ParseMode(name string) (Mode, error) {
+	switch strings.ToLower(name) {
+	case ModeNameDefault:
+		return ModeDefault, nil
+	case ModeNameExe:
+		return ModeExe, nil
+	case ModeNamePIE:
+		return ModePIE, nil
+	case ModeNameCArchive:
+		return ModeCArchive, nil
+	case ModeNameCShared:
+		return ModeCShared, nil
+	case ModeNamePlugin:
+		return ModePlugin, nil
+	}
+
+	var m Mode
+	return m, fmt.Errorf("not a valid mode: %q", name)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModeFileSuffix(t *testing.T) {
+	tests := []struct {
+		mode Mode
+		goos string
+		want string
+	}{
+		{mode: ModeDefault, goos: "linux", want: ""},
+		{mode: ModeDefault, goos: "windows", want: ""},
+		{mode: ModeExe, goos: "linux", want: ""},
+		{mode: ModeExe, goos: "windows", want: ".exe"},
+		{mode: ModePIE, goos: "darwin", want: ""},
+		{mode: ModePIE, goos: "windows", want: ".exe"},
+		{mode: ModeCArchive, goos: "linux", want: ".a"},
+		{mode: ModeCArchive, goos: "windows", want: ".a"},
+		{mode: ModeCShared, goos: "linux", want: ".so"},
+		{mode: ModeCShared, goos: "darwin", want: ".dylib"},
+		{mode: ModeCShared, goos: "ios", want: ".dylib"},
+		{mode: ModeCShared, goos: "windows", want: ".dll"},
+		{mode: ModePlugin, goos: "linux", want: ".so"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.mode.String()+"/"+tc.goos, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.mode.FileSuffix(tc.goos))
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, m := range []Mode{ModeDefault, ModeExe, ModePIE, ModeCArchive, ModeCShared, ModePlugin} {
+		t.Run(m.String(), func(t *testing.T) {
+			parsed, err := ParseMode(m.String())
+			require.NoError(t, err)
+			require.Equal(t, m, parsed)
+		})
+	}
+
+	_, err := ParseMode("archive")
+	require.Error(t, err)
+}
@@ -4,6 +4,9 @@
 package build
 
 import (
+	"fmt"
+
+	"github.com/svengreb/wand/pkg/task"
 	taskGo "github.com/svengreb/wand/pkg/task/golang"
 )
 
@@ -11,6 +14,15 @@ const (
 	// DefaultDistOutputDirName is the default directory name for production and distribution builds.
 	DefaultDistOutputDirName = "dist"
 
+	// DefaultPGOProfileFileName is the default file name of the profile for profile-guided optimization that is
+	// detected in the main package directory by the Go toolchain.
+	//
+	// See https://go.dev/doc/pgo for more details.
+	DefaultPGOProfileFileName = "default.pgo"
+
+	// PGOProfileOff is the PGOProfile value to disable profile-guided optimization.
+	PGOProfileOff = "off"
+
 	// taskName is the name of the task.
 	taskName = "go/build"
 )
@@ -37,18 +49,34 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
 	Flags []string
 
+	// Mode is the build mode that determines the kind of build artifacts and their file names.
+	//
+	// See `go help buildmode` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Build_modes
+	Mode Mode
+
 	// name is the task name.
 	name string
 
 	// OutputDir is the output directory, relative to the project root, for compilation artifacts.
 	OutputDir string
 
+	// PGOProfile is the path to the profile for profile-guided optimization or PGOProfileOff to disable it.
+	// When empty the "-pgo" flag is omitted so that the default "auto" mode of the Go toolchain applies which uses the
+	// profile named DefaultPGOProfileFileName in the main package directory, if any.
+	//
+	// See `go help build` and the Go PGO documentation for more details:
+	//   - https://go.dev/doc/pgo
+	PGOProfile string
+
 	// taskGoOpts are shared Go toolchain task options.
 	taskGoOpts []taskGo.Option
 }
 
 // NewOptions creates new task options.
-func NewOptions(opts ...Option) *Options {
+// It returns an error of type *task.ErrTask when the shared Go toolchain task options are invalid or the build mode
+// requires cgo while it has been disabled.
+func NewOptions(opts ...Option) (*Options, error) {
 	opt := &Options{
 		name: taskName,
 	}
@@ -56,9 +84,20 @@ func NewOptions(opts ...Option) *Options {
 		o(opt)
 	}
 
-	opt.Options = taskGo.NewOptions(opt.taskGoOpts...)
+	goOpts, goOptsErr := taskGo.NewOptions(opt.taskGoOpts...)
+	if goOptsErr != nil {
+		return nil, goOptsErr
+	}
+	opt.Options = goOpts
 
-	return opt
+	if opt.Mode.RequiresCgo() && opt.Env[taskGo.DefaultEnvVarCGOENABLED] == "0" {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("build mode %q requires cgo but %s=0", opt.Mode, taskGo.DefaultEnvVarCGOENABLED),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	return opt, nil
 }
 
 // WithBinaryArtifactName sets the name for the binary build artifact.
@@ -89,9 +128,26 @@ func WithGoOptions(goOpts ...taskGo.Option) Option {
 	}
 }
 
+// WithMode sets the build mode that determines the kind of build artifacts and their file names.
+// Defaults to ModeDefault.
+func WithMode(mode Mode) Option {
+	return func(o *Options) {
+		o.Mode = mode
+	}
+}
+
 // WithOutputDir sets the output directory, relative to the project root, for compilation artifacts.
 func WithOutputDir(dir string) Option {
 	return func(o *Options) {
 		o.OutputDir = dir
 	}
 }
+
+// WithPGOProfile sets the path to the profile for profile-guided optimization or PGOProfileOff to disable it.
+// Defaults to the "auto" mode of the Go toolchain that uses the profile named DefaultPGOProfileFileName in the main
+// package directory, if any.
+func WithPGOProfile(profile string) Option {
+	return func(o *Options) {
+		o.PGOProfile = profile
+	}
+}
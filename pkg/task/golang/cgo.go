@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golang
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CgoOptions are options for cgo.
+//
+// See `go help environment`, `go doc cmd/cgo` and the `go` command documentations for more details:
+//   - https://pkg.go.dev/cmd/cgo
+//   - https://golang.org/cmd/go/#hdr-Environment_variables
+type CgoOptions struct {
+	// CFlags are the flags passed to the C compiler.
+	CFlags []string
+
+	// CXXFlags are the flags passed to the C++ compiler.
+	CXXFlags []string
+
+	// Enabled indicates whether cgo is enabled.
+	// The "CGO_ENABLED" environment variable is only set when explicitly enabled or disabled while the default of the Go
+	// toolchain is used when nil.
+	Enabled *bool
+
+	// LdFlags are the flags passed to the linker.
+	LdFlags []string
+
+	// Static indicates whether binary artifacts should be linked statically through the external linker.
+	// It is only applied when cgo is explicitly enabled since binary artifacts without cgo are statically linked by
+	// default.
+	Static bool
+
+	// Toolchains are the C toolchains mapped by the target platform in the "os/arch" format.
+	// A toolchain must be configured for each cgo-enabled target platform that differs from the host platform.
+	Toolchains map[string]CgoToolchain
+}
+
+// CgoToolchain is a C toolchain for a target platform.
+type CgoToolchain struct {
+	// CC is the C compiler command, e.g. "aarch64-linux-gnu-gcc" or "zig cc -target aarch64-linux-musl".
+	CC string
+
+	// CXX is the C++ compiler command, e.g. "aarch64-linux-gnu-g++".
+	CXX string
+}
+
+// Env returns the cgo environment for the given target platform in the "os/arch" format.
+// The "CGO_ENABLED" environment variable is only included when cgo has been explicitly enabled or disabled.
+func (c *CgoOptions) Env(platform string) map[string]string {
+	if c.IsDisabled() {
+		return map[string]string{DefaultEnvVarCGOENABLED: "0"}
+	}
+
+	env := make(map[string]string)
+	if c.IsEnabled() {
+		env[DefaultEnvVarCGOENABLED] = "1"
+	}
+	for name, flags := range map[string][]string{
+		DefaultEnvVarCGOCFLAGS:   c.CFlags,
+		DefaultEnvVarCGOCXXFLAGS: c.CXXFlags,
+		DefaultEnvVarCGOLDFLAGS:  c.LdFlags,
+	} {
+		if len(flags) > 0 {
+			env[name] = strings.Join(flags, " ")
+		}
+	}
+	if tc, ok := c.Toolchains[platform]; ok {
+		if tc.CC != "" {
+			env[DefaultEnvVarCC] = tc.CC
+		}
+		if tc.CXX != "" {
+			env[DefaultEnvVarCXX] = tc.CXX
+		}
+	}
+	return env
+}
+
+// IsDisabled indicates whether cgo has been explicitly disabled.
+func (c *CgoOptions) IsDisabled() bool {
+	return c.Enabled != nil && !*c.Enabled
+}
+
+// IsEnabled indicates whether cgo has been explicitly enabled.
+func (c *CgoOptions) IsEnabled() bool {
+	return c.Enabled != nil && *c.Enabled
+}
+
+// ValidateToolchain checks that the configured C toolchain for the given target platform in the "os/arch" format
+// exists unless cgo has been explicitly disabled. When cgo has been explicitly enabled target platforms that differ
+// from the host platform require a configured C cross-compiler while the default C compiler of the Go toolchain is
+// used for the host platform otherwise.
+func (c *CgoOptions) ValidateToolchain(platform string) error {
+	if c.IsDisabled() {
+		return nil
+	}
+
+	tc := c.Toolchains[platform]
+	if tc.CC == "" {
+		if !c.IsEnabled() || platform == HostPlatform() {
+			return nil
+		}
+		return fmt.Errorf("no C cross-compiler configured for cgo-enabled platform %q", platform)
+	}
+	for _, cmd := range []string{tc.CC, tc.CXX} {
+		fields := strings.Fields(cmd)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			return fmt.Errorf("C toolchain %q for cgo-enabled platform %q not found: %w", cmd, platform, err)
+		}
+	}
+	return nil
+}
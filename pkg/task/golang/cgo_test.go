@@ -0,0 +1,137 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptionsCgo(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		env    map[string]string
+		static bool
+	}{
+		{
+			name: "flags without explicitly enabled cgo",
+			opts: []Option{WithCgoCFlags("-O2"), WithCgoLdFlags("-lm")},
+			env:  map[string]string{DefaultEnvVarCGOCFLAGS: "-O2", DefaultEnvVarCGOLDFLAGS: "-lm"},
+		},
+		{
+			name: "toolchain without explicitly enabled cgo",
+			opts: []Option{WithCgoToolchain("linux/arm64", "aarch64-linux-gnu-gcc", ""), WithEnv(map[string]string{
+				DefaultEnvVarGOOS: "linux", DefaultEnvVarGOARCH: "arm64",
+			})},
+			env: map[string]string{
+				DefaultEnvVarCC: "aarch64-linux-gnu-gcc", DefaultEnvVarGOARCH: "arm64", DefaultEnvVarGOOS: "linux",
+			},
+		},
+		{
+			name: "static linking without explicitly enabled cgo",
+			opts: []Option{WithCgoStaticLinking(true)},
+			env:  map[string]string{},
+		},
+		{
+			name:   "enabled with static linking",
+			opts:   []Option{WithCgo(true), WithCgoStaticLinking(true), WithCgoCXXFlags("-std=c++17")},
+			env:    map[string]string{DefaultEnvVarCGOENABLED: "1", DefaultEnvVarCGOCXXFLAGS: "-std=c++17"},
+			static: true,
+		},
+		{
+			name: "disabled ignores flags",
+			opts: []Option{WithCgo(false), WithCgoCFlags("-O2")},
+			env:  map[string]string{DefaultEnvVarCGOENABLED: "0"},
+		},
+		{
+			name: "explicit environment takes precedence",
+			opts: []Option{WithCgo(true), WithEnv(map[string]string{DefaultEnvVarCGOENABLED: "0"})},
+			env:  map[string]string{DefaultEnvVarCGOENABLED: "0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := NewOptions(tc.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tc.env, opts.Env)
+			if tc.static {
+				require.Equal(t, []string{"-linkmode=external", "-extldflags=-static"}, opts.LdFlags)
+			} else {
+				require.Empty(t, opts.LdFlags)
+			}
+		})
+	}
+}
+
+func TestCgoOptionsValidateToolchain(t *testing.T) {
+	cc := filepath.Join(t.TempDir(), "cc")
+	if runtime.GOOS == "windows" {
+		cc += ".exe"
+	}
+	//nolint:gosec // The fake C compiler must be executable to be found.
+	require.NoError(t, os.WriteFile(cc, []byte("#!/bin/sh\n"), 0o755))
+	missing := filepath.Join(t.TempDir(), "missing-cc")
+	enabled, disabled := true, false
+	cross := "linux/riscv64"
+	if HostPlatform() == cross {
+		cross = "linux/arm64"
+	}
+
+	tests := []struct {
+		name     string
+		cgo      CgoOptions
+		platform string
+		wantErr  bool
+	}{
+		{name: "disabled", cgo: CgoOptions{Enabled: &disabled}, platform: cross},
+		{name: "enabled for host platform", cgo: CgoOptions{Enabled: &enabled}, platform: HostPlatform()},
+		{name: "enabled without cross-compiler", cgo: CgoOptions{Enabled: &enabled}, platform: cross, wantErr: true},
+		{name: "default without cross-compiler", cgo: CgoOptions{}, platform: cross},
+		{
+			name:     "existing cross-compiler with arguments",
+			cgo:      CgoOptions{Enabled: &enabled, Toolchains: map[string]CgoToolchain{cross: {CC: cc + " -target x"}}},
+			platform: cross,
+		},
+		{
+			name:     "missing cross-compiler",
+			cgo:      CgoOptions{Enabled: &enabled, Toolchains: map[string]CgoToolchain{cross: {CC: missing}}},
+			platform: cross,
+			wantErr:  true,
+		},
+		{
+			name:     "missing C++ cross-compiler",
+			cgo:      CgoOptions{Enabled: &enabled, Toolchains: map[string]CgoToolchain{cross: {CC: cc, CXX: missing}}},
+			platform: cross,
+			wantErr:  true,
+		},
+		{
+			name:     "missing cross-compiler without explicitly enabled cgo",
+			cgo:      CgoOptions{Toolchains: map[string]CgoToolchain{cross: {CC: missing}}},
+			platform: cross,
+			wantErr:  true,
+		},
+		{
+			name:     "missing cross-compiler with disabled cgo",
+			cgo:      CgoOptions{Enabled: &disabled, Toolchains: map[string]CgoToolchain{cross: {CC: missing}}},
+			platform: cross,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cgo.ValidateToolchain(tc.platform)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fuzz
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrCrashers indicates that at least one fuzz target found failing inputs.
+const ErrCrashers = wErr.ErrString("fuzz targets found failing inputs")
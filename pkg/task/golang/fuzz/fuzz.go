@@ -0,0 +1,171 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package fuzz provides tasks to discover and run Go native fuzz targets with the Go toolchain "test" command and to
+// collect failing inputs.
+//
+// See https://go.dev/security/fuzz and `go help testflag` for more details.
+package fuzz
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+const (
+	// listPattern is the regular expression to list all fuzz targets.
+	listPattern = "^Fuzz"
+
+	// listTaskName is the name of the task to list fuzz targets.
+	listTaskName = "go/fuzz/list"
+)
+
+// ListTask is a task to discover fuzz targets with the Go toolchain "test" command.
+type ListTask struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *ListTask) BuildParams() []string {
+	params := []string{"test"}
+	params = append(params, t.opts.Params()...)
+	params = append(params, fmt.Sprintf("-list=%s", listPattern))
+	return append(params, t.opts.Pkgs...)
+}
+
+// Env returns the task specific environment.
+func (t *ListTask) Env() map[string]string {
+	return t.opts.Env
+}
+
+// Kind returns the task kind.
+func (t *ListTask) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the unique task name.
+func (t *ListTask) Name() string {
+	return listTaskName
+}
+
+// Options returns the task options.
+func (t *ListTask) Options() task.Options {
+	return *t.opts
+}
+
+// Task is a task to run a single fuzz target with the Go toolchain "test" command.
+type Task struct {
+	ac     app.Config
+	opts   *Options
+	target Target
+}
+
+// BuildParams builds the parameters.
+// All tests are skipped so that only the fuzz target is run. The command is run in the package directory of the target
+// since the Go toolchain only supports fuzzing of a single package at a time.
+// Note that configured flags are applied after the "GOFLAGS" environment variable and could overwrite already defined
+// flags.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func (t *Task) BuildParams() []string {
+	params := []string{"test"}
+
+	params = append(params, t.opts.Params()...)
+
+	params = append(params,
+		"-run=^$",
+		fmt.Sprintf("-fuzz=^%s$", regexp.QuoteMeta(t.target.Name)),
+		fmt.Sprintf("-fuzztime=%s", t.opts.FuzzTime),
+	)
+
+	// Minimization of failing inputs is disabled by setting the minimization time to zero.
+	minimizeTime := "0"
+	if t.opts.EnableMinimize {
+		minimizeTime = t.opts.MinimizeTime
+	}
+	params = append(params, fmt.Sprintf("-fuzzminimizetime=%s", minimizeTime))
+
+	if t.opts.Workers > 0 {
+		params = append(params, fmt.Sprintf("-parallel=%d", t.opts.Workers))
+	}
+
+	if len(t.opts.Flags) > 0 {
+		params = append(params, t.opts.Flags...)
+	}
+
+	return append(params, ".")
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.Env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the unique task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// Target returns the fuzz target.
+func (t *Task) Target() Target {
+	return t.target
+}
+
+// WorkingDir returns the path to the working directory of the command which is the package directory of the target.
+func (t *Task) WorkingDir() string {
+	return t.target.Dir
+}
+
+// New creates a new task to run the given fuzz target with the Go toolchain "test" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(ac app.Config, target Target, opts ...Option) (*Task, error) {
+	opt, optErr := newOptions(ac, opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+	return &Task{ac: ac, opts: opt, target: target}, nil
+}
+
+// NewList creates a new task to discover fuzz targets with the Go toolchain "test" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func NewList(ac app.Config, opts ...Option) (*ListTask, error) {
+	opt, optErr := newOptions(ac, opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+	return &ListTask{ac: ac, opts: opt}, nil
+}
+
+// newOptions creates new task options with application specific defaults.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func newOptions(ac app.Config, opts ...Option) (*Options, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+
+	// Store failing inputs within the application specific subdirectory.
+	if opt.OutputDir == "" {
+		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
+	}
+
+	return opt, nil
+}
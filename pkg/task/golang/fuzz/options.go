@@ -0,0 +1,221 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fuzz
+
+import (
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+)
+
+const (
+	// DefaultFuzzTime is the default amount of time each fuzz target is run.
+	DefaultFuzzTime = "10s"
+
+	// DefaultMinimizeTime is the default amount of time to minimize a failing input when minimization is enabled.
+	DefaultMinimizeTime = "60s"
+
+	// DefaultOutputDirName is the default output directory name for collected failing inputs.
+	DefaultOutputDirName = "fuzz"
+
+	// DefaultParallelism is the default number of fuzz targets that are run concurrently.
+	DefaultParallelism = 1
+
+	// DefaultPattern is the default regular expression to select fuzz targets which matches all targets.
+	DefaultPattern = "."
+
+	// taskName is the name of the task.
+	taskName = "go/fuzz"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	*taskGo.Options
+
+	// EnableCorpusCopy indicates whether failing inputs should be kept in the seed corpus of the package, the
+	// "testdata/fuzz" directory, so that they are run as regression inputs by the Go toolchain "test" command.
+	// Failing inputs are always copied into the output directory and only removed from the package when disabled.
+	EnableCorpusCopy bool
+
+	// EnableMinimize indicates whether failing inputs should be minimized.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	EnableMinimize bool
+
+	// Flags are additional flags that are passed to the Go "test" command along with the shared Go flags.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	Flags []string
+
+	// FuzzTime is the amount of time or iterations each fuzz target is run, e.g. "30s" or "1000x".
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	FuzzTime string
+
+	// MinimizeTime is the amount of time or iterations to minimize a failing input when minimization is enabled.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	MinimizeTime string
+
+	// name is the task name.
+	name string
+
+	// OutputDir is the output directory, relative to the project root, for collected failing inputs.
+	OutputDir string
+
+	// Parallelism is the number of fuzz targets that are run concurrently where 1 runs all targets sequentially.
+	Parallelism int
+
+	// Pattern is the regular expression to select the fuzz targets to run.
+	Pattern string
+
+	// Pkgs is a list of packages to discover fuzz targets in.
+	Pkgs []string
+
+	// taskGoOpts are shared Go toolchain task options.
+	taskGoOpts []taskGo.Option
+
+	// Workers is the number of fuzzing processes per fuzz target.
+	// Defaults to the value of "GOMAXPROCS" when not set.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	Workers int
+}
+
+// NewOptions creates new task options.
+// It returns an error of type *task.ErrTask when the shared Go toolchain task options are invalid.
+func NewOptions(opts ...Option) (*Options, error) {
+	opt := &Options{
+		EnableCorpusCopy: true,
+		FuzzTime:         DefaultFuzzTime,
+		MinimizeTime:     DefaultMinimizeTime,
+		name:             taskName,
+		Parallelism:      DefaultParallelism,
+		Pattern:          DefaultPattern,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	goOpts, goOptsErr := taskGo.NewOptions(opt.taskGoOpts...)
+	if goOptsErr != nil {
+		return nil, goOptsErr
+	}
+	opt.Options = goOpts
+
+	return opt, nil
+}
+
+// WithCorpusCopy indicates whether failing inputs should be kept in the seed corpus of the package so that they are
+// run as regression inputs by the Go toolchain "test" command.
+// Enabled by default, when disabled failing inputs are only kept in the output directory.
+func WithCorpusCopy(enableCorpusCopy bool) Option {
+	return func(o *Options) {
+		o.EnableCorpusCopy = enableCorpusCopy
+	}
+}
+
+// WithFlags sets additional flags that are passed to the Go "test" command along with the shared Go flags.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.Flags = append(o.Flags, flags...)
+	}
+}
+
+// WithFuzzTime sets the amount of time or iterations each fuzz target is run, e.g. "30s" or "1000x".
+// Defaults to DefaultFuzzTime.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithFuzzTime(fuzzTime string) Option {
+	return func(o *Options) {
+		if fuzzTime != "" {
+			o.FuzzTime = fuzzTime
+		}
+	}
+}
+
+// WithGoOptions sets shared Go toolchain task options.
+func WithGoOptions(goOpts ...taskGo.Option) Option {
+	return func(o *Options) {
+		o.taskGoOpts = append(o.taskGoOpts, goOpts...)
+	}
+}
+
+// WithMinimize indicates whether failing inputs should be minimized.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithMinimize(enableMinimize bool) Option {
+	return func(o *Options) {
+		o.EnableMinimize = enableMinimize
+	}
+}
+
+// WithMinimizeTime sets the amount of time or iterations to minimize a failing input when minimization is enabled.
+// Defaults to DefaultMinimizeTime.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithMinimizeTime(minimizeTime string) Option {
+	return func(o *Options) {
+		if minimizeTime != "" {
+			o.MinimizeTime = minimizeTime
+		}
+	}
+}
+
+// WithOutputDir sets the output directory, relative to the project root, for collected failing inputs.
+// Defaults to DefaultOutputDirName within the application specific output directory.
+func WithOutputDir(outputDir string) Option {
+	return func(o *Options) {
+		o.OutputDir = outputDir
+	}
+}
+
+// WithParallelism sets the number of fuzz targets that are run concurrently where 1 runs all targets sequentially.
+// Defaults to DefaultParallelism.
+func WithParallelism(parallelism int) Option {
+	return func(o *Options) {
+		if parallelism > 0 {
+			o.Parallelism = parallelism
+		}
+	}
+}
+
+// WithPattern sets the regular expression to select the fuzz targets to run.
+// Defaults to DefaultPattern.
+func WithPattern(pattern string) Option {
+	return func(o *Options) {
+		if pattern != "" {
+			o.Pattern = pattern
+		}
+	}
+}
+
+// WithPkgs sets the list of packages to discover fuzz targets in.
+func WithPkgs(pkgs ...string) Option {
+	return func(o *Options) {
+		o.Pkgs = append(o.Pkgs, pkgs...)
+	}
+}
+
+// WithWorkers sets the number of fuzzing processes per fuzz target.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithWorkers(workers int) Option {
+	return func(o *Options) {
+		o.Workers = workers
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fuzz
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// corpusDirName is the name of the directory within the "testdata" directory of a package that contains the seed
+	// corpus of all fuzz targets.
+	corpusDirName = "fuzz"
+
+	// testDataDirName is the name of the directory for test data of a package.
+	testDataDirName = "testdata"
+)
+
+// Crasher is a failing input found by a fuzz target.
+type Crasher struct {
+	// CorpusPath is the path of the input within the seed corpus of the package.
+	// The file does not exist anymore when the input has not been kept in the seed corpus.
+	CorpusPath string
+
+	// Path is the path of the collected input within the output directory.
+	Path string
+}
+
+// Report is the report of all run fuzz targets.
+type Report struct {
+	// Results are the results of all run fuzz targets in the order they have been discovered.
+	Results []TargetResult
+}
+
+// Crashing returns the results of all fuzz targets that found failing inputs.
+func (r *Report) Crashing() []TargetResult {
+	var results []TargetResult
+	for _, res := range r.Results {
+		if len(res.Crashers) > 0 {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// Errored returns the results of all fuzz targets that failed without finding failing inputs, e.g. due to build
+// errors.
+func (r *Report) Errored() []TargetResult {
+	var results []TargetResult
+	for _, res := range r.Results {
+		if len(res.Crashers) == 0 && res.Error != "" {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// String returns a human-readable summary of the results of all fuzz targets.
+func (r *Report) String() string {
+	lines := make([]string, 0, len(r.Results))
+	for _, res := range r.Results {
+		lines = append(lines, res.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Target is a fuzz target.
+type Target struct {
+	// Dir is the directory of the package the fuzz target belongs to.
+	Dir string
+
+	// Name is the name of the fuzz target function, e.g. "FuzzParse".
+	Name string
+
+	// Pkg is the import path of the package the fuzz target belongs to.
+	Pkg string
+}
+
+// CorpusDir returns the path to the seed corpus directory of the fuzz target.
+func (t Target) CorpusDir() string {
+	return filepath.Join(t.Dir, testDataDirName, corpusDirName, t.Name)
+}
+
+// String returns the fully qualified name of the fuzz target.
+func (t Target) String() string {
+	return t.Pkg + "." + t.Name
+}
+
+// TargetResult is the result of a run fuzz target.
+type TargetResult struct {
+	// Crashers are the failing inputs found by the fuzz target.
+	Crashers []Crasher
+
+	// Duration is the duration of the fuzz target run.
+	Duration time.Duration
+
+	// Error is the error message when the fuzz target failed without finding failing inputs.
+	Error string
+
+	// Output is the output of the fuzz target run.
+	Output string
+
+	// Target is the fuzz target.
+	Target Target
+}
+
+// String returns a human-readable summary of the result.
+func (r TargetResult) String() string {
+	switch {
+	case len(r.Crashers) > 0:
+		paths := make([]string, 0, len(r.Crashers))
+		for _, c := range r.Crashers {
+			paths = append(paths, c.Path)
+		}
+		return fmt.Sprintf("%s: %d failing inputs found after %s:\n  %s",
+			r.Target, len(r.Crashers), r.Duration.Round(time.Millisecond), strings.Join(paths, "\n  "),
+		)
+	case r.Error != "":
+		return fmt.Sprintf("%s: failed after %s: %s", r.Target, r.Duration.Round(time.Millisecond), r.Error)
+	default:
+		return fmt.Sprintf("%s: no failing inputs found in %s", r.Target, r.Duration.Round(time.Millisecond))
+	}
+}
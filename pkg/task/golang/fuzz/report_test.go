@@ -0,0 +1,20 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptionsKeepsCorpusByDefault(t *testing.T) {
+	opts, err := NewOptions()
+	require.NoError(t, err)
+	require.True(t, opts.EnableCorpusCopy)
+
+	opts, err = NewOptions(WithCorpusCopy(false))
+	require.NoError(t, err)
+	require.False(t, opts.EnableCorpusCopy)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	glFS "github.com/svengreb/golib/pkg/io/fs"
+
+	"github.com/svengreb/wand/pkg/project"
+)
+
+const (
+	// DirectivePrefix is the prefix of a "go:generate" directive.
+	DirectivePrefix = "//go:generate"
+
+	// DirectiveCommandAlias is the first argument of a directive that defines a command alias.
+	DirectiveCommandAlias = "-command"
+)
+
+// Directive is a "go:generate" directive within a Go source file.
+//
+// See `go help generate` and the `go` command documentations for more details:
+//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+type Directive struct {
+	// Args are the arguments of the directive where the first argument is the generator command.
+	// Double-quoted strings are evaluated using Go syntax and passed as a single argument.
+	Args []string
+
+	// File is the path to the Go source file that contains the directive.
+	File string
+
+	// Line is the line number of the directive within the File.
+	Line int
+
+	// Package is the name of the package the File belongs to.
+	Package string
+
+	// Text is the full original source text of the directive excluding any trailing spaces.
+	// Run and skip patterns are matched against this text.
+	Text string
+}
+
+// Env returns the environment variables the Go "generate" command provides to the generator of the directive.
+func (d Directive) Env(goos, goarch string) map[string]string {
+	return map[string]string{
+		"DOLLAR":    "$",
+		"GOARCH":    goarch,
+		"GOFILE":    filepath.Base(d.File),
+		"GOLINE":    strconv.Itoa(d.Line),
+		"GOOS":      goos,
+		"GOPACKAGE": d.Package,
+	}
+}
+
+// GoRunModule returns the Go module and arguments when the directive uses the Go "run" command in module-aware mode,
+// e.g. "go run golang.org/x/tools/cmd/stringer@v0.1.7 -type=Kind".
+// The returned boolean is false when the directive does not match the "go run pkg@version" form or uses additional
+// flags for the "run" command.
+func (d Directive) GoRunModule() (*project.GoModuleID, []string, bool) {
+	if len(d.Args) < 3 || d.Args[0] != "go" || d.Args[1] != "run" {
+		return nil, nil, false
+	}
+	if strings.HasPrefix(d.Args[2], "-") || !strings.Contains(d.Args[2], project.GoModuleVersionSuffixSeparator) {
+		return nil, nil, false
+	}
+
+	gm, gmErr := project.GoModuleFromImportPath(d.Args[2])
+	if gmErr != nil {
+		return nil, nil, false
+	}
+	return gm, d.Args[3:], true
+}
+
+// IsCommandAlias indicates whether the directive defines a command alias.
+func (d Directive) IsCommandAlias() bool {
+	return len(d.Args) > 0 && d.Args[0] == DirectiveCommandAlias
+}
+
+// ScanDirectives scans all Go source files in the given directory recursively for "go:generate" directives.
+// Like the Go toolchain it ignores directories named "testdata" or "vendor", directories whose names start with "."
+// or "_" and nested Go modules.
+// The directives are returned in the order the Go "generate" command processes them.
+// Note that build constraints of Go source files are not evaluated.
+func ScanDirectives(dir string) ([]Directive, error) {
+	var directives []Directive
+
+	walkErr := filepath.WalkDir(dir, func(path string, de fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if de.IsDir() {
+			if path == dir {
+				return nil
+			}
+			name := de.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			isModule, fsErr := glFS.RegularFileExists(filepath.Join(path, project.GoModuleDefaultFileName))
+			if fsErr != nil {
+				return fmt.Errorf("check for nested Go module in %q: %w", path, fsErr)
+			}
+			if isModule {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+		fileDirectives, scanErr := scanFile(path)
+		if scanErr != nil {
+			return scanErr
+		}
+		directives = append(directives, fileDirectives...)
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("scan %q for directives: %w", dir, walkErr)
+	}
+
+	return directives, nil
+}
+
+// scanFile scans the Go source file at the given path for "go:generate" directives.
+func scanFile(path string) ([]Directive, error) {
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("read %q: %w", path, readErr)
+	}
+	if !bytes.Contains(data, []byte(DirectivePrefix)) {
+		return nil, nil
+	}
+
+	f, parseErr := parser.ParseFile(token.NewFileSet(), path, data, parser.PackageClauseOnly)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse package clause of %q: %w", path, parseErr)
+	}
+
+	var directives []Directive
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		if !strings.HasPrefix(text, DirectivePrefix+" ") && !strings.HasPrefix(text, DirectivePrefix+"\t") {
+			continue
+		}
+
+		args, splitErr := splitArgs(text[len(DirectivePrefix):])
+		if splitErr != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, splitErr)
+		}
+		if len(args) == 0 {
+			continue
+		}
+		directives = append(directives, Directive{
+			Args:    args,
+			File:    path,
+			Line:    line,
+			Package: f.Name.Name,
+			Text:    text,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan %q: %w", path, err)
+	}
+
+	return directives, nil
+}
+
+// splitArgs splits the arguments of a directive into space-separated tokens or double-quoted strings using Go syntax.
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return args, nil
+		}
+
+		if s[0] != '"' {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			args = append(args, s[:end])
+			s = s[end:]
+			continue
+		}
+
+		end := 1
+		for ; end < len(s); end++ {
+			if s[end] == '\\' {
+				end++
+				continue
+			}
+			if s[end] == '"' {
+				break
+			}
+		}
+		if end >= len(s) {
+			return nil, fmt.Errorf("unterminated quoted string in directive arguments %q", s)
+		}
+		arg, unquoteErr := strconv.Unquote(s[:end+1])
+		if unquoteErr != nil {
+			return nil, fmt.Errorf("unquote directive argument %q: %w", s[:end+1], unquoteErr)
+		}
+		args = append(args, arg)
+		s = s[end+1:]
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		args    []string
+		wantErr bool
+	}{
+		{name: "tokens", s: " stringer -type=Kind", args: []string{"stringer", "-type=Kind"}},
+		{name: "tabs and repeated spaces", s: "\tgo  run\t./gen", args: []string{"go", "run", "./gen"}},
+		{name: "quoted string", s: ` echo "hello world" done`, args: []string{"echo", "hello world", "done"}},
+		{name: "quoted string with escapes", s: ` echo "a \"b\"\tc"`, args: []string{"echo", "a \"b\"\tc"}},
+		{name: "quoted string adjacent to token", s: ` echo "a"b`, args: []string{"echo", "a", "b"}},
+		{name: "empty quoted string", s: ` echo ""`, args: []string{"echo", ""}},
+		{name: "blank"},
+		{name: "unterminated quoted string", s: ` echo "hello`, wantErr: true},
+		{name: "invalid escape sequence", s: ` echo "\q"`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := splitArgs(tc.s)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestScanDirectives(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go": strings.Join([]string{
+			"package main",
+			"",
+			"//go:generate -command str go run golang.org/x/tools/cmd/stringer@v0.1.7",
+			"//go:generate str -type=Kind  ",
+			"//go:generated not a directive",
+			"// go:generate not a directive",
+			"//go:generate",
+			"func main() {}",
+		}, "\n"),
+		"b.go":                     "package main\n\n//go:generate\techo \"b c\"\n",
+		"README.md":                "//go:generate echo readme\n",
+		"pkg/c.go":                 "// Package pkg is a package.\npackage pkg\n//go:generate echo c\n",
+		"pkg/no_directives.go":     "package pkg\n",
+		"testdata/d.go":            "package testdata\n//go:generate echo testdata\n",
+		"vendor/e.go":              "package vendor\n//go:generate echo vendor\n",
+		".hidden/f.go":             "package hidden\n//go:generate echo hidden\n",
+		"_ignored/g.go":            "package ignored\n//go:generate echo ignored\n",
+		"nested/go.mod":            "module example.com/nested\n",
+		"nested/h.go":              "package nested\n//go:generate echo nested\n",
+		"pkg/internal/deep/i.go":   "package deep\n\n\n//go:generate echo deep\n",
+		"pkg/internal/deep/i.txt":  "//go:generate echo text\n",
+		"pkg/internal/deep/j_x.go": "package deep_test\n//go:generate echo test\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	directives, err := ScanDirectives(dir)
+	require.NoError(t, err)
+
+	type directive struct {
+		args    []string
+		file    string
+		line    int
+		pkg     string
+		text    string
+		isAlias bool
+	}
+	want := []directive{
+		{
+			args:    []string{"-command", "str", "go", "run", "golang.org/x/tools/cmd/stringer@v0.1.7"},
+			file:    "a.go",
+			line:    3,
+			pkg:     "main",
+			text:    "//go:generate -command str go run golang.org/x/tools/cmd/stringer@v0.1.7",
+			isAlias: true,
+		},
+		{args: []string{"str", "-type=Kind"}, file: "a.go", line: 4, pkg: "main", text: "//go:generate str -type=Kind"},
+		{args: []string{"echo", "b c"}, file: "b.go", line: 3, pkg: "main", text: "//go:generate\techo \"b c\""},
+		{args: []string{"echo", "c"}, file: "pkg/c.go", line: 3, pkg: "pkg", text: "//go:generate echo c"},
+		{
+			args: []string{"echo", "deep"},
+			file: "pkg/internal/deep/i.go",
+			line: 4,
+			pkg:  "deep",
+			text: "//go:generate echo deep",
+		},
+		{
+			args: []string{"echo", "test"},
+			file: "pkg/internal/deep/j_x.go",
+			line: 2,
+			pkg:  "deep_test",
+			text: "//go:generate echo test",
+		},
+	}
+	got := make([]directive, 0, len(directives))
+	for _, d := range directives {
+		rel, relErr := filepath.Rel(dir, d.File)
+		require.NoError(t, relErr)
+		got = append(got, directive{
+			args:    d.Args,
+			file:    filepath.ToSlash(rel),
+			line:    d.Line,
+			pkg:     d.Package,
+			text:    d.Text,
+			isAlias: d.IsCommandAlias(),
+		})
+	}
+	require.Equal(t, want, got)
+}
+
+func TestScanDirectivesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unterminated quoted string", content: "package main\n//go:generate echo \"a\n"},
+		{name: "invalid package clause", content: "//go:generate echo a\nfunc main() {}\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte(tc.content), 0o600))
+			_, err := ScanDirectives(dir)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := ScanDirectives(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDirectiveGoRunModule(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		path    string
+		version string
+		runArgs []string
+		ok      bool
+	}{
+		{
+			name:    "module with version",
+			args:    []string{"go", "run", "golang.org/x/tools/cmd/stringer@v0.1.7", "-type=Kind"},
+			path:    "golang.org/x/tools/cmd/stringer",
+			version: "0.1.7",
+			runArgs: []string{"-type=Kind"},
+			ok:      true,
+		},
+		{
+			name:    "module with latest version",
+			args:    []string{"go", "run", "golang.org/x/tools/cmd/stringer@latest"},
+			path:    "golang.org/x/tools/cmd/stringer",
+			runArgs: []string{},
+			ok:      true,
+		},
+		{name: "local package", args: []string{"go", "run", "./gen", "-out=x.go"}},
+		{name: "run flag", args: []string{"go", "run", "-mod=mod", "golang.org/x/tools/cmd/stringer@v0.1.7"}},
+		{name: "invalid version", args: []string{"go", "run", "golang.org/x/tools/cmd/stringer@main"}},
+		{name: "other command", args: []string{"stringer", "-type=Kind", "x@v1.0.0"}},
+		{name: "missing package", args: []string{"go", "run"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gm, runArgs, ok := Directive{Args: tc.args}.GoRunModule()
+			require.Equal(t, tc.ok, ok)
+			if !tc.ok {
+				require.Nil(t, gm)
+				return
+			}
+			require.Equal(t, tc.path, gm.Path)
+			require.Equal(t, tc.version == "", gm.IsLatest)
+			if tc.version != "" {
+				require.Equal(t, tc.version, gm.Version.String())
+			}
+			require.Equal(t, tc.runArgs, runArgs)
+		})
+	}
+}
+
+func TestDirectiveEnv(t *testing.T) {
+	d := Directive{File: filepath.Join("pkg", "kind.go"), Line: 7, Package: "kind"}
+	require.Equal(t, map[string]string{
+		"DOLLAR":    "$",
+		"GOARCH":    "arm64",
+		"GOFILE":    "kind.go",
+		"GOLINE":    "7",
+		"GOOS":      "linux",
+		"GOPACKAGE": "kind",
+	}, d.Env("linux", "arm64"))
+}
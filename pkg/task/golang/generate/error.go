@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package generate
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrOutdated indicates that regenerating would change tracked files or create untracked files.
+const ErrOutdated = wErr.ErrString("generated files are out of date")
@@ -0,0 +1,120 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package generate provides a task for the Go toolchain "generate" command.
+// It also provides utilities to scan Go source files for "go:generate" directives which allows to resolve generators,
+// that use the Go "run" command in module-aware mode, through the [github.com/svengreb/wand/pkg/task/gotool.Runner].
+//
+// See `go help generate` and the `go` command documentations for more details:
+//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+package generate
+
+import (
+	"fmt"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Result is the result of processing "go:generate" directives.
+type Result struct {
+	// Changed are the paths, relative to the project root directory, of tracked files that have been changed and
+	// untracked files that have been created by regenerating.
+	// Note that this is only populated when the check mode is enabled.
+	Changed []string
+
+	// Directives are the processed directives.
+	Directives []Directive
+}
+
+// Task is a task for the Go toolchain "generate" command.
+type Task struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+// Note that configured flags are applied after the "GOFLAGS" environment variable and could overwrite already defined
+// flags.
+//
+// See `go help environment`, `go help env` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Environment_variables
+func (t *Task) BuildParams() []string {
+	params := []string{"generate"}
+
+	params = append(params, t.opts.Params()...)
+
+	if t.opts.RunPattern != "" {
+		params = append(params, fmt.Sprintf("-run=%s", t.opts.RunPattern))
+	}
+
+	if t.opts.SkipPattern != "" {
+		params = append(params, fmt.Sprintf("-skip=%s", t.opts.SkipPattern))
+	}
+
+	if t.opts.EnableDryRun {
+		params = append(params, "-n")
+	}
+
+	if t.opts.EnablePrintCommands {
+		params = append(params, "-x")
+	}
+
+	if t.opts.EnableVerboseOutput {
+		params = append(params, "-v")
+	}
+
+	if len(t.opts.Flags) > 0 {
+		params = append(params, t.opts.Flags...)
+	}
+
+	return append(params, t.opts.Pkgs...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.Env
+}
+
+// ForFile returns a copy of the task that only processes directives of the given Go source file whose full original
+// source text matches the given pattern.
+func (t *Task) ForFile(file, runPattern string) *Task {
+	opts := *t.opts
+	opts.Pkgs = []string{file}
+	opts.RunPattern = runPattern
+	opts.SkipPattern = ""
+
+	return &Task{ac: t.ac, opts: &opts}
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// New creates a new task for the Go toolchain "generate" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(ac app.Config, opts ...Option) (*Task, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+
+	// Process all packages of the application recursively by default.
+	if len(opt.Pkgs) == 0 {
+		opt.Pkgs = []string{fmt.Sprintf("%s/...", ac.PkgImportPath)}
+	}
+
+	return &Task{ac: ac, opts: opt}, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package generate
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+)
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/generate"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	*taskGo.Options
+
+	// EnableCheck indicates whether the task should fail when regenerating would change tracked files or create untracked
+	// files. All changes are reverted afterwards so that the working tree is left untouched.
+	EnableCheck bool
+
+	// EnableDryRun indicates whether commands should only be printed but not run.
+	//
+	// See `go help generate` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	EnableDryRun bool
+
+	// EnablePrintCommands indicates whether commands should be printed as they are run.
+	//
+	// See `go help generate` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	EnablePrintCommands bool
+
+	// EnableVerboseOutput indicates whether the names of packages and files should be printed as they are processed.
+	//
+	// See `go help generate` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	EnableVerboseOutput bool
+
+	// Flags are additional flags that are passed to the Go "generate" command along with the shared Go flags.
+	Flags []string
+
+	// name is the task name.
+	name string
+
+	// Pkgs is a list of packages or files to process.
+	// By default all packages of the application are processed recursively.
+	Pkgs []string
+
+	// RunPattern is the regular expression to select directives whose full original source text matches the expression.
+	//
+	// See `go help generate` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	RunPattern string
+
+	// SkipPattern is the regular expression to suppress directives whose full original source text matches the
+	// expression.
+	//
+	// See `go help generate` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	SkipPattern string
+
+	// runRegexp is the compiled RunPattern.
+	runRegexp *regexp.Regexp
+
+	// skipRegexp is the compiled SkipPattern.
+	skipRegexp *regexp.Regexp
+
+	// taskGoOpts are shared Go toolchain task options.
+	taskGoOpts []taskGo.Option
+}
+
+// NewOptions creates new task options.
+// It returns an error of type *task.ErrTask when the run or skip pattern is not a valid regular expression.
+func NewOptions(opts ...Option) (*Options, error) {
+	opt := &Options{
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if opt.RunPattern != "" {
+		re, reErr := regexp.Compile(opt.RunPattern)
+		if reErr != nil {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("compile run pattern %q: %w", opt.RunPattern, reErr),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		opt.runRegexp = re
+	}
+
+	if opt.SkipPattern != "" {
+		re, reErr := regexp.Compile(opt.SkipPattern)
+		if reErr != nil {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("compile skip pattern %q: %w", opt.SkipPattern, reErr),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		opt.skipRegexp = re
+	}
+
+	goOpts, goOptsErr := taskGo.NewOptions(opt.taskGoOpts...)
+	if goOptsErr != nil {
+		return nil, goOptsErr
+	}
+	opt.Options = goOpts
+
+	return opt, nil
+}
+
+// Selects reports whether the given directive is selected by the configured run and skip patterns.
+func (o *Options) Selects(d Directive) bool {
+	if o.runRegexp != nil && !o.runRegexp.MatchString(d.Text) {
+		return false
+	}
+	if o.skipRegexp != nil && o.skipRegexp.MatchString(d.Text) {
+		return false
+	}
+	return true
+}
+
+// WithCheck indicates whether the task should fail when regenerating would change tracked files or create untracked
+// files. All changes are reverted afterwards so that the working tree is left untouched.
+func WithCheck(check bool) Option {
+	return func(o *Options) {
+		o.EnableCheck = check
+	}
+}
+
+// WithDryRun indicates whether commands should only be printed but not run.
+func WithDryRun(dryRun bool) Option {
+	return func(o *Options) {
+		o.EnableDryRun = dryRun
+	}
+}
+
+// WithFlags sets additional flags that are passed to the Go "generate" command along with the shared Go flags.
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.Flags = append(o.Flags, flags...)
+	}
+}
+
+// WithGoOptions sets shared Go toolchain task options.
+func WithGoOptions(goOpts ...taskGo.Option) Option {
+	return func(o *Options) {
+		o.taskGoOpts = append(o.taskGoOpts, goOpts...)
+	}
+}
+
+// WithPkgs sets the list of packages or files to process.
+// By default all packages of the application are processed recursively.
+func WithPkgs(pkgs ...string) Option {
+	return func(o *Options) {
+		o.Pkgs = append(o.Pkgs, pkgs...)
+	}
+}
+
+// WithPrintCommands indicates whether commands should be printed as they are run.
+func WithPrintCommands(printCommands bool) Option {
+	return func(o *Options) {
+		o.EnablePrintCommands = printCommands
+	}
+}
+
+// WithRunPattern sets the regular expression to select directives whose full original source text matches the
+// expression.
+func WithRunPattern(pattern string) Option {
+	return func(o *Options) {
+		o.RunPattern = pattern
+	}
+}
+
+// WithSkipPattern sets the regular expression to suppress directives whose full original source text matches the
+// expression.
+func WithSkipPattern(pattern string) Option {
+	return func(o *Options) {
+		o.SkipPattern = pattern
+	}
+}
+
+// WithVerboseOutput indicates whether the names of packages and files should be printed as they are processed.
+func WithVerboseOutput(verbose bool) Option {
+	return func(o *Options) {
+		o.EnableVerboseOutput = verbose
+	}
+}
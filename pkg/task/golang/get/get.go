@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package get provides a task for the Go toolchain "get" command to add, upgrade or downgrade module dependencies.
+//
+// See `go help get` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-get
+package get
+
+import "github.com/svengreb/wand/pkg/task"
+
+// Task is a task for the Go toolchain "get" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := append([]string{"get"}, t.opts.flags...)
+	return append(params, t.opts.modules...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "get" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package get
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/get"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// env is the task specific environment.
+	env map[string]string
+
+	// flags are additional flags that are passed to the command.
+	flags []string
+
+	// modules are the module queries to add, upgrade or downgrade, e.g. "golang.org/x/mod@v0.7.0".
+	modules []string
+
+	// name is the task name.
+	name string
+
+	// workingDir is the path to the module directory.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithFlags sets additional flags that are passed to the command.
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.flags = append(o.flags, flags...)
+	}
+}
+
+// WithModules sets the module queries to add, upgrade or downgrade, e.g. "golang.org/x/mod@v0.7.0".
+func WithModules(modules ...string) Option {
+	return func(o *Options) {
+		o.modules = append(o.modules, modules...)
+	}
+}
+
+// WithWorkingDir sets the path to the module directory.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
@@ -14,10 +14,9 @@ import (
 	"fmt"
 	"os/exec"
 
-	"github.com/magefile/mage/sh"
-
 	glFS "github.com/svengreb/golib/pkg/io/fs"
 
+	shSupport "github.com/svengreb/wand/internal/support/sh"
 	"github.com/svengreb/wand/pkg/task"
 )
 
@@ -39,13 +38,14 @@ func (r *Runner) Handles() task.Kind {
 // Run runs the command.
 // It returns an error of type *task.ErrRunner when any error occurs during the command execution.
 func (r *Runner) Run(t task.Task) error {
-	tExec, tErr := r.prepareTask(t)
+	tExec, env, tErr := r.prepareTask(t)
 	if tErr != nil {
 		return fmt.Errorf("runner %q: %w", RunnerName, tErr)
 	}
 
+	dir := workingDir(t)
 	if r.opts.Quiet {
-		if err := sh.RunWith(r.opts.Env, r.opts.Exec, tExec.BuildParams()...); err != nil {
+		if err := shSupport.RunWith(env, dir, r.opts.Exec, tExec.BuildParams()...); err != nil {
 			return &task.ErrRunner{
 				Err:  fmt.Errorf("run task %q: %w", t.Name(), err),
 				Kind: task.ErrRun,
@@ -53,7 +53,7 @@ func (r *Runner) Run(t task.Task) error {
 		}
 		return nil
 	}
-	if err := sh.RunWithV(r.opts.Env, r.opts.Exec, tExec.BuildParams()...); err != nil {
+	if err := shSupport.RunWithV(env, dir, r.opts.Exec, tExec.BuildParams()...); err != nil {
 		return &task.ErrRunner{
 			Err:  fmt.Errorf("run task %q: %w", t.Name(), err),
 			Kind: task.ErrRun,
@@ -64,15 +64,17 @@ func (r *Runner) Run(t task.Task) error {
 
 // RunOut runs the command and returns its output.
 // It returns an error of type *task.ErrRunner when any error occurs during the command execution.
+// Note that the output is also returned when the command failed since some commands, like "go mod download -json",
+// report errors as part of their machine-readable output.
 func (r *Runner) RunOut(t task.Task) (string, error) {
-	tExec, tErr := r.prepareTask(t)
+	tExec, env, tErr := r.prepareTask(t)
 	if tErr != nil {
 		return "", fmt.Errorf("runner %q: %w", RunnerName, tErr)
 	}
 
-	out, runErr := sh.OutputWith(r.opts.Env, r.opts.Exec, tExec.BuildParams()...)
+	out, runErr := shSupport.OutputWith(env, workingDir(t), r.opts.Exec, tExec.BuildParams()...)
 	if runErr != nil {
-		return "", &task.ErrRunner{
+		return out, &task.ErrRunner{
 			Err:  fmt.Errorf("run task %q: %w", t.Name(), runErr),
 			Kind: task.ErrRun,
 		}
@@ -108,21 +110,36 @@ func (r *Runner) Validate() error {
 }
 
 // prepareTask checks if the given task is of type task.Exec and prepares the task specific environment.
+// The returned environment is a copy of the runner environment merged with the task specific environment so that the
+// environment of a task does not leak into subsequently run tasks.
 // It returns an error of type *task.ErrRunner when any error occurs during the execution.
-func (r *Runner) prepareTask(t task.Task) (task.Exec, error) {
+func (r *Runner) prepareTask(t task.Task) (task.Exec, map[string]string, error) {
 	tExec, ok := t.(task.Exec)
 	if t.Kind() != task.KindExec || !ok {
-		return nil, &task.ErrRunner{
+		return nil, nil, &task.ErrRunner{
 			Err:  fmt.Errorf("expected %q but got %q", r.Handles(), t.Kind()),
 			Kind: task.ErrUnsupportedTaskKind,
 		}
 	}
 
+	env := make(map[string]string, len(r.opts.Env))
+	for k, v := range r.opts.Env {
+		env[k] = v
+	}
 	for k, v := range tExec.Env() {
-		r.opts.Env[k] = v
+		env[k] = v
 	}
 
-	return tExec, nil
+	return tExec, env, nil
+}
+
+// workingDir returns the working directory of the given task or an empty string when the task does not implement
+// task.WorkingDir.
+func workingDir(t task.Task) string {
+	if tWD, ok := t.(task.WorkingDir); ok {
+		return tWD.WorkingDir()
+	}
+	return ""
 }
 
 // NewRunner creates a new Go toolchain command runner.
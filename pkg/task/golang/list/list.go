@@ -0,0 +1,237 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package list provides a task for the Go toolchain "list" command.
+// The JSON output of the command is parsed into typed package or module information.
+//
+// See `go help list` and the [Go command documentation] for more details.
+//
+// [Go command documentation]: https://pkg.go.dev/cmd/go#hdr-List_packages_or_modules
+package list
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Module is the information about a module.
+type Module struct {
+	// Deprecated is the deprecation message of the module, if any.
+	Deprecated string `json:"Deprecated,omitempty"`
+
+	// Dir is the directory holding the local copy of the module files, if any.
+	Dir string `json:"Dir,omitempty"`
+
+	// Error is the error that occurred while loading the module.
+	Error *ModuleError `json:"Error,omitempty"`
+
+	// GoMod is the path to the "go.mod" file of the module, if any.
+	GoMod string `json:"GoMod,omitempty"`
+
+	// GoVersion is the Go version used by the module.
+	GoVersion string `json:"GoVersion,omitempty"`
+
+	// Indirect indicates whether the module is only indirectly needed by the main module.
+	Indirect bool `json:"Indirect,omitempty"`
+
+	// Main indicates whether the module is the main module.
+	Main bool `json:"Main,omitempty"`
+
+	// Path is the module path.
+	Path string `json:"Path"`
+
+	// Query is the version query corresponding to the version.
+	Query string `json:"Query,omitempty"`
+
+	// Replace is the module that replaces this module, if any.
+	Replace *Module `json:"Replace,omitempty"`
+
+	// Retracted is the retraction information of the module, if any.
+	Retracted []string `json:"Retracted,omitempty"`
+
+	// Time is the time the version was created.
+	Time *time.Time `json:"Time,omitempty"`
+
+	// Update is the available update of the module, if any.
+	Update *Module `json:"Update,omitempty"`
+
+	// Version is the module version.
+	Version string `json:"Version,omitempty"`
+}
+
+// ModuleError is an error that occurred while loading a module.
+type ModuleError struct {
+	// Err is the error message.
+	Err string `json:"Err"`
+}
+
+// Package is the information about a package.
+type Package struct {
+	// DepOnly indicates whether the package is only a dependency and not matched by the listed patterns.
+	DepOnly bool `json:"DepOnly,omitempty"`
+
+	// Deps are the import paths of all transitive dependencies of the package.
+	Deps []string `json:"Deps,omitempty"`
+
+	// Dir is the directory containing the package sources.
+	Dir string `json:"Dir,omitempty"`
+
+	// EmbedFiles are the paths, relative to Dir, of the files matched by the "//go:embed" patterns of the package.
+	EmbedFiles []string `json:"EmbedFiles,omitempty"`
+
+	// Error is the error that occurred while loading the package, if any.
+	Error *PackageError `json:"Error,omitempty"`
+
+	// ForTest is the import path of the package under test when the package is a test variant that is only compiled
+	// for the tests of that package, e.g. when listed with the "-test" flag.
+	ForTest string `json:"ForTest,omitempty"`
+
+	// GoFiles are the names of the Go source files of the package, excluding test files.
+	GoFiles []string `json:"GoFiles,omitempty"`
+
+	// ImportPath is the import path of the package.
+	ImportPath string `json:"ImportPath"`
+
+	// Imports are the import paths used by the package.
+	Imports []string `json:"Imports,omitempty"`
+
+	// Module is the module the package belongs to, if any.
+	Module *Module `json:"Module,omitempty"`
+
+	// Name is the package name.
+	Name string `json:"Name,omitempty"`
+
+	// Standard indicates whether the package is part of the Go standard library.
+	Standard bool `json:"Standard,omitempty"`
+
+	// TestEmbedFiles are the paths, relative to Dir, of the files matched by the "//go:embed" patterns in TestGoFiles.
+	TestEmbedFiles []string `json:"TestEmbedFiles,omitempty"`
+
+	// TestGoFiles are the names of the "_test.go" files of the package.
+	TestGoFiles []string `json:"TestGoFiles,omitempty"`
+
+	// TestImports are the import paths used by the TestGoFiles.
+	TestImports []string `json:"TestImports,omitempty"`
+
+	// XTestEmbedFiles are the paths, relative to Dir, of the files matched by the "//go:embed" patterns in XTestGoFiles.
+	XTestEmbedFiles []string `json:"XTestEmbedFiles,omitempty"`
+
+	// XTestGoFiles are the names of the "_test.go" files outside of the package.
+	XTestGoFiles []string `json:"XTestGoFiles,omitempty"`
+
+	// XTestImports are the import paths used by the XTestGoFiles.
+	XTestImports []string `json:"XTestImports,omitempty"`
+}
+
+// PackageError is an error that occurred while loading a package.
+type PackageError struct {
+	// Err is the error message.
+	Err string `json:"Err"`
+
+	// Pos is the position of the error, if any.
+	Pos string `json:"Pos,omitempty"`
+}
+
+// Task is a task for the Go toolchain "list" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"list"}
+
+	if t.opts.enableModules {
+		params = append(params, "-m")
+	}
+
+	if t.opts.enableUpdates {
+		params = append(params, "-u")
+	}
+
+	if t.opts.enableDeps {
+		params = append(params, "-deps")
+	}
+
+	if t.opts.enableTest {
+		params = append(params, "-test")
+	}
+
+	if t.opts.enableErrors {
+		params = append(params, "-e")
+	}
+
+	if t.opts.enableJSON {
+		params = append(params, "-json")
+	}
+
+	params = append(params, t.opts.flags...)
+	return append(params, t.opts.patterns...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the working directory of the command.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "list" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
+
+// ParseModules parses the stream of JSON objects printed by the "list -m -json" command.
+func ParseModules(r io.Reader) ([]Module, error) {
+	var modules []Module
+	dec := json.NewDecoder(r)
+	for {
+		var m Module
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return modules, nil
+			}
+			return nil, fmt.Errorf("decode module information: %w", err)
+		}
+		modules = append(modules, m)
+	}
+}
+
+// ParsePackages parses the stream of JSON objects printed by the "list -json" command.
+func ParsePackages(r io.Reader) ([]Package, error) {
+	var pkgs []Package
+	dec := json.NewDecoder(r)
+	for {
+		var p Package
+		if err := dec.Decode(&p); err != nil {
+			if errors.Is(err, io.EOF) {
+				return pkgs, nil
+			}
+			return nil, fmt.Errorf("decode package information: %w", err)
+		}
+		pkgs = append(pkgs, p)
+	}
+}
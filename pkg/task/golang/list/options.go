@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package list
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/list"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// enableDeps indicates whether all dependencies of the named packages should be listed as well.
+	enableDeps bool
+
+	// enableErrors indicates whether erroneous packages or modules should be reported instead of failing.
+	enableErrors bool
+
+	// enableJSON indicates whether the output should be printed in JSON format.
+	enableJSON bool
+
+	// enableModules indicates whether modules should be listed instead of packages.
+	enableModules bool
+
+	// enableUpdates indicates whether information about available module updates should be added.
+	enableUpdates bool
+
+	// enableTest indicates whether test packages and test variants of the named packages should be listed as well.
+	enableTest bool
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// flags are additional flags that are passed to the command.
+	flags []string
+
+	// name is the task name.
+	name string
+
+	// patterns are the package or module patterns to list.
+	patterns []string
+
+	// workingDir is the path to the working directory of the command.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithDeps indicates whether all dependencies of the named packages should be listed as well.
+func WithDeps(enableDeps bool) Option {
+	return func(o *Options) {
+		o.enableDeps = enableDeps
+	}
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithErrors indicates whether erroneous packages or modules should be reported in the output instead of failing.
+func WithErrors(enableErrors bool) Option {
+	return func(o *Options) {
+		o.enableErrors = enableErrors
+	}
+}
+
+// WithFlags sets additional flags that are passed to the command.
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.flags = append(o.flags, flags...)
+	}
+}
+
+// WithJSON indicates whether the output should be printed in JSON format.
+func WithJSON(enableJSON bool) Option {
+	return func(o *Options) {
+		o.enableJSON = enableJSON
+	}
+}
+
+// WithModules indicates whether modules should be listed instead of packages.
+func WithModules(enableModules bool) Option {
+	return func(o *Options) {
+		o.enableModules = enableModules
+	}
+}
+
+// WithPatterns sets the package or module patterns to list.
+func WithPatterns(patterns ...string) Option {
+	return func(o *Options) {
+		o.patterns = append(o.patterns, patterns...)
+	}
+}
+
+// WithTest indicates whether test packages and test variants of the named packages should be listed as well.
+// Combined with WithDeps the dependencies of the tests are listed too.
+func WithTest(enableTest bool) Option {
+	return func(o *Options) {
+		o.enableTest = enableTest
+	}
+}
+
+// WithUpdates indicates whether information about available module updates should be added.
+func WithUpdates(enableUpdates bool) Option {
+	return func(o *Options) {
+		o.enableUpdates = enableUpdates
+	}
+}
+
+// WithWorkingDir sets the path to the working directory of the command.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
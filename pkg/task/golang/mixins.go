@@ -6,11 +6,19 @@ package golang
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/svengreb/wand/pkg/project"
 	"github.com/svengreb/wand/pkg/task"
 )
 
+// conflictingMixins are pairs of mixins that can not be combined since they result in contradicting options.
+var conflictingMixins = [][2]task.Mixin{
+	// Debuggers rely on the DWARF tables that are omitted when stripping debug metadata.
+	{MixinImproveDebugging{}, MixinStripDebugMetadata{}},
+}
+
 // MixinImproveDebugging is a task.Mixin for golang.Options to add linker flags to improve the debugging of binary
 // artifacts.
 // This includes the disabling of inlining and all compiler optimizations to improve the compatibility for debuggers.
@@ -168,3 +176,43 @@ func (m MixinStripDebugMetadata) Apply(so task.Options) (task.Options, error) {
 
 	return goOpts, nil
 }
+
+// ValidateMixins checks the given mixins for conflicting combinations.
+// It returns an error of type *task.ErrTask when any conflict has been detected.
+func ValidateMixins(mixins ...task.Mixin) error {
+	var conflicts []string
+	for _, c := range conflictingMixins {
+		if containsMixin(mixins, c[0]) && containsMixin(mixins, c[1]) {
+			conflicts = append(conflicts, fmt.Sprintf("%q conflicts with %q", mixinName(c[0]), mixinName(c[1])))
+		}
+	}
+	if len(conflicts) > 0 {
+		return &task.ErrTask{
+			Err:  fmt.Errorf("conflicting mixins: %s", strings.Join(conflicts, ", ")),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+	return nil
+}
+
+// containsMixin checks whether the given mixins contain a mixin of the same type as the given mixin.
+func containsMixin(mixins []task.Mixin, m task.Mixin) bool {
+	for _, mixin := range mixins {
+		if mixinName(mixin) == mixinName(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// mixinName returns the name of the type of the given mixin where pointers are dereferenced.
+func mixinName(m task.Mixin) string {
+	t := reflect.TypeOf(m)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+func TestBuildGoOptionsMixins(t *testing.T) {
+	tests := []struct {
+		name    string
+		mixins  []task.Mixin
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "debugging",
+			mixins: []task.Mixin{MixinImproveDebugging{}},
+			want:   []string{"-gcflags=all=-N -l"},
+		},
+		{
+			name:   "escape analysis after debugging limits flags to the target package",
+			mixins: []task.Mixin{MixinImproveDebugging{}, MixinImproveEscapeAnalysis{}},
+			want:   []string{"-gcflags=-N -l -m -m"},
+		},
+		{
+			name:   "stripped debug metadata after escape analysis applies flags to all packages",
+			mixins: []task.Mixin{MixinImproveEscapeAnalysis{}, MixinStripDebugMetadata{}},
+			want:   []string{"-gcflags=all=-m -m", "-ldflags=all=-s -w"},
+		},
+		{
+			name:    "debugging conflicts with stripped debug metadata",
+			mixins:  []task.Mixin{MixinImproveDebugging{}, MixinStripDebugMetadata{}},
+			wantErr: true,
+		},
+		{
+			name:    "mixin that can not be applied",
+			mixins:  []task.Mixin{MixinInjectBuildTimeVariableValues{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := BuildGoOptions(WithMixins(tc.mixins...))
+			if tc.wantErr {
+				require.ErrorIs(t, err, task.ErrInvalidTaskOpts)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, args)
+		})
+	}
+}
+
+func TestOptionsAppliedMixins(t *testing.T) {
+	opts, err := NewOptions(
+		WithMixins(MixinImproveEscapeAnalysis{}),
+		WithMixins(MixinImproveDebugging{}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []task.Mixin{MixinImproveEscapeAnalysis{}, MixinImproveDebugging{}}, opts.AppliedMixins())
+	require.True(t, opts.FlagsPrefixAll, "the last added mixin must take precedence")
+}
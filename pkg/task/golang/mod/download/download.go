@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package download provides a task for the Go toolchain "mod download" command.
+// The JSON output of the command is parsed into typed module information.
+//
+// See `go help mod download` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-mod-download
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Module is the information about a downloaded module.
+type Module struct {
+	// Dir is the absolute path to the cached source root directory.
+	Dir string `json:"Dir,omitempty"`
+
+	// Error is the error that occurred while loading the module.
+	Error string `json:"Error,omitempty"`
+
+	// GoMod is the absolute path to the cached ".mod" file.
+	GoMod string `json:"GoMod,omitempty"`
+
+	// GoModSum is the checksum of the "go.mod" file as stored in the "go.sum" file.
+	GoModSum string `json:"GoModSum,omitempty"`
+
+	// Info is the absolute path to the cached ".info" file.
+	Info string `json:"Info,omitempty"`
+
+	// Path is the module path.
+	Path string `json:"Path"`
+
+	// Query is the version query corresponding to the version.
+	Query string `json:"Query,omitempty"`
+
+	// Sum is the checksum of the module as stored in the "go.sum" file.
+	Sum string `json:"Sum,omitempty"`
+
+	// Version is the module version.
+	Version string `json:"Version,omitempty"`
+
+	// Zip is the absolute path to the cached ".zip" file.
+	Zip string `json:"Zip,omitempty"`
+}
+
+// Result is the result of running the task for a single module.
+type Result struct {
+	// ModuleDir is the path to the module directory.
+	ModuleDir string
+
+	// Modules are the downloaded modules.
+	Modules []Module
+}
+
+// Task is a task for the Go toolchain "mod download" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"mod", "download", "-json"}
+	params = append(params, t.opts.extraArgs...)
+	return append(params, t.opts.modules...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "mod download" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
+
+// ParseOutput parses the stream of JSON objects printed by the "mod download -json" command.
+func ParseOutput(r io.Reader) ([]Module, error) {
+	var modules []Module
+	dec := json.NewDecoder(r)
+	for {
+		var m Module
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return modules, nil
+			}
+			return nil, fmt.Errorf("decode module information: %w", err)
+		}
+		modules = append(modules, m)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package download
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		modules []Module
+		wantErr bool
+	}{
+		{
+			name: "modules",
+			output: strings.Join([]string{
+				`{`,
+				`	"Path": "golang.org/x/mod",`,
+				`	"Version": "v0.12.0",`,
+				`	"Info": "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.info",`,
+				`	"GoMod": "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.mod",`,
+				`	"Zip": "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.zip",`,
+				`	"Dir": "/go/pkg/mod/golang.org/x/mod@v0.12.0",`,
+				`	"Sum": "h1:rmsUpXtvNzj340zd98LZ4KntptpfRHwpFOHG188oHXc=",`,
+				`	"GoModSum": "h1:iBbtSCu2XBx23ZKBPSOrRkjjQPZFPuis4dIYUhu/chs="`,
+				`}`,
+				`{`,
+				`	"Path": "golang.org/x/tools",`,
+				`	"Query": "latest",`,
+				`	"Version": "v0.6.0"`,
+				`}`,
+			}, "\n"),
+			modules: []Module{
+				{
+					Dir:      "/go/pkg/mod/golang.org/x/mod@v0.12.0",
+					GoMod:    "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.mod",
+					GoModSum: "h1:iBbtSCu2XBx23ZKBPSOrRkjjQPZFPuis4dIYUhu/chs=",
+					Info:     "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.info",
+					Path:     "golang.org/x/mod",
+					Sum:      "h1:rmsUpXtvNzj340zd98LZ4KntptpfRHwpFOHG188oHXc=",
+					Version:  "v0.12.0",
+					Zip:      "/go/pkg/mod/cache/download/golang.org/x/mod/@v/v0.12.0.zip",
+				},
+				{Path: "golang.org/x/tools", Query: "latest", Version: "v0.6.0"},
+			},
+		},
+		{
+			name:    "module with error",
+			output:  `{"Path":"example.com/missing","Version":"v1.0.0","Error":"unknown revision v1.0.0"}`,
+			modules: []Module{{Error: "unknown revision v1.0.0", Path: "example.com/missing", Version: "v1.0.0"}},
+		},
+		{name: "empty output"},
+		{name: "invalid JSON", output: `{"Path":"golang.org/x/mod"`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			modules, err := ParseOutput(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.modules, modules)
+		})
+	}
+}
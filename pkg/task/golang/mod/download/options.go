@@ -0,0 +1,72 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package download
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/mod/download"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// env is the task specific environment.
+	env map[string]string
+
+	// extraArgs are additional arguments passed to the command.
+	extraArgs []string
+
+	// modules are the module queries to download.
+	// By default all dependencies of the main module are downloaded.
+	modules []string
+
+	// name is the task name.
+	name string
+
+	// workingDir is the path to the module directory.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithExtraArgs sets additional arguments to pass to the command.
+func WithExtraArgs(extraArgs ...string) Option {
+	return func(o *Options) {
+		o.extraArgs = append(o.extraArgs, extraArgs...)
+	}
+}
+
+// WithModules sets the module queries to download, e.g. "golang.org/x/mod@latest".
+func WithModules(modules ...string) Option {
+	return func(o *Options) {
+		o.modules = append(o.modules, modules...)
+	}
+}
+
+// WithWorkingDir sets the path to the module directory.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
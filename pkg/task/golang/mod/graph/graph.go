@@ -0,0 +1,172 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package graph provides a task for the Go toolchain "mod graph" command.
+// The output of the command is parsed into a typed module requirement graph.
+//
+// See `go help mod graph` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-mod-graph
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Edge is a requirement of one module on another.
+type Edge struct {
+	// From is the requiring module.
+	From Node
+
+	// To is the required module.
+	To Node
+}
+
+// Graph is a module requirement graph.
+type Graph struct {
+	// Edges are all requirements of the graph.
+	Edges []Edge
+
+	// ModuleDir is the path to the directory of the main module.
+	ModuleDir string
+}
+
+// Dependencies returns the modules that are directly required by the given module.
+func (g *Graph) Dependencies(n Node) []Node {
+	var nodes []Node
+	for _, e := range g.Edges {
+		if e.From == n {
+			nodes = append(nodes, e.To)
+		}
+	}
+	return nodes
+}
+
+// Dependents returns the modules that directly require the given module.
+func (g *Graph) Dependents(n Node) []Node {
+	var nodes []Node
+	for _, e := range g.Edges {
+		if e.To == n {
+			nodes = append(nodes, e.From)
+		}
+	}
+	return nodes
+}
+
+// Nodes returns all unique modules of the graph sorted by path and version.
+func (g *Graph) Nodes() []Node {
+	seen := make(map[Node]bool)
+	var nodes []Node
+	for _, e := range g.Edges {
+		for _, n := range []Node{e.From, e.To} {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Path != nodes[j].Path {
+			return nodes[i].Path < nodes[j].Path
+		}
+		return nodes[i].Version < nodes[j].Version
+	})
+	return nodes
+}
+
+// Node is a module of the graph.
+type Node struct {
+	// Path is the module path.
+	Path string
+
+	// Version is the module version.
+	// It is empty for the main module.
+	Version string
+}
+
+func (n Node) String() string {
+	if n.Version == "" {
+		return n.Path
+	}
+	return n.Path + project.GoModuleVersionSuffixSeparator + n.Version
+}
+
+// Task is a task for the Go toolchain "mod graph" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"mod", "graph"}
+
+	if t.opts.goVersion != "" {
+		params = append(params, "-go="+t.opts.goVersion)
+	}
+
+	return params
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "mod graph" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
+
+// ParseNode parses a module in the "path@version" format.
+func ParseNode(s string) Node {
+	path, version, _ := strings.Cut(s, project.GoModuleVersionSuffixSeparator)
+	return Node{Path: path, Version: version}
+}
+
+// ParseOutput parses the output of the "mod graph" command where each line is a requirement in the "from to" format.
+func ParseOutput(r io.Reader) (*Graph, error) {
+	g := &Graph{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid requirement line: %q", line)
+		}
+		g.Edges = append(g.Edges, Edge{From: ParseNode(fields[0]), To: ParseNode(fields[1])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read output: %w", err)
+	}
+	return g, nil
+}
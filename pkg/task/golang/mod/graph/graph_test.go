@@ -0,0 +1,93 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		edges   []Edge
+		wantErr bool
+	}{
+		{
+			name: "requirements",
+			output: strings.Join([]string{
+				"example.com/app golang.org/x/mod@v0.12.0",
+				"example.com/app go@1.21",
+				"",
+				"golang.org/x/mod@v0.12.0 golang.org/x/tools@v0.6.0",
+			}, "\n"),
+			edges: []Edge{
+				{From: Node{Path: "example.com/app"}, To: Node{Path: "golang.org/x/mod", Version: "v0.12.0"}},
+				{From: Node{Path: "example.com/app"}, To: Node{Path: "go", Version: "1.21"}},
+				{
+					From: Node{Path: "golang.org/x/mod", Version: "v0.12.0"},
+					To:   Node{Path: "golang.org/x/tools", Version: "v0.6.0"},
+				},
+			},
+		},
+		{name: "empty output"},
+		{name: "missing requirement", output: "example.com/app\n", wantErr: true},
+		{name: "too many fields", output: "example.com/app a@v1.0.0 b@v1.0.0\n", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := ParseOutput(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.edges, g.Edges)
+		})
+	}
+}
+
+func TestGraph(t *testing.T) {
+	g, err := ParseOutput(strings.NewReader(strings.Join([]string{
+		"example.com/app golang.org/x/tools@v0.6.0",
+		"example.com/app golang.org/x/mod@v0.12.0",
+		"golang.org/x/tools@v0.6.0 golang.org/x/mod@v0.8.0",
+		"golang.org/x/mod@v0.12.0 golang.org/x/tools@v0.6.0",
+	}, "\n")))
+	require.NoError(t, err)
+
+	app := Node{Path: "example.com/app"}
+	mod8 := Node{Path: "golang.org/x/mod", Version: "v0.8.0"}
+	mod12 := Node{Path: "golang.org/x/mod", Version: "v0.12.0"}
+	tools := Node{Path: "golang.org/x/tools", Version: "v0.6.0"}
+
+	require.Equal(t, []Node{app, mod12, mod8, tools}, g.Nodes())
+	require.Equal(t, []Node{tools, mod12}, g.Dependencies(app))
+	require.Equal(t, []Node{mod8}, g.Dependencies(tools))
+	require.Empty(t, g.Dependencies(mod8))
+	require.Equal(t, []Node{app, mod12}, g.Dependents(tools))
+	require.Empty(t, g.Dependents(app))
+}
+
+func TestNode(t *testing.T) {
+	tests := []struct {
+		s    string
+		node Node
+	}{
+		{s: "example.com/app", node: Node{Path: "example.com/app"}},
+		{s: "golang.org/x/mod@v0.12.0", node: Node{Path: "golang.org/x/mod", Version: "v0.12.0"}},
+		{s: "toolchain@go1.21.0", node: Node{Path: "toolchain", Version: "go1.21.0"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			require.Equal(t, tc.node, ParseNode(tc.s))
+			require.Equal(t, tc.s, tc.node.String())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package graph
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/mod/graph"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// env is the task specific environment.
+	env map[string]string
+
+	// goVersion is the Go version used to load the module graph.
+	goVersion string
+
+	// name is the task name.
+	name string
+
+	// workingDir is the path to the module directory.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithGoVersion sets the Go version used to load the module graph.
+//
+// See `go help mod graph` for more details.
+func WithGoVersion(version string) Option {
+	return func(o *Options) {
+		o.goVersion = version
+	}
+}
+
+// WithWorkingDir sets the path to the module directory.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
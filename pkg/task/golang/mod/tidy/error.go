@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package tidy
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrUntidy indicates that module files are not tidy.
+const ErrUntidy = wErr.ErrString("module files are not tidy")
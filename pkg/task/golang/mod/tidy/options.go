@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package tidy
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/mod/tidy"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// compat is the Go version whose module graph must remain loadable.
+	compat string
+
+	// EnableCheck indicates whether the task should only check whether the module files are tidy without modifying them.
+	EnableCheck bool
+
+	// enableVerboseOutput indicates whether information about removed modules should be printed.
+	enableVerboseOutput bool
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// extraArgs are additional arguments passed to the command.
+	extraArgs []string
+
+	// goVersion is the Go version to set in the "go" directive of the module file.
+	goVersion string
+
+	// name is the task name.
+	name string
+
+	// workingDir is the path to the module directory.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithCheck indicates whether the task should only check whether the module files are tidy.
+// In check mode the module files are restored after running the command and the task fails when they would change.
+func WithCheck(enableCheck bool) Option {
+	return func(o *Options) {
+		o.EnableCheck = enableCheck
+	}
+}
+
+// WithCompat sets the Go version whose module graph must remain loadable.
+//
+// See `go help mod tidy` for more details.
+func WithCompat(version string) Option {
+	return func(o *Options) {
+		o.compat = version
+	}
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithExtraArgs sets additional arguments to pass to the command.
+func WithExtraArgs(extraArgs ...string) Option {
+	return func(o *Options) {
+		o.extraArgs = append(o.extraArgs, extraArgs...)
+	}
+}
+
+// WithGoVersion sets the Go version to set in the "go" directive of the module file.
+//
+// See `go help mod tidy` for more details.
+func WithGoVersion(version string) Option {
+	return func(o *Options) {
+		o.goVersion = version
+	}
+}
+
+// WithVerboseOutput indicates whether information about removed modules should be printed.
+func WithVerboseOutput(enableVerboseOutput bool) Option {
+	return func(o *Options) {
+		o.enableVerboseOutput = enableVerboseOutput
+	}
+}
+
+// WithWorkingDir sets the path to the module directory.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package tidy provides a task for the Go toolchain "mod tidy" command.
+//
+// See `go help mod tidy` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-mod-tidy
+package tidy
+
+import "github.com/svengreb/wand/pkg/task"
+
+// Result is the result of running the task for a single module.
+type Result struct {
+	// Changed indicates whether the module files changed or, in check mode, would change.
+	Changed bool
+
+	// Diff is the unified diff of all changes to the module files.
+	Diff string
+
+	// ModuleDir is the path to the module directory.
+	ModuleDir string
+}
+
+// Task is a task for the Go toolchain "mod tidy" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"mod", "tidy"}
+
+	if t.opts.enableVerboseOutput {
+		params = append(params, "-v")
+	}
+
+	if t.opts.goVersion != "" {
+		params = append(params, "-go="+t.opts.goVersion)
+	}
+
+	if t.opts.compat != "" {
+		params = append(params, "-compat="+t.opts.compat)
+	}
+
+	return append(params, t.opts.extraArgs...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "mod tidy" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package verify provides a task for the Go toolchain "mod verify" command.
+// It checks that the dependencies of the main module stored in the module cache have not been modified since they were
+// downloaded.
+//
+// See `go help mod verify` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-mod-verify
+package verify
+
+import "github.com/svengreb/wand/pkg/task"
+
+// Task is a task for the Go toolchain "mod verify" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	return append([]string{"mod", "verify"}, t.opts.extraArgs...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "mod verify" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package why
+
+const (
+	// taskName is the name of the task.
+	taskName = "go/mod/why"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// enableModules indicates whether the targets are modules instead of packages.
+	enableModules bool
+
+	// enableVendor indicates whether imports of tests of dependencies should be excluded.
+	enableVendor bool
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// name is the task name.
+	name string
+
+	// targets are the packages or modules to explain.
+	targets []string
+
+	// workingDir is the path to the module directory.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:  make(map[string]string),
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithModules indicates whether the targets are modules instead of packages.
+//
+// See `go help mod why` for more details.
+func WithModules(enableModules bool) Option {
+	return func(o *Options) {
+		o.enableModules = enableModules
+	}
+}
+
+// WithTargets sets the packages or modules to explain.
+func WithTargets(targets ...string) Option {
+	return func(o *Options) {
+		o.targets = append(o.targets, targets...)
+	}
+}
+
+// WithVendor indicates whether imports of tests of dependencies should be excluded.
+//
+// See `go help mod why` for more details.
+func WithVendor(enableVendor bool) Option {
+	return func(o *Options) {
+		o.enableVendor = enableVendor
+	}
+}
+
+// WithWorkingDir sets the path to the module directory.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package why provides a task for the Go toolchain "mod why" command.
+// The output of the command is parsed into typed explanations.
+//
+// See `go help mod why` and the [Go module reference documentation] for more details.
+//
+// [Go module reference documentation]: https://go.dev/ref/mod#go-mod-why
+package why
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Explanation explains why a package or module is needed by the main module.
+type Explanation struct {
+	// Chain is the shortest import path from the main module to the target.
+	// It is empty when the target is not needed.
+	Chain []string
+
+	// NotNeeded indicates whether the target is not needed by the main module.
+	NotNeeded bool
+
+	// Target is the explained package or module.
+	Target string
+}
+
+// Result is the result of running the task for a single module.
+type Result struct {
+	// Explanations are the explanations for all targets.
+	Explanations []Explanation
+
+	// ModuleDir is the path to the module directory.
+	ModuleDir string
+}
+
+// Task is a task for the Go toolchain "mod why" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"mod", "why"}
+
+	if t.opts.enableModules {
+		params = append(params, "-m")
+	}
+
+	if t.opts.enableVendor {
+		params = append(params, "-vendor")
+	}
+
+	return append(params, t.opts.targets...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the module directory.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go toolchain "mod why" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
+
+// ParseOutput parses the output of the "mod why" command.
+// Each explanation starts with a "# <target>" line followed by the import chain or a note that the target is not
+// needed.
+func ParseOutput(r io.Reader) ([]Explanation, error) {
+	var explanations []Explanation
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "# "):
+			explanations = append(explanations, Explanation{Target: strings.TrimPrefix(line, "# ")})
+		case len(explanations) == 0:
+			return nil, fmt.Errorf("unexpected line without target: %q", line)
+		case strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")"):
+			explanations[len(explanations)-1].NotNeeded = true
+		default:
+			explanations[len(explanations)-1].Chain = append(explanations[len(explanations)-1].Chain, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read output: %w", err)
+	}
+	return explanations, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package why
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		explanations []Explanation
+		wantErr      bool
+	}{
+		{
+			name: "packages",
+			output: strings.Join([]string{
+				"# golang.org/x/text/language",
+				"example.com/app",
+				"golang.org/x/text/language",
+				"",
+				"# golang.org/x/mod/semver",
+				"example.com/app/internal/version",
+				"golang.org/x/mod/semver",
+			}, "\n"),
+			explanations: []Explanation{
+				{Chain: []string{"example.com/app", "golang.org/x/text/language"}, Target: "golang.org/x/text/language"},
+				{
+					Chain:  []string{"example.com/app/internal/version", "golang.org/x/mod/semver"},
+					Target: "golang.org/x/mod/semver",
+				},
+			},
+		},
+		{
+			name: "module not needed by the main module",
+			output: strings.Join([]string{
+				"# golang.org/x/sys",
+				"(main module does not need module golang.org/x/sys)",
+				"",
+				"# golang.org/x/text",
+				"example.com/app",
+				"golang.org/x/text/language",
+			}, "\n"),
+			explanations: []Explanation{
+				{NotNeeded: true, Target: "golang.org/x/sys"},
+				{Chain: []string{"example.com/app", "golang.org/x/text/language"}, Target: "golang.org/x/text"},
+			},
+		},
+		{
+			name:         "package not needed by the main module",
+			output:       "# example.com/unused\n(main module does not need package example.com/unused)\n",
+			explanations: []Explanation{{NotNeeded: true, Target: "example.com/unused"}},
+		},
+		{name: "empty output", output: "\n\n"},
+		{name: "line without target", output: "example.com/app\n# golang.org/x/sys\n", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			explanations, err := ParseOutput(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.explanations, explanations)
+		})
+	}
+}
@@ -16,15 +16,46 @@ import (
 )
 
 const (
+	// DefaultEnvVarCC is the default environment variable name for the C compiler used by cgo.
+	DefaultEnvVarCC = "CC"
+
+	// DefaultEnvVarCGOCFLAGS is the default environment variable name for the flags passed to the C compiler by cgo.
+	DefaultEnvVarCGOCFLAGS = "CGO_CFLAGS"
+
+	// DefaultEnvVarCGOCXXFLAGS is the default environment variable name for the flags passed to the C++ compiler by
+	// cgo.
+	DefaultEnvVarCGOCXXFLAGS = "CGO_CXXFLAGS"
+
+	// DefaultEnvVarCGOENABLED is the default environment variable name to toggle cgo.
+	DefaultEnvVarCGOENABLED = "CGO_ENABLED"
+
+	// DefaultEnvVarCGOLDFLAGS is the default environment variable name for the flags passed to the linker by cgo.
+	DefaultEnvVarCGOLDFLAGS = "CGO_LDFLAGS"
+
+	// DefaultEnvVarCXX is the default environment variable name for the C++ compiler used by cgo.
+	DefaultEnvVarCXX = "CXX"
+
 	// DefaultEnvVarGO111MODULE is the default environment variable name to toggle the Go 1.11 module mode.
 	DefaultEnvVarGO111MODULE = "GO111MODULE"
 
+	// DefaultEnvVarGOARCH is the default environment variable name for the target architecture.
+	DefaultEnvVarGOARCH = "GOARCH"
+
 	// DefaultEnvVarGOBIN is the default environment variable name for the Go binary executable search path.
 	DefaultEnvVarGOBIN = "GOBIN"
 
+	// DefaultEnvVarGOCACHE is the default environment variable name for the Go build cache directory.
+	DefaultEnvVarGOCACHE = "GOCACHE"
+
 	// DefaultEnvVarGOFLAGS is the default environment variable name for Go tool flags.
 	DefaultEnvVarGOFLAGS = "GOFLAGS"
 
+	// DefaultEnvVarGOMODCACHE is the default environment variable name for the Go module cache directory.
+	DefaultEnvVarGOMODCACHE = "GOMODCACHE"
+
+	// DefaultEnvVarGOOS is the default environment variable name for the target operating system.
+	DefaultEnvVarGOOS = "GOOS"
+
 	// DefaultEnvVarGOPATH is the default environment variable name for the Go path.
 	DefaultEnvVarGOPATH = "GOPATH"
 
@@ -53,6 +84,12 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
 	AsmFlags []string
 
+	// Cgo are the cgo options. The defaults of the Go toolchain are used when nil.
+	//
+	// See `go help environment`, `go doc cmd/cgo` and the `go` command documentations for more details:
+	//   - https://pkg.go.dev/cmd/cgo
+	Cgo *CgoOptions
+
 	// EnableRaceDetector indicates whether the race detector should be enabled.
 	//
 	// See `go help build` and the `go` command documentations for more details:
@@ -102,6 +139,9 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
 	LdFlags []string
 
+	// appliedMixins are the parameter mixins that have been applied in the order of their application.
+	appliedMixins []task.Mixin
+
 	// mixins are parameter mixins that can be applied by option consumers.
 	mixins []task.Mixin
 
@@ -128,73 +168,89 @@ type RunnerOptions struct {
 }
 
 // BuildGoOptions builds shared Go toolchain options.
-func BuildGoOptions(opts ...Option) []string {
-	opt := NewOptions(opts...)
+// It returns an error of type *task.ErrTask when mixins conflict with each other or when any mixin can not be applied.
+//
+// See Options.Params to build the parameters of already created options.
+func BuildGoOptions(opts ...Option) ([]string, error) {
+	opt, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return opt.Params(), nil
+}
+
+// NewOptions creates new shared Go toolchain options.
+// Mixins are applied after all other options in the order they have been added.
+// It returns an error of type *task.ErrTask when mixins conflict with each other or when any mixin can not be applied.
+func NewOptions(opts ...Option) (*Options, error) {
+	opt, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+// cgo returns the cgo options which are initialized on first use.
+func (o *Options) cgo() *CgoOptions {
+	if o.Cgo == nil {
+		o.Cgo = &CgoOptions{}
+	}
+	return o.Cgo
+}
+
+// AppliedMixins returns the parameter mixins that have been applied in the order of their application.
+func (o *Options) AppliedMixins() []task.Mixin {
+	return o.appliedMixins
+}
+
+// Params returns the parameters of the shared Go toolchain options.
+func (o *Options) Params() []string {
 	var args []string
 
-	if len(opt.Tags) > 0 {
-		args = append(args, fmt.Sprintf("-tags='%s'", strings.Join(opt.Tags, " ")))
+	if len(o.Tags) > 0 {
+		args = append(args, fmt.Sprintf("-tags='%s'", strings.Join(o.Tags, " ")))
 	}
 
-	if opt.EnableRaceDetector {
+	if o.EnableRaceDetector {
 		args = append(args, "-race")
 	}
 
-	if opt.EnableTrimPath {
+	if o.EnableTrimPath {
 		args = append(args, "-trimpath")
 	}
 
-	if len(opt.AsmFlags) > 0 {
+	if len(o.AsmFlags) > 0 {
 		flag := "-asmflags"
-		if opt.FlagsPrefixAll {
+		if o.FlagsPrefixAll {
 			flag = fmt.Sprintf("%s=all", flag)
 		}
-		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(opt.AsmFlags, " ")))
+		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(o.AsmFlags, " ")))
 	}
 
-	if len(opt.GcFlags) > 0 {
+	if len(o.GcFlags) > 0 {
 		flag := "-gcflags"
-		if opt.FlagsPrefixAll {
+		if o.FlagsPrefixAll {
 			flag = fmt.Sprintf("%s=all", flag)
 		}
-		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(opt.GcFlags, " ")))
+		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(o.GcFlags, " ")))
 	}
 
-	if len(opt.LdFlags) > 0 {
+	if len(o.LdFlags) > 0 {
 		flag := "-ldflags"
-		if opt.FlagsPrefixAll {
+		if o.FlagsPrefixAll {
 			flag = fmt.Sprintf("%s=all", flag)
 		}
-		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(opt.LdFlags, " ")))
+		args = append(args, fmt.Sprintf("%s=%s", flag, strings.Join(o.LdFlags, " ")))
 	}
 
-	if len(opt.Flags) > 0 {
-		args = append(args, opt.Flags...)
+	if len(o.Flags) > 0 {
+		args = append(args, o.Flags...)
 	}
 
 	return args
 }
 
-// NewOptions creates new shared Go toolchain options.
-func NewOptions(opts ...Option) *Options {
-	opt := &Options{
-		Env: make(map[string]string),
-	}
-	for _, o := range opts {
-		o(opt)
-	}
-
-	for _, m := range opt.mixins {
-		mixedOpt, mixErr := m.Apply(opt)
-		if mixErr != nil {
-			continue
-		}
-		_ = mergo.Merge(opt, mixedOpt)
-	}
-
-	return opt
-}
-
 // NewRunnerOptions creates new runner options.
 func NewRunnerOptions(opts ...RunnerOption) *RunnerOptions {
 	opt := &RunnerOptions{
@@ -219,6 +275,57 @@ func WithAsmFlags(asmFlags ...string) Option {
 	}
 }
 
+// WithCgo indicates whether cgo should be enabled.
+// Note that the "CGO_ENABLED" environment variable is set explicitly so that cross-compilations do not silently disable
+// cgo. When this option is not used the default of the Go toolchain applies, also when other cgo options are set.
+func WithCgo(enabled bool) Option {
+	return func(o *Options) {
+		o.cgo().Enabled = &enabled
+	}
+}
+
+// WithCgoCFlags sets the flags passed to the C compiler by cgo through the "CGO_CFLAGS" environment variable.
+func WithCgoCFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.cgo().CFlags = append(o.cgo().CFlags, flags...)
+	}
+}
+
+// WithCgoCXXFlags sets the flags passed to the C++ compiler by cgo through the "CGO_CXXFLAGS" environment variable.
+func WithCgoCXXFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.cgo().CXXFlags = append(o.cgo().CXXFlags, flags...)
+	}
+}
+
+// WithCgoLdFlags sets the flags passed to the linker by cgo through the "CGO_LDFLAGS" environment variable.
+func WithCgoLdFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.cgo().LdFlags = append(o.cgo().LdFlags, flags...)
+	}
+}
+
+// WithCgoStaticLinking indicates whether binary artifacts should be linked statically through the external linker.
+// This adds the "-linkmode=external" and "-extldflags=-static" linker flags when cgo has been enabled through WithCgo.
+//
+// See `go doc cmd/link` for more details.
+func WithCgoStaticLinking(static bool) Option {
+	return func(o *Options) {
+		o.cgo().Static = static
+	}
+}
+
+// WithCgoToolchain sets the C and C++ compiler commands for the given target platform in the "os/arch" format that are
+// passed through the "CC" and "CXX" environment variables when building for the platform.
+func WithCgoToolchain(platform, cc, cxx string) Option {
+	return func(o *Options) {
+		if o.cgo().Toolchains == nil {
+			o.cgo().Toolchains = make(map[string]CgoToolchain)
+		}
+		o.cgo().Toolchains[platform] = CgoToolchain{CC: cc, CXX: cxx}
+	}
+}
+
 // WithEnv sets the runner specific environment.
 func WithEnv(env map[string]string) Option {
 	return func(o *Options) {
@@ -275,6 +382,10 @@ func WithLdFlags(ldFlags ...string) Option {
 }
 
 // WithMixins sets parameter mixins that can be applied by option consumers.
+// Mixins are applied after all other options in the order they have been added, also across multiple calls, so that
+// later mixins take precedence over earlier ones when they set the same options. For example, the "all" prefix for
+// flags is disabled when MixinImproveEscapeAnalysis is added after MixinImproveDebugging and enabled when it is added
+// before.
 func WithMixins(mixins ...task.Mixin) Option {
 	return func(o *Options) {
 		o.mixins = append(o.mixins, mixins...)
@@ -336,3 +447,43 @@ func WithTrimmedPath(enableTrimPath bool) Option {
 		o.EnableTrimPath = enableTrimPath
 	}
 }
+
+// newOptions creates new shared Go toolchain options.
+// The returned options include all successfully applied mixins even when an error is returned.
+func newOptions(opts ...Option) (*Options, error) {
+	opt := &Options{
+		Env: make(map[string]string),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	// Variables of the explicitly configured environment take precedence over the derived cgo environment.
+	if opt.Cgo != nil {
+		for k, v := range opt.Cgo.Env(TargetPlatform(opt.Env)) {
+			if _, ok := opt.Env[k]; !ok {
+				opt.Env[k] = v
+			}
+		}
+		if opt.Cgo.IsEnabled() && opt.Cgo.Static {
+			opt.LdFlags = append(opt.LdFlags, "-linkmode=external", "-extldflags=-static")
+		}
+	}
+
+	if err := ValidateMixins(opt.mixins...); err != nil {
+		return opt, err
+	}
+	for _, m := range opt.mixins {
+		mixedOpt, mixErr := m.Apply(opt)
+		if mixErr != nil {
+			return opt, &task.ErrTask{
+				Err:  fmt.Errorf("apply mixin %q: %w", mixinName(m), mixErr),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		_ = mergo.Merge(opt, mixedOpt)
+		opt.appliedMixins = append(opt.appliedMixins, m)
+	}
+
+	return opt, nil
+}
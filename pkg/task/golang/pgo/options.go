@@ -0,0 +1,184 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pgo
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+)
+
+const (
+	// BenchRunNameBaseline is the name of the benchmark run without profile-guided optimization.
+	BenchRunNameBaseline = "pgo=off"
+
+	// BenchRunNameOptimized is the name of the benchmark run with profile-guided optimization.
+	BenchRunNameOptimized = "pgo=on"
+
+	// DefaultAppProfileURLPath is the default URL path of the CPU profile endpoint of the "net/http/pprof" package.
+	DefaultAppProfileURLPath = "/debug/pprof/profile"
+
+	// DefaultMergedProfileFileName is the default file name for the merged profile.
+	DefaultMergedProfileFileName = "merged.pgo"
+
+	// DefaultOutputDirName is the default output directory name for the merged profile and the binary artifacts of the
+	// builds with and without profile-guided optimization.
+	DefaultOutputDirName = "pgo"
+
+	// taskName is the name of the task.
+	taskName = "go/pgo"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// BenchOptions are the Go toolchain "test" command benchmark task options for the comparison of the benchmarks with
+	// and without profile-guided optimization.
+	BenchOptions []taskGoBench.Option
+
+	// BinaryArtifactName is the name for the binary build artifacts.
+	BinaryArtifactName string
+
+	// BuildOptions are the Go toolchain "build" command task options for the builds with and without profile-guided
+	// optimization.
+	BuildOptions []taskGoBuild.Option
+
+	// EnableBenchComparison indicates whether the benchmarks should be run with and without profile-guided optimization
+	// to compare them.
+	EnableBenchComparison bool
+
+	// EnableDefaultProfileUpdate indicates whether the merged profile should be copied into the main package directory
+	// as taskGoBuild.DefaultPGOProfileFileName so that it is used for all further builds.
+	EnableDefaultProfileUpdate bool
+
+	// Env is the environment for "pprof".
+	Env map[string]string
+
+	// MergedProfileFileName is the file name for the merged profile.
+	MergedProfileFileName string
+
+	// name is the task name.
+	name string
+
+	// OutputDir is the output directory, relative to the project root, for the merged profile and the binary artifacts
+	// of the builds with and without profile-guided optimization.
+	OutputDir string
+
+	// ProfileDir is the directory the CPU profile of the GoTest task is discovered in when no profiles are set
+	// explicitly.
+	ProfileDir string
+
+	// Profiles are the paths or URLs to the CPU profiles to merge.
+	Profiles []string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		Env:                   make(map[string]string),
+		MergedProfileFileName: DefaultMergedProfileFileName,
+		name:                  taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithAppProfile adds the URL to the CPU profile endpoint of the "net/http/pprof" package of the running application
+// with the given address, e.g. "localhost:6060", that collects a profile for the given duration.
+func WithAppProfile(addr string, duration time.Duration) Option {
+	return func(o *Options) {
+		seconds := int(math.Ceil(duration.Seconds()))
+		o.Profiles = append(o.Profiles,
+			fmt.Sprintf("http://%s%s?seconds=%d", addr, DefaultAppProfileURLPath, seconds))
+	}
+}
+
+// WithBenchComparison indicates whether the benchmarks should be run with and without profile-guided optimization to
+// compare them.
+func WithBenchComparison(enableBenchComparison bool) Option {
+	return func(o *Options) {
+		o.EnableBenchComparison = enableBenchComparison
+	}
+}
+
+// WithBenchOptions sets the Go toolchain "test" command benchmark task options for the comparison of the benchmarks
+// with and without profile-guided optimization.
+func WithBenchOptions(benchOpts ...taskGoBench.Option) Option {
+	return func(o *Options) {
+		o.BenchOptions = append(o.BenchOptions, benchOpts...)
+	}
+}
+
+// WithBinaryArtifactName sets the name for the binary build artifacts.
+// Defaults to the application name.
+func WithBinaryArtifactName(name string) Option {
+	return func(o *Options) {
+		o.BinaryArtifactName = name
+	}
+}
+
+// WithBuildOptions sets the Go toolchain "build" command task options for the builds with and without profile-guided
+// optimization.
+func WithBuildOptions(buildOpts ...taskGoBuild.Option) Option {
+	return func(o *Options) {
+		o.BuildOptions = append(o.BuildOptions, buildOpts...)
+	}
+}
+
+// WithDefaultProfileUpdate indicates whether the merged profile should be copied into the main package directory as
+// taskGoBuild.DefaultPGOProfileFileName so that it is used for all further builds.
+func WithDefaultProfileUpdate(enableDefaultProfileUpdate bool) Option {
+	return func(o *Options) {
+		o.EnableDefaultProfileUpdate = enableDefaultProfileUpdate
+	}
+}
+
+// WithEnv sets the environment for "pprof".
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.Env = env
+	}
+}
+
+// WithMergedProfileFileName sets the file name for the merged profile.
+// Defaults to DefaultMergedProfileFileName.
+func WithMergedProfileFileName(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.MergedProfileFileName = name
+		}
+	}
+}
+
+// WithOutputDir sets the output directory, relative to the project root, for the merged profile and the binary
+// artifacts of the builds with and without profile-guided optimization.
+// Defaults to DefaultOutputDirName within the application specific output directory.
+func WithOutputDir(dir string) Option {
+	return func(o *Options) {
+		o.OutputDir = dir
+	}
+}
+
+// WithProfileDir sets the directory the CPU profile of the GoTest task is discovered in when no profiles are set
+// explicitly.
+// Defaults to the test output directory within the application specific output directory.
+func WithProfileDir(dir string) Option {
+	return func(o *Options) {
+		o.ProfileDir = dir
+	}
+}
+
+// WithProfiles adds the paths or URLs to the CPU profiles to merge.
+func WithProfiles(profiles ...string) Option {
+	return func(o *Options) {
+		o.Profiles = append(o.Profiles, profiles...)
+	}
+}
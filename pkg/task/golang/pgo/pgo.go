@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package pgo provides a task for profile-guided optimization (PGO) workflows.
+// CPU profiles, e.g. collected from benchmarks run through the GoTest task or from the "net/http/pprof" endpoint of a
+// running application, are merged into a single profile with the Go toolchain "tool pprof" command. The application is
+// then built with and without the merged profile to report the binary size delta and, optionally, the benchmark delta.
+//
+// See https://go.dev/doc/pgo for more details about profile-guided optimization.
+package pgo
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+	taskGoPprof "github.com/svengreb/wand/pkg/task/golang/pprof"
+	taskGoTest "github.com/svengreb/wand/pkg/task/golang/test"
+)
+
+// Task is a task for profile-guided optimization workflows.
+type Task struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BenchTask returns the Go toolchain "test" command benchmark task that runs with or without profile-guided
+// optimization using the merged profile.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+func (t *Task) BenchTask(optimized bool) (*taskGoBench.Task, error) {
+	opts := append([]taskGoBench.Option{}, t.opts.BenchOptions...)
+	opts = append(opts, taskGoBench.WithFlags(fmt.Sprintf("-pgo=%s", t.pgoProfile(optimized))))
+	return taskGoBench.New(t.ac, opts...)
+}
+
+// BuildOutputDir returns the output directory, relative to the project root, for the build with or without
+// profile-guided optimization.
+func (t *Task) BuildOutputDir(optimized bool) string {
+	if optimized {
+		return filepath.Join(t.opts.OutputDir, "on")
+	}
+	return filepath.Join(t.opts.OutputDir, "off")
+}
+
+// BuildTask returns the Go toolchain "build" task that builds with or without profile-guided optimization using the
+// merged profile.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+func (t *Task) BuildTask(optimized bool) (*taskGoBuild.Task, error) {
+	opts := append([]taskGoBuild.Option{}, t.opts.BuildOptions...)
+	opts = append(opts,
+		taskGoBuild.WithBinaryArtifactName(t.opts.BinaryArtifactName),
+		taskGoBuild.WithOutputDir(t.BuildOutputDir(optimized)),
+		taskGoBuild.WithPGOProfile(t.pgoProfile(optimized)),
+	)
+	return taskGoBuild.New(t.ac, opts...)
+}
+
+// DefaultProfile returns the path, relative to the project root, to the profile in the main package directory that is
+// detected by the default "auto" mode of the Go toolchain "build" command.
+func (t *Task) DefaultProfile() string {
+	return filepath.Join(t.ac.PathRel, taskGoBuild.DefaultPGOProfileFileName)
+}
+
+// MergeTask returns the Go toolchain "tool pprof" task that merges the given CPU profiles into the merged profile.
+func (t *Task) MergeTask(profiles ...string) *taskGoPprof.Task {
+	return taskGoPprof.New(
+		taskGoPprof.WithEnv(t.opts.Env),
+		taskGoPprof.WithFormat(taskGoPprof.FormatProto),
+		taskGoPprof.WithOutputFile(t.MergedProfile()),
+		taskGoPprof.WithProfiles(profiles...),
+	)
+}
+
+// MergedProfile returns the path, relative to the project root, to the merged profile.
+func (t *Task) MergedProfile() string {
+	return filepath.Join(t.opts.OutputDir, t.opts.MergedProfileFileName)
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// pgoProfile returns the value for the "-pgo" flag with or without profile-guided optimization.
+func (t *Task) pgoProfile(optimized bool) string {
+	if optimized {
+		return t.MergedProfile()
+	}
+	return taskGoBuild.PGOProfileOff
+}
+
+// New creates a new task for profile-guided optimization workflows.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(ac app.Config, opts ...Option) *Task {
+	opt := NewOptions(opts...)
+
+	if opt.BinaryArtifactName == "" {
+		opt.BinaryArtifactName = ac.Name
+	}
+
+	if opt.OutputDir == "" {
+		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
+	}
+
+	if opt.ProfileDir == "" {
+		opt.ProfileDir = filepath.Join(ac.BaseOutputDir, taskGoTest.DefaultOutputDirName)
+	}
+
+	return &Task{ac: ac, opts: opt}
+}
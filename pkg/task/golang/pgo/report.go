@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pgo
+
+import (
+	"fmt"
+	"strings"
+
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
+)
+
+// Build is a build with or without profile-guided optimization.
+type Build struct {
+	// Path is the path to the binary artifact.
+	Path string
+
+	// Size is the size of the binary artifact in bytes.
+	Size int64
+}
+
+// Report is the report of a profile-guided optimization workflow.
+type Report struct {
+	// BenchComparison is the comparison of the benchmarks without, as base, and with profile-guided optimization, if
+	// any.
+	BenchComparison *taskGoBench.Comparison
+
+	// Baseline is the build without profile-guided optimization.
+	Baseline Build
+
+	// DefaultProfileUpdated indicates whether the merged profile has been copied into the main package directory.
+	DefaultProfileUpdated bool
+
+	// MergedProfile is the path to the merged profile.
+	MergedProfile string
+
+	// Optimized is the build with profile-guided optimization.
+	Optimized Build
+
+	// Profiles are the paths or URLs to the CPU profiles that have been merged.
+	Profiles []string
+}
+
+// SizeChange returns the relative change of the binary artifact size from the build without to the build with
+// profile-guided optimization, e.g. 0.1 for an increase of 10%.
+func (r *Report) SizeChange() float64 {
+	if r.Baseline.Size == 0 {
+		return 0
+	}
+	return float64(r.SizeDelta()) / float64(r.Baseline.Size)
+}
+
+// SizeDelta returns the change of the binary artifact size in bytes from the build without to the build with
+// profile-guided optimization.
+func (r *Report) SizeDelta() int64 {
+	return r.Optimized.Size - r.Baseline.Size
+}
+
+// String returns a human-readable summary of the report.
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "merged %d profiles into %s\n", len(r.Profiles), r.MergedProfile)
+	fmt.Fprintf(&sb, "binary size: %d bytes without PGO, %d bytes with PGO (%+d bytes, %+.2f%%)",
+		r.Baseline.Size, r.Optimized.Size, r.SizeDelta(), r.SizeChange()*100)
+	if r.BenchComparison != nil {
+		fmt.Fprintf(&sb, "\n%s", r.BenchComparison)
+	}
+	return sb.String()
+}
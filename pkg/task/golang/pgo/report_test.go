@@ -0,0 +1,53 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pgo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	taskGoBench "github.com/svengreb/wand/pkg/task/golang/bench"
+)
+
+func TestReportSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseline  int64
+		optimized int64
+		delta     int64
+		change    float64
+	}{
+		{name: "growth", baseline: 1000, optimized: 1100, delta: 100, change: 0.1},
+		{name: "shrink", baseline: 1000, optimized: 750, delta: -250, change: -0.25},
+		{name: "unchanged", baseline: 1000, optimized: 1000},
+		{name: "missing baseline", optimized: 1000, delta: 1000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Report{Baseline: Build{Size: tc.baseline}, Optimized: Build{Size: tc.optimized}}
+			require.Equal(t, tc.delta, r.SizeDelta())
+			require.InDelta(t, tc.change, r.SizeChange(), 1e-9)
+		})
+	}
+}
+
+func TestReportString(t *testing.T) {
+	r := &Report{
+		Baseline:      Build{Path: "off/app", Size: 1000},
+		MergedProfile: "out/pgo/merged.pgo",
+		Optimized:     Build{Path: "on/app", Size: 1100},
+		Profiles:      []string{"a.pprof", "b.pprof"},
+	}
+	want := strings.Join([]string{
+		"merged 2 profiles into out/pgo/merged.pgo",
+		"binary size: 1000 bytes without PGO, 1100 bytes with PGO (+100 bytes, +10.00%)",
+	}, "\n")
+	require.Equal(t, want, r.String())
+
+	r.BenchComparison = &taskGoBench.Comparison{BaseCommit: BenchRunNameBaseline, HeadCommit: BenchRunNameOptimized}
+	require.Equal(t, want+"\n"+r.BenchComparison.String(), r.String())
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golang
+
+import (
+	"os"
+	"runtime"
+)
+
+// HostPlatform returns the platform of the current process in the "os/arch" format.
+func HostPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// TargetPlatform returns the target platform in the "os/arch" format for the given environment.
+// The "GOOS" and "GOARCH" environment variables of the given environment take precedence over the ones of the current
+// process while the host platform is used as fallback.
+func TargetPlatform(env map[string]string) string {
+	lookup := func(name, fallback string) string {
+		if v := env[name]; v != "" {
+			return v
+		}
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+		return fallback
+	}
+	return lookup(DefaultEnvVarGOOS, runtime.GOOS) + "/" + lookup(DefaultEnvVarGOARCH, runtime.GOARCH)
+}
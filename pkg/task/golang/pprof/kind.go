@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pprof
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatNameProto is the Format name for compressed protocol buffers.
+	FormatNameProto = "proto"
+	// FormatNameSVG is the Format name for SVG call graphs.
+	FormatNameSVG = "svg"
+	// FormatNameTop is the Format name for text tables of the top entries.
+	FormatNameTop = "top"
+	// FormatNameTraces is the Format name for all samples with their stack traces in text form.
+	FormatNameTraces = "traces"
+	// FormatNameUnknown is the name for a unknown Format.
+	FormatNameUnknown = "unknown"
+)
+
+const (
+	// FormatTop is the Format for text tables of the top entries.
+	FormatTop Format = iota
+	// FormatTraces is the Format for all samples with their stack traces in text form.
+	FormatTraces
+	// FormatSVG is the Format for SVG call graphs.
+	// Note that this format requires the "dot" command of Graphviz to be available in the executable search path.
+	//
+	// See https://graphviz.org for more details.
+	FormatSVG
+	// FormatProto is the Format for compressed protocol buffers which is the format of profiles itself.
+	// This format is used to merge multiple profiles into a single one, e.g. for profile-guided optimization.
+	FormatProto
+)
+
+// Format defines an output format of "pprof".
+type Format uint32
+
+// MarshalText returns the textual representation of itself.
+func (f Format) MarshalText() ([]byte, error) {
+	switch f {
+	case FormatTop:
+		return []byte(FormatNameTop), nil
+	case FormatTraces:
+		return []byte(FormatNameTraces), nil
+	case FormatSVG:
+		return []byte(FormatNameSVG), nil
+	case FormatProto:
+		return []byte(FormatNameProto), nil
+	}
+
+	return nil, fmt.Errorf("not a valid format %d", f)
+}
+
+func (f Format) String() string {
+	if b, err := f.MarshalText(); err == nil {
+		return string(b)
+	}
+	return FormatNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (f *Format) UnmarshalText(text []byte) error {
+	parsed, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = parsed
+	return nil
+}
+
+// ParseFormat takes a format name and returns the Format constant.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case FormatNameTop:
+		return FormatTop, nil
+	case FormatNameTraces:
+		return FormatTraces, nil
+	case FormatNameSVG:
+		return FormatSVG, nil
+	case FormatNameProto:
+		return FormatProto, nil
+	}
+
+	var f Format
+	return f, fmt.Errorf("not a valid format: %q", name)
+}
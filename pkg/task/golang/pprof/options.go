@@ -0,0 +1,251 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pprof
+
+const (
+	// DefaultNodeCount is the default number of entries shown in text tables.
+	DefaultNodeCount = 20
+
+	// DefaultReportOutputDirName is the default output directory name for profile reports.
+	DefaultReportOutputDirName = "pprof"
+
+	// taskName is the name of the task.
+	taskName = "go/pprof"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// diffBase is the path to the profile that is used as base to show differences.
+	diffBase string
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// flags are additional flags that are passed to "pprof".
+	flags []string
+
+	// format is the output format.
+	format Format
+
+	// name is the task name.
+	name string
+
+	// nodeCount is the number of entries shown in text tables.
+	nodeCount int
+
+	// outputFile is the path to the file the output is written to instead of the standard output.
+	outputFile string
+
+	// profile is the path to the profile to analyze.
+	profile string
+
+	// profiles are the paths to additional profiles that are merged with the profile to analyze.
+	profiles []string
+
+	// sampleIndex is the sample value to report, e.g. "alloc_space" or "inuse_objects" for memory profiles.
+	sampleIndex string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		env:       make(map[string]string),
+		format:    FormatTop,
+		name:      taskName,
+		nodeCount: DefaultNodeCount,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithDiffBase sets the path to the profile that is used as base to show differences.
+func WithDiffBase(diffBase string) Option {
+	return func(o *Options) {
+		o.diffBase = diffBase
+	}
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithFlags sets additional flags that are passed to "pprof".
+func WithFlags(flags ...string) Option {
+	return func(o *Options) {
+		o.flags = append(o.flags, flags...)
+	}
+}
+
+// WithFormat sets the output format.
+// Defaults to FormatTop.
+func WithFormat(format Format) Option {
+	return func(o *Options) {
+		o.format = format
+	}
+}
+
+// WithNodeCount sets the number of entries shown in text tables.
+// Defaults to DefaultNodeCount.
+func WithNodeCount(nodeCount int) Option {
+	return func(o *Options) {
+		if nodeCount > 0 {
+			o.nodeCount = nodeCount
+		}
+	}
+}
+
+// WithOutputFile sets the path to the file the output is written to instead of the standard output.
+func WithOutputFile(outputFile string) Option {
+	return func(o *Options) {
+		o.outputFile = outputFile
+	}
+}
+
+// WithProfile sets the path to the profile to analyze.
+func WithProfile(profile string) Option {
+	return func(o *Options) {
+		o.profile = profile
+	}
+}
+
+// WithProfiles sets the paths to additional profiles that are merged with the profile to analyze.
+// Note that "pprof" also accepts URLs, e.g. of the "net/http/pprof" endpoints of a running application, instead of
+// paths.
+func WithProfiles(profiles ...string) Option {
+	return func(o *Options) {
+		o.profiles = append(o.profiles, profiles...)
+	}
+}
+
+// WithSampleIndex sets the sample value to report, e.g. "alloc_space" or "inuse_objects" for memory profiles.
+func WithSampleIndex(sampleIndex string) Option {
+	return func(o *Options) {
+		o.sampleIndex = sampleIndex
+	}
+}
+
+// ReportOption is a option for profile reports.
+type ReportOption func(*ReportOptions)
+
+// ReportOptions are options for profile reports.
+type ReportOptions struct {
+	// BaseDir is the path to the directory with the profiles that are used as base to show differences.
+	// Profiles are matched by their file name.
+	BaseDir string
+
+	// BaseRev is the Git revision whose stored profiles are used as base to show differences.
+	// It is only used when BaseDir is not set.
+	BaseRev string
+
+	// EnableSVG indicates whether SVG call graphs should be generated.
+	// Note that this requires the "dot" command of Graphviz to be available in the executable search path.
+	EnableSVG bool
+
+	// Env is the environment for "pprof".
+	Env map[string]string
+
+	// NodeCount is the number of entries shown in text tables.
+	NodeCount int
+
+	// OutputDir is the output directory, relative to the project root, for reports.
+	OutputDir string
+
+	// ProfileDir is the directory the profiles are discovered in when no profiles are set explicitly.
+	ProfileDir string
+
+	// Profiles are the paths to the profiles to analyze.
+	Profiles []string
+
+	// SampleIndex is the sample value to report, e.g. "alloc_space" or "inuse_objects" for memory profiles.
+	SampleIndex string
+}
+
+// NewReportOptions creates new options for profile reports.
+func NewReportOptions(opts ...ReportOption) *ReportOptions {
+	opt := &ReportOptions{
+		Env:       make(map[string]string),
+		NodeCount: DefaultNodeCount,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithReportBaseDir sets the path to the directory with the profiles that are used as base to show differences.
+func WithReportBaseDir(dir string) ReportOption {
+	return func(o *ReportOptions) {
+		o.BaseDir = dir
+	}
+}
+
+// WithReportBaseRev sets the Git revision whose stored profiles are used as base to show differences, e.g. "HEAD~1"
+// to compare with the previous commit.
+func WithReportBaseRev(rev string) ReportOption {
+	return func(o *ReportOptions) {
+		o.BaseRev = rev
+	}
+}
+
+// WithReportEnv sets the environment for "pprof".
+func WithReportEnv(env map[string]string) ReportOption {
+	return func(o *ReportOptions) {
+		o.Env = env
+	}
+}
+
+// WithReportNodeCount sets the number of entries shown in text tables.
+// Defaults to DefaultNodeCount.
+func WithReportNodeCount(nodeCount int) ReportOption {
+	return func(o *ReportOptions) {
+		if nodeCount > 0 {
+			o.NodeCount = nodeCount
+		}
+	}
+}
+
+// WithReportOutputDir sets the output directory, relative to the project root, for reports.
+// Defaults to DefaultReportOutputDirName within the application specific output directory.
+func WithReportOutputDir(dir string) ReportOption {
+	return func(o *ReportOptions) {
+		o.OutputDir = dir
+	}
+}
+
+// WithReportProfileDir sets the directory the profiles are discovered in when no profiles are set explicitly.
+// Defaults to the test output directory within the application specific output directory.
+func WithReportProfileDir(dir string) ReportOption {
+	return func(o *ReportOptions) {
+		o.ProfileDir = dir
+	}
+}
+
+// WithReportProfiles sets the paths to the profiles to analyze.
+func WithReportProfiles(profiles ...string) ReportOption {
+	return func(o *ReportOptions) {
+		o.Profiles = append(o.Profiles, profiles...)
+	}
+}
+
+// WithReportSampleIndex sets the sample value to report, e.g. "alloc_space" or "inuse_objects" for memory profiles.
+func WithReportSampleIndex(sampleIndex string) ReportOption {
+	return func(o *ReportOptions) {
+		o.SampleIndex = sampleIndex
+	}
+}
+
+// WithReportSVG indicates whether SVG call graphs should be generated.
+func WithReportSVG(enableSVG bool) ReportOption {
+	return func(o *ReportOptions) {
+		o.EnableSVG = enableSVG
+	}
+}
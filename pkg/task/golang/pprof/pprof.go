@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package pprof provides a task for the Go toolchain "tool pprof" command to analyze profiles, e.g. those written by
+// the [github.com/svengreb/wand/pkg/task/golang/test] task, and to parse its output into typed reports.
+//
+// See `go tool pprof -help` and https://github.com/google/pprof/blob/main/doc/README.md for more details.
+package pprof
+
+import (
+	"fmt"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Task is a task for the Go toolchain "tool pprof" command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	params := []string{"tool", "pprof", fmt.Sprintf("-%s", t.opts.format)}
+
+	if t.opts.format == FormatTop {
+		params = append(params, fmt.Sprintf("-nodecount=%d", t.opts.nodeCount))
+	}
+
+	if t.opts.diffBase != "" {
+		params = append(params, fmt.Sprintf("-diff_base=%s", t.opts.diffBase))
+	}
+
+	if t.opts.sampleIndex != "" {
+		params = append(params, fmt.Sprintf("-sample_index=%s", t.opts.sampleIndex))
+	}
+
+	if t.opts.outputFile != "" {
+		params = append(params, fmt.Sprintf("-output=%s", t.opts.outputFile))
+	}
+
+	params = append(params, t.opts.flags...)
+
+	if t.opts.profile != "" {
+		params = append(params, t.opts.profile)
+	}
+
+	return append(params, t.opts.profiles...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// New creates a new task for the Go toolchain "tool pprof" command.
+func New(opts ...Option) *Task {
+	return &Task{opts: NewOptions(opts...)}
+}
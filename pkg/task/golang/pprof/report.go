@@ -0,0 +1,439 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pprof
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// UnitBytes is the base unit of memory profile values.
+	UnitBytes = "B"
+
+	// UnitCount is the base unit of profile values without a unit, e.g. object or contention counts.
+	UnitCount = ""
+
+	// UnitNanoseconds is the base unit of time profile values.
+	UnitNanoseconds = "ns"
+
+	// inlineSuffix is the suffix of function names that have been inlined.
+	inlineSuffix = " (inline)"
+
+	// summaryRowCount is the maximum number of entries of a profile shown in the summary.
+	summaryRowCount = 10
+
+	// topHeaderPrefix is the first column name of the table header of the "top" output format.
+	topHeaderPrefix = "flat"
+
+	// traceSeparatorPrefix is the prefix of lines that separate samples in the "traces" output format.
+	traceSeparatorPrefix = "-----------+"
+)
+
+// byteUnits are the units of memory values in ascending order where each unit is 1024 times the previous one.
+var byteUnits = []string{"B", "kB", "MB", "GB", "TB", "PB"}
+
+// timeUnits maps units of time values to their factor in nanoseconds.
+var timeUnits = map[string]float64{
+	"ns": 1, "us": 1e3, "µs": 1e3, "ms": 1e6, "s": 1e9,
+	"min": 60e9, "mins": 60e9, "hr": 3600e9, "hrs": 3600e9, "day": 86400e9, "days": 86400e9,
+}
+
+// ProfileReport is the report of a single profile.
+type ProfileReport struct {
+	// Diff is the text table of the top differences compared to the base profile, if any.
+	Diff *Top
+
+	// DiffBase is the path to the base profile the differences are computed with, if any.
+	DiffBase string
+
+	// DiffFile is the path to the file of the text table of the top differences, if any.
+	DiffFile string
+
+	// FoldedFile is the path to the file of the folded stacks that are compatible with flame graph tools.
+	FoldedFile string
+
+	// Profile is the path to the profile.
+	Profile string
+
+	// SVGFile is the path to the file of the SVG call graph, if any.
+	SVGFile string
+
+	// Top is the text table of the top entries.
+	Top *Top
+
+	// TopFile is the path to the file of the text table of the top entries.
+	TopFile string
+}
+
+// Report is the report of multiple profiles.
+type Report struct {
+	// Profiles are the reports of all analyzed profiles.
+	Profiles []ProfileReport
+
+	// SummaryFile is the path to the file of the text summary.
+	SummaryFile string
+}
+
+// String returns a human-readable text summary of all profiles with their top entries and differences.
+func (r *Report) String() string {
+	var buf bytes.Buffer
+	for i, p := range r.Profiles {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%s (%s, total %s)\n",
+			filepath.Base(p.Profile), p.Top.Type, FormatValue(p.Top.Total, p.Top.Unit),
+		)
+		writeRows(&buf, p.Top.Rows, p.Top.Unit, false)
+
+		if p.Diff == nil {
+			continue
+		}
+		fmt.Fprintf(&buf, "Hot path changes compared to %s:\n", p.DiffBase)
+		rows := p.Diff.Changes()
+		if len(rows) == 0 {
+			buf.WriteString("  no changes\n")
+			continue
+		}
+		writeRows(&buf, rows, p.Diff.Unit, true)
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// Top is a text table of the top entries of a profile.
+type Top struct {
+	// Header are the header lines, e.g. the profile type and duration.
+	Header []string
+
+	// Rows are all entries in the order they have been reported.
+	Rows []TopRow
+
+	// Total is the total value of the profile in Unit.
+	Total float64
+
+	// Type is the type of the profile, e.g. "cpu", "alloc_space" or "delay".
+	Type string
+
+	// Unit is the base unit of all values, e.g. UnitNanoseconds or UnitBytes.
+	Unit string
+}
+
+// Changes returns all rows with a non-zero value sorted by the absolute flat and cumulative value in descending
+// order, e.g. to highlight the largest differences of a diff table.
+func (t *Top) Changes() []TopRow {
+	var rows []TopRow
+	for _, r := range t.Rows {
+		if r.Flat != 0 || r.Cum != 0 {
+			rows = append(rows, r)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if math.Abs(rows[i].Flat) != math.Abs(rows[j].Flat) {
+			return math.Abs(rows[i].Flat) > math.Abs(rows[j].Flat)
+		}
+		return math.Abs(rows[i].Cum) > math.Abs(rows[j].Cum)
+	})
+	return rows
+}
+
+// TopRow is an entry of a text table of the top entries of a profile.
+type TopRow struct {
+	// Cum is the cumulative value of the function and all functions it called.
+	Cum float64
+
+	// CumPercent is the percentage of the cumulative value of the total value.
+	CumPercent float64
+
+	// Flat is the value of the function itself.
+	Flat float64
+
+	// FlatPercent is the percentage of the flat value of the total value.
+	FlatPercent float64
+
+	// Inline indicates whether the function has been inlined.
+	Inline bool
+
+	// Name is the name of the function.
+	Name string
+
+	// SumPercent is the sum of the flat percentages of this and all previous entries.
+	SumPercent float64
+}
+
+// Trace is a sample with its stack trace.
+type Trace struct {
+	// Stack are the function names of the stack trace starting with the leaf function.
+	Stack []string
+
+	// Unit is the base unit of the value, e.g. UnitNanoseconds or UnitBytes.
+	Unit string
+
+	// Value is the value of the sample in Unit.
+	Value float64
+}
+
+// FormatValue returns the human-readable representation of the given value in the given base unit.
+func FormatValue(v float64, unit string) string {
+	abs := math.Abs(v)
+	switch unit {
+	case UnitNanoseconds:
+		for _, u := range []struct {
+			name   string
+			factor float64
+		}{{"s", 1e9}, {"ms", 1e6}, {"us", 1e3}} {
+			if abs >= u.factor {
+				return formatFloat(v/u.factor) + u.name
+			}
+		}
+		return formatFloat(v) + UnitNanoseconds
+	case UnitBytes:
+		i := 0
+		for abs >= 1024 && i < len(byteUnits)-1 {
+			abs /= 1024
+			v /= 1024
+			i++
+		}
+		return formatFloat(v) + byteUnits[i]
+	default:
+		return formatFloat(v)
+	}
+}
+
+// ParseTop parses the output of the "top" format into a text table.
+func ParseTop(r io.Reader) (*Top, error) {
+	t := &Top{}
+	inTable := false
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if !inTable {
+			if fields[0] == topHeaderPrefix {
+				inTable = true
+				continue
+			}
+			t.Header = append(t.Header, line)
+			if strings.HasPrefix(line, "Type: ") {
+				t.Type = strings.TrimSpace(strings.TrimPrefix(line, "Type: "))
+			}
+			if err := parseTotal(line, t); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		row, rowErr := parseTopRow(line, fields)
+		if rowErr != nil {
+			return nil, rowErr
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read top entries: %w", err)
+	}
+	return t, nil
+}
+
+// ParseTraces parses the output of the "traces" format into samples with their stack traces.
+func ParseTraces(r io.Reader) ([]Trace, error) {
+	var traces []Trace
+	var current *Trace
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, traceSeparatorPrefix) {
+			if current != nil && len(current.Stack) > 0 {
+				traces = append(traces, *current)
+			}
+			current = &Trace{}
+			continue
+		}
+		// Lines before the first separator are header lines.
+		if current == nil {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// The first line of a sample starts with its value, optionally preceded by label lines like "bytes: 64B".
+		if len(current.Stack) == 0 {
+			if strings.HasSuffix(fields[0], ":") {
+				continue
+			}
+			v, unit, err := ParseValue(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			current.Value, current.Unit = v, unit
+			line = strings.Join(fields[1:], " ")
+		}
+		current.Stack = append(current.Stack, strings.TrimSuffix(strings.TrimSpace(line), inlineSuffix))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read traces: %w", err)
+	}
+	return traces, nil
+}
+
+// ParseValue parses a profile value with an optional unit, e.g. "300ms" or "55510.19kB", into the value in its base
+// unit.
+func ParseValue(s string) (float64, string, error) {
+	end := 0
+	for end < len(s) && strings.ContainsRune("+-.0123456789", rune(s[end])) {
+		end++
+	}
+	v, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("parse value %q: %w", s, err)
+	}
+
+	unit := s[end:]
+	if unit == "" {
+		return v, UnitCount, nil
+	}
+	if factor, ok := timeUnits[unit]; ok {
+		return v * factor, UnitNanoseconds, nil
+	}
+	factor := 1.0
+	for _, u := range byteUnits {
+		if u == unit {
+			return v * factor, UnitBytes, nil
+		}
+		factor *= 1024
+	}
+	return 0, "", fmt.Errorf("parse value %q: unknown unit %q", s, unit)
+}
+
+// WriteFolded writes the given traces as folded stacks where each line consists of the semicolon separated function
+// names, starting with the root function, and the value in its base unit. This format is supported by flame graph
+// tools like https://github.com/brendangregg/FlameGraph and https://www.speedscope.app.
+// Identical stacks are merged and values are rounded to integers.
+func WriteFolded(w io.Writer, traces []Trace) error {
+	values := make(map[string]float64)
+	for _, t := range traces {
+		frames := make([]string, len(t.Stack))
+		for i, f := range t.Stack {
+			frames[len(t.Stack)-1-i] = strings.ReplaceAll(f, ";", ":")
+		}
+		values[strings.Join(frames, ";")] += t.Value
+	}
+
+	stacks := make([]string, 0, len(values))
+	for s := range values {
+		stacks = append(stacks, s)
+	}
+	sort.Strings(stacks)
+
+	bw := bufio.NewWriter(w)
+	for _, s := range stacks {
+		if _, err := fmt.Fprintf(bw, "%s %d\n", s, int64(math.Round(values[s]))); err != nil {
+			return fmt.Errorf("write folded stacks: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write folded stacks: %w", err)
+	}
+	return nil
+}
+
+// formatFloat formats the given value with up to two decimal places.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// parsePercent parses a percentage like "93.75%".
+func parsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse percentage %q: %w", s, err)
+	}
+	return v, nil
+}
+
+// parseTopRow parses a row of the "top" format.
+func parseTopRow(line string, fields []string) (TopRow, error) {
+	const valueColumns = 5
+	if len(fields) <= valueColumns {
+		return TopRow{}, fmt.Errorf("parse top entry %q: expected at least %d columns", line, valueColumns+1)
+	}
+
+	var row TopRow
+	var err error
+	if row.Flat, _, err = ParseValue(fields[0]); err != nil {
+		return row, err
+	}
+	if row.FlatPercent, err = parsePercent(fields[1]); err != nil {
+		return row, err
+	}
+	if row.SumPercent, err = parsePercent(fields[2]); err != nil {
+		return row, err
+	}
+	if row.Cum, _, err = ParseValue(fields[3]); err != nil {
+		return row, err
+	}
+	if row.CumPercent, err = parsePercent(fields[4]); err != nil {
+		return row, err
+	}
+	row.Name = strings.Join(fields[valueColumns:], " ")
+	if strings.HasSuffix(row.Name, inlineSuffix) {
+		row.Name = strings.TrimSuffix(row.Name, inlineSuffix)
+		row.Inline = true
+	}
+	return row, nil
+}
+
+// parseTotal parses the total value from a header line like "Showing nodes accounting for 320ms, 100% of 320ms total"
+// into the given text table. Other lines are ignored.
+func parseTotal(line string, t *Top) error {
+	if !strings.HasPrefix(line, "Showing nodes accounting for") || !strings.HasSuffix(line, " total") {
+		return nil
+	}
+	fields := strings.Fields(line)
+	total, unit, err := ParseValue(fields[len(fields)-2])
+	if err != nil {
+		return err
+	}
+	t.Total, t.Unit = total, unit
+	return nil
+}
+
+// writeRows writes the given text table rows in aligned columns to the given buffer.
+func writeRows(buf *bytes.Buffer, rows []TopRow, unit string, signed bool) {
+	if len(rows) > summaryRowCount {
+		rows = rows[:summaryRowCount]
+	}
+	format := func(v float64) string {
+		s := FormatValue(v, unit)
+		if signed && v > 0 {
+			return "+" + s
+		}
+		return s
+	}
+
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(tw, "  flat\tflat%%\tcum\tcum%%\t\n")
+	for _, r := range rows {
+		_, _ = fmt.Fprintf(tw, "  %s\t%.2f%%\t%s\t%.2f%%\t  %s\n",
+			format(r.Flat), r.FlatPercent, format(r.Cum), r.CumPercent, r.Name,
+		)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package pprof
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		v       float64
+		unit    string
+		wantErr bool
+	}{
+		{name: "count", value: "42", v: 42, unit: UnitCount},
+		{name: "nanoseconds", value: "120ns", v: 120, unit: UnitNanoseconds},
+		{name: "milliseconds", value: "300ms", v: 300e6, unit: UnitNanoseconds},
+		{name: "minutes", value: "1.5mins", v: 90e9, unit: UnitNanoseconds},
+		{name: "bytes", value: "512B", v: 512, unit: UnitBytes},
+		{name: "kilobytes", value: "1.5kB", v: 1536, unit: UnitBytes},
+		{name: "megabytes", value: "2MB", v: 2 * 1024 * 1024, unit: UnitBytes},
+		{name: "negative", value: "-10ms", v: -10e6, unit: UnitNanoseconds},
+		{name: "unknown unit", value: "10parsecs", wantErr: true},
+		{name: "missing number", value: "ms", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, unit, err := ParseValue(tc.value)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tc.v, v, 1e-6)
+			require.Equal(t, tc.unit, unit)
+		})
+	}
+}
+
+func TestParseTop(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		top     *Top
+		wantErr bool
+	}{
+		{
+			name: "cpu profile",
+			output: strings.Join([]string{
+				"File: app.test",
+				"Type: cpu",
+				"Showing nodes accounting for 320ms, 100% of 320ms total",
+				"      flat  flat%   sum%        cum   cum%",
+				"     200ms 62.50% 62.50%      300ms 93.75%  main.encode",
+				"     120ms 37.50%   100%      120ms 37.50%  strings.(*Builder).WriteString (inline)",
+			}, "\n"),
+			top: &Top{
+				Header: []string{
+					"File: app.test",
+					"Type: cpu",
+					"Showing nodes accounting for 320ms, 100% of 320ms total",
+				},
+				Rows: []TopRow{
+					{Cum: 300e6, CumPercent: 93.75, Flat: 200e6, FlatPercent: 62.5, Name: "main.encode", SumPercent: 62.5},
+					{
+						Cum:         120e6,
+						CumPercent:  37.5,
+						Flat:        120e6,
+						FlatPercent: 37.5,
+						Inline:      true,
+						Name:        "strings.(*Builder).WriteString",
+						SumPercent:  100,
+					},
+				},
+				Total: 320e6,
+				Type:  "cpu",
+				Unit:  UnitNanoseconds,
+			},
+		},
+		{
+			name: "missing columns",
+			output: strings.Join([]string{
+				"      flat  flat%   sum%        cum   cum%",
+				"     200ms 62.50% 62.50%      300ms",
+			}, "\n"),
+			wantErr: true,
+		},
+		{
+			name: "invalid percentage",
+			output: strings.Join([]string{
+				"      flat  flat%   sum%        cum   cum%",
+				"     200ms many 62.50%      300ms 93.75%  main.encode",
+			}, "\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			top, err := ParseTop(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.top, top)
+		})
+	}
+}
+
+func TestParseTraces(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		traces  []Trace
+		wantErr bool
+	}{
+		{
+			name: "cpu samples",
+			output: strings.Join([]string{
+				"File: app.test",
+				"Type: cpu",
+				"-----------+-------------------------------------------------------",
+				"      10ms   main.encode",
+				"             main.run (inline)",
+				"             main.main",
+				"-----------+-------------------------------------------------------",
+				"      20ms   main.decode",
+				"             main.main",
+				"-----------+-------------------------------------------------------",
+			}, "\n"),
+			traces: []Trace{
+				{Stack: []string{"main.encode", "main.run", "main.main"}, Unit: UnitNanoseconds, Value: 10e6},
+				{Stack: []string{"main.decode", "main.main"}, Unit: UnitNanoseconds, Value: 20e6},
+			},
+		},
+		{
+			name: "samples with labels",
+			output: strings.Join([]string{
+				"-----------+-------------------------------------------------------",
+				"     bytes:  64B",
+				"      64B   main.alloc",
+				"-----------+-------------------------------------------------------",
+			}, "\n"),
+			traces: []Trace{{Stack: []string{"main.alloc"}, Unit: UnitBytes, Value: 64}},
+		},
+		{
+			name: "invalid value",
+			output: strings.Join([]string{
+				"-----------+-------------------------------------------------------",
+				"      10parsecs   main.encode",
+			}, "\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			traces, err := ParseTraces(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.traces, traces)
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []struct {
+		name   string
+		traces []Trace
+		folded string
+	}{
+		{
+			name: "merged stacks",
+			traces: []Trace{
+				{Stack: []string{"main.encode", "main.main"}, Value: 10.4},
+				{Stack: []string{"main.decode", "main.main"}, Value: 20},
+				{Stack: []string{"main.encode", "main.main"}, Value: 5.2},
+			},
+			folded: "main.main;main.decode 20\nmain.main;main.encode 16\n",
+		},
+		{
+			name:   "semicolons in function names",
+			traces: []Trace{{Stack: []string{"pkg.f;g"}, Value: 1}},
+			folded: "pkg.f:g 1\n",
+		},
+		{name: "no traces", folded: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteFolded(&buf, tc.traces))
+			require.Equal(t, tc.folded, buf.String())
+		})
+	}
+}
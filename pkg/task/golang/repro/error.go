@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package repro
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrNotReproducible indicates that the builds of a binary artifact are not bit-for-bit identical.
+const ErrNotReproducible = wErr.ErrString("build not reproducible")
@@ -0,0 +1,123 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package repro
+
+import (
+	"time"
+
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+)
+
+const (
+	// DefaultEnvVarSourceDateEpoch is the default environment variable name for the timestamp build tools should use
+	// instead of the current time.
+	//
+	// See https://reproducible-builds.org/specs/source-date-epoch for more details.
+	DefaultEnvVarSourceDateEpoch = "SOURCE_DATE_EPOCH"
+
+	// DefaultOutputDirName is the default output directory name for the binary artifacts of both builds.
+	DefaultOutputDirName = "repro"
+
+	// taskName is the name of the task.
+	taskName = "go/repro"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// BinaryArtifactName is the name for the binary build artifact.
+	BinaryArtifactName string
+
+	// BuildOptions are options of the Go toolchain "build" task that are applied to both builds.
+	BuildOptions []taskGoBuild.Option
+
+	// EnableBuildVCS indicates whether version control information should be stamped into the binary artifacts.
+	//
+	// See `go help build` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
+	EnableBuildVCS bool
+
+	// EnableIsolatedModCache indicates whether each build should also use its own module cache instead of the module
+	// cache of the current environment. Note that this requires to download all dependencies for each build.
+	EnableIsolatedModCache bool
+
+	// name is the task name.
+	name string
+
+	// OutputDir is the output directory, relative to the project root, for the binary artifacts of both builds.
+	// Each build stores its binary artifact in a numbered subdirectory that is kept for further inspection.
+	OutputDir string
+
+	// SourceDateEpoch is the timestamp that is passed to both builds through the DefaultEnvVarSourceDateEpoch environment
+	// variable for build tools, like code generators or cgo compilers, that would otherwise use the current time.
+	SourceDateEpoch time.Time
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		EnableBuildVCS:  true,
+		name:            taskName,
+		SourceDateEpoch: time.Unix(0, 0),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// WithBinaryArtifactName sets the name for the binary build artifact.
+func WithBinaryArtifactName(name string) Option {
+	return func(o *Options) {
+		o.BinaryArtifactName = name
+	}
+}
+
+// WithBuildOptions sets options of the Go toolchain "build" task that are applied to both builds.
+// Note that the output directory, the binary artifact name and the options required for reproducible builds are set by
+// this task and override the same options.
+func WithBuildOptions(buildOpts ...taskGoBuild.Option) Option {
+	return func(o *Options) {
+		o.BuildOptions = append(o.BuildOptions, buildOpts...)
+	}
+}
+
+// WithBuildVCS indicates whether version control information should be stamped into the binary artifacts.
+// Defaults to true.
+//
+// See `go help build` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
+func WithBuildVCS(enableBuildVCS bool) Option {
+	return func(o *Options) {
+		o.EnableBuildVCS = enableBuildVCS
+	}
+}
+
+// WithIsolatedModCache indicates whether each build should also use its own module cache instead of the module cache
+// of the current environment.
+func WithIsolatedModCache(enableIsolatedModCache bool) Option {
+	return func(o *Options) {
+		o.EnableIsolatedModCache = enableIsolatedModCache
+	}
+}
+
+// WithOutputDir sets the output directory, relative to the project root, for the binary artifacts of both builds.
+// Defaults to DefaultOutputDirName within the application specific output directory.
+func WithOutputDir(dir string) Option {
+	return func(o *Options) {
+		o.OutputDir = dir
+	}
+}
+
+// WithSourceDateEpoch sets the timestamp that is passed to both builds through the DefaultEnvVarSourceDateEpoch
+// environment variable.
+// Defaults to the Unix epoch.
+func WithSourceDateEpoch(t time.Time) Option {
+	return func(o *Options) {
+		o.SourceDateEpoch = t
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package repro
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Build is the binary artifact of a single build.
+type Build struct {
+	// Path is the path to the binary artifact.
+	Path string
+
+	// SHA256 is the hex encoded SHA-256 checksum of the binary artifact.
+	SHA256 string
+
+	// Size is the size of the binary artifact in bytes.
+	Size int64
+}
+
+// Report is the report of a reproducible build verification.
+type Report struct {
+	// Builds are the binary artifacts of both builds in the order they have been built.
+	Builds [2]Build
+
+	// ELF indicates whether the binary artifacts are ELF files whose sections have been compared.
+	ELF bool
+
+	// Sections are the sections that differ between both binary artifacts, sorted by name.
+	// It is empty when the binary artifacts are identical or not ELF files.
+	Sections []SectionDiff
+}
+
+// Reproducible indicates whether the binary artifacts of both builds are bit-for-bit identical.
+func (r *Report) Reproducible() bool {
+	return r.Builds[0].SHA256 == r.Builds[1].SHA256
+}
+
+// SectionDiff is a ELF section that differs between the binary artifacts of both builds.
+type SectionDiff struct {
+	// Name is the section name, e.g. ".text" or ".go.buildinfo".
+	Name string
+
+	// SHA256 are the hex encoded SHA-256 checksums of the section data of both builds.
+	// A checksum is empty when the binary artifact does not contain the section.
+	SHA256 [2]string
+
+	// Size are the sizes of the section data of both builds in bytes.
+	Size [2]uint64
+}
+
+// String returns a human-readable representation of the section difference.
+func (d SectionDiff) String() string {
+	switch {
+	case d.SHA256[0] == "":
+		return fmt.Sprintf("%s: only in second build (%d bytes)", d.Name, d.Size[1])
+	case d.SHA256[1] == "":
+		return fmt.Sprintf("%s: only in first build (%d bytes)", d.Name, d.Size[0])
+	}
+	return fmt.Sprintf(
+		"%s: %s (%d bytes) != %s (%d bytes)",
+		d.Name, d.SHA256[0][:12], d.Size[0], d.SHA256[1][:12], d.Size[1],
+	)
+}
+
+// section is the checksum and size of the data of a ELF section.
+type section struct {
+	sha256 string
+	size   uint64
+}
+
+// Compare compares the binary artifacts at the given paths.
+// When both artifacts differ and are ELF files, the sections are hashed individually to report which sections differ
+// which helps to track down the source of non-determinism, e.g. embedded paths in ".go.buildinfo" or timestamps in
+// ".rodata".
+func Compare(first, second string) (*Report, error) {
+	report := &Report{}
+	for i, path := range []string{first, second} {
+		b, err := newBuild(path)
+		if err != nil {
+			return nil, err
+		}
+		report.Builds[i] = *b
+	}
+	if report.Reproducible() {
+		return report, nil
+	}
+
+	firstSections, firstErr := elfSections(first)
+	secondSections, secondErr := elfSections(second)
+	var formatErr *elf.FormatError
+	if errors.As(firstErr, &formatErr) || errors.As(secondErr, &formatErr) {
+		return report, nil
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if secondErr != nil {
+		return nil, secondErr
+	}
+
+	report.ELF = true
+	for name, s := range firstSections {
+		o, ok := secondSections[name]
+		if ok && o.sha256 == s.sha256 {
+			continue
+		}
+		report.Sections = append(report.Sections, SectionDiff{
+			Name:   name,
+			SHA256: [2]string{s.sha256, o.sha256},
+			Size:   [2]uint64{s.size, o.size},
+		})
+	}
+	for name, o := range secondSections {
+		if _, ok := firstSections[name]; !ok {
+			report.Sections = append(report.Sections, SectionDiff{
+				Name:   name,
+				SHA256: [2]string{"", o.sha256},
+				Size:   [2]uint64{0, o.size},
+			})
+		}
+	}
+	sort.Slice(report.Sections, func(i, j int) bool { return report.Sections[i].Name < report.Sections[j].Name })
+
+	return report, nil
+}
+
+// elfSections returns the checksums and sizes of all sections with data of the ELF file at the given path mapped by
+// their name.
+// The returned error wraps a *elf.FormatError when the file is not a ELF file.
+func elfSections(path string) (map[string]section, error) {
+	f, openErr := elf.Open(path)
+	if openErr != nil {
+		return nil, fmt.Errorf("open ELF file %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	sections := make(map[string]section, len(f.Sections))
+	for _, s := range f.Sections {
+		if s.Type == elf.SHT_NULL || s.Type == elf.SHT_NOBITS {
+			continue
+		}
+		h := sha256.New()
+		size, err := io.Copy(h, s.Open())
+		if err != nil {
+			return nil, fmt.Errorf("read section %q of %q: %w", s.Name, path, err)
+		}
+		sections[s.Name] = section{sha256: hex.EncodeToString(h.Sum(nil)), size: uint64(size)}
+	}
+	return sections, nil
+}
+
+// newBuild returns the build of the binary artifact at the given path.
+func newBuild(path string) (*Build, error) {
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return nil, fmt.Errorf("open binary artifact %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, fmt.Errorf("compute checksum of binary artifact %q: %w", path, err)
+	}
+	return &Build{Path: path, SHA256: hex.EncodeToString(h.Sum(nil)), Size: size}, nil
+}
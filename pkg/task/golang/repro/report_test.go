@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package repro
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name         string
+		first        []byte
+		second       []byte
+		reproducible bool
+	}{
+		{name: "identical", first: []byte("wand"), second: []byte("wand"), reproducible: true},
+		{name: "different content", first: []byte("wand"), second: []byte("elder")},
+		{name: "empty", first: []byte{}, second: []byte{}, reproducible: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			first, second := filepath.Join(dir, "first"), filepath.Join(dir, "second")
+			require.NoError(t, os.WriteFile(first, tc.first, 0o600))
+			require.NoError(t, os.WriteFile(second, tc.second, 0o600))
+
+			report, err := Compare(first, second)
+			require.NoError(t, err)
+			require.Equal(t, tc.reproducible, report.Reproducible())
+			require.Equal(t, first, report.Builds[0].Path)
+			require.Equal(t, second, report.Builds[1].Path)
+			require.Equal(t, int64(len(tc.first)), report.Builds[0].Size)
+			require.Equal(t, int64(len(tc.second)), report.Builds[1].Size)
+			require.False(t, report.ELF, "non-ELF files must not be compared by sections")
+			require.Empty(t, report.Sections)
+		})
+	}
+}
+
+func TestCompareMissingArtifact(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	require.NoError(t, os.WriteFile(first, []byte("wand"), 0o600))
+
+	_, err := Compare(first, filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestCompareELFSections(t *testing.T) {
+	exe, exeErr := os.Executable()
+	require.NoError(t, exeErr)
+	data, readErr := os.ReadFile(exe)
+	require.NoError(t, readErr)
+
+	f, openErr := elf.Open(exe)
+	if openErr != nil {
+		t.Skipf("test binary is not a ELF file: %v", openErr)
+	}
+	rodata := f.Section(".rodata")
+	require.NoError(t, f.Close())
+	if rodata == nil || rodata.Type != elf.SHT_PROGBITS || rodata.Size == 0 {
+		t.Skip(`test binary has no ".rodata" section with data`)
+	}
+
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first"), filepath.Join(dir, "second")
+	require.NoError(t, os.WriteFile(first, data, 0o600))
+	modified := append([]byte(nil), data...)
+	modified[rodata.Offset] ^= 0xff
+	require.NoError(t, os.WriteFile(second, modified, 0o600))
+
+	report, err := Compare(first, second)
+	require.NoError(t, err)
+	require.False(t, report.Reproducible())
+	require.True(t, report.ELF)
+	require.Len(t, report.Sections, 1)
+	require.Equal(t, ".rodata", report.Sections[0].Name)
+	require.Equal(t, [2]uint64{rodata.Size, rodata.Size}, report.Sections[0].Size)
+	require.NotEqual(t, report.Sections[0].SHA256[0], report.Sections[0].SHA256[1])
+}
+
+func TestSectionDiffString(t *testing.T) {
+	first, second := "0123456789abcdef", "fedcba9876543210"
+	tests := []struct {
+		name string
+		diff SectionDiff
+		want string
+	}{
+		{
+			name: "different data",
+			diff: SectionDiff{Name: ".rodata", SHA256: [2]string{first, second}, Size: [2]uint64{10, 12}},
+			want: ".rodata: 0123456789ab (10 bytes) != fedcba987654 (12 bytes)",
+		},
+		{
+			name: "only in first build",
+			diff: SectionDiff{Name: ".note", SHA256: [2]string{first, ""}, Size: [2]uint64{8, 0}},
+			want: ".note: only in first build (8 bytes)",
+		},
+		{
+			name: "only in second build",
+			diff: SectionDiff{Name: ".note", SHA256: [2]string{"", second}, Size: [2]uint64{0, 4}},
+			want: ".note: only in second build (4 bytes)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.diff.String())
+		})
+	}
+}
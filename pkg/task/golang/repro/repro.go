@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package repro provides a task to verify that binary artifacts built with the Go toolchain "build" command are
+// reproducible.
+// The application is built twice in isolated environments and the resulting binary artifacts are compared
+// bit-for-bit. When they differ, the sections of ELF binary artifacts are compared individually to help tracking down
+// the source of non-determinism.
+//
+// See https://reproducible-builds.org and https://go.dev/blog/rebuild for more details.
+package repro
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	taskGoBuild "github.com/svengreb/wand/pkg/task/golang/build"
+)
+
+// Task is a task to verify that binary artifacts built with the Go toolchain "build" command are reproducible.
+type Task struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BuildTask returns the Go toolchain "build" task for the build with the given number, starting at 1.
+// The build uses the given directories as "GOPATH", "GOCACHE" and "GOMODCACHE" and stores the binary artifact in the
+// numbered subdirectory of the output directory.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+func (t *Task) BuildTask(n int, goPath, goCache, goModCache string) (*taskGoBuild.Task, error) {
+	env := map[string]string{
+		taskGo.DefaultEnvVarGOCACHE:    goCache,
+		taskGo.DefaultEnvVarGOMODCACHE: goModCache,
+		taskGo.DefaultEnvVarGOPATH:     goPath,
+		DefaultEnvVarSourceDateEpoch:   strconv.FormatInt(t.opts.SourceDateEpoch.Unix(), 10),
+	}
+	goOpts := []taskGo.Option{
+		taskGo.WithFlags(fmt.Sprintf("-buildvcs=%t", t.opts.EnableBuildVCS)),
+		taskGo.WithTrimmedPath(true),
+	}
+	if t.opts.EnableIsolatedModCache {
+		// Allow to remove the isolated module cache afterwards which is read-only by default.
+		goOpts = append(goOpts, taskGo.WithFlags("-modcacherw"))
+	}
+	goOpts = append(goOpts, taskGo.WithEnv(env))
+
+	opts := append([]taskGoBuild.Option{}, t.opts.BuildOptions...)
+	opts = append(opts,
+		taskGoBuild.WithBinaryArtifactName(t.opts.BinaryArtifactName),
+		taskGoBuild.WithGoOptions(goOpts...),
+		taskGoBuild.WithOutputDir(t.BuildOutputDir(n)),
+	)
+	return taskGoBuild.New(t.ac, opts...)
+}
+
+// BuildOutputDir returns the output directory, relative to the project root, for the build with the given number.
+func (t *Task) BuildOutputDir(n int) string {
+	return filepath.Join(t.opts.OutputDir, strconv.Itoa(n))
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// New creates a new task to verify that binary artifacts built with the Go toolchain "build" command are reproducible.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(ac app.Config, opts ...Option) *Task {
+	opt := NewOptions(opts...)
+
+	if opt.BinaryArtifactName == "" {
+		opt.BinaryArtifactName = ac.Name
+	}
+
+	if opt.OutputDir == "" {
+		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
+	}
+
+	return &Task{ac: ac, opts: opt}
+}
@@ -28,6 +28,8 @@ type Options struct {
 	goModule *project.GoModuleID
 	// name is the task name.
 	name string
+	// workingDir is the path to the working directory of the command.
+	workingDir string
 }
 
 // NewOptions creates new task options.
@@ -74,3 +76,11 @@ func WithModuleVersion(version *semver.Version) Option {
 		o.goModule.Version = version
 	}
 }
+
+// WithWorkingDir sets the path to the working directory of the command.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
@@ -64,6 +64,11 @@ func (t *Task) Options() task.Options {
 	return *t.opts
 }
 
+// WorkingDir returns the path to the working directory of the command.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
 // New creates a new task for the Go toolchain "run" command.
 func New(opts ...Option) *Task {
 	return &Task{opts: NewOptions(opts...)}
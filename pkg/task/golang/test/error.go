@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrFailed indicates that at least one test or package failed on every attempt.
+const ErrFailed = wErr.ErrString("tests failed")
@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"errors"
+	"fmt"
+
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+const (
+	// ErrCanceled indicates that the run was canceled, e.g. by an interrupt signal.
+	ErrCanceled = wErr.ErrString("canceled")
+
+	// ErrInvalidOpts indicates invalid fixture options.
+	ErrInvalidOpts = wErr.ErrString("invalid options")
+
+	// ErrNotReady indicates that a fixture did not become ready in time.
+	ErrNotReady = wErr.ErrString("not ready")
+
+	// ErrStart indicates that a fixture failed to start.
+	ErrStart = wErr.ErrString("failed to start")
+
+	// ErrStop indicates that a fixture failed to stop.
+	ErrStop = wErr.ErrString("failed to stop")
+)
+
+// ErrFixture represents a fixture error.
+type ErrFixture struct {
+	// Err is a wrapped error.
+	Err error
+	// Kind is the error kind.
+	Kind error
+	// Name is the name of the fixture.
+	Name string
+}
+
+func (e *ErrFixture) Error() string {
+	msg := fmt.Sprintf("fixture %q", e.Name)
+	if e.Kind != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Kind)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+
+	return msg
+}
+
+// Is enables usage of errors.Is() to determine the kind of error that occurred.
+func (e *ErrFixture) Is(err error) bool {
+	return errors.Is(err, e.Kind)
+}
+
+// Unwrap returns the underlying error for usage with errors.Unwrap().
+func (e *ErrFixture) Unwrap() error { return e.Err }
@@ -0,0 +1,233 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package fixture provides fixtures for integration tests like local helper processes, HTTP stubs and temporary
+// directories that are started before and torn down after the tests run.
+// The addresses and paths of started fixtures are passed to the tests through environment variables whose names are
+// built from the fixture name, e.g. "API_ADDR" and "API_URL" for a fixture named "api".
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultProbeInterval is the default interval between readiness checks.
+	DefaultProbeInterval = 100 * time.Millisecond
+
+	// DefaultReadyTimeout is the default maximum duration to wait for a fixture to become ready.
+	DefaultReadyTimeout = 30 * time.Second
+
+	// DefaultStopTimeout is the default maximum duration to wait for a process to exit gracefully before it is killed.
+	DefaultStopTimeout = 10 * time.Second
+
+	// EnvSuffixAddr is the suffix of the environment variable name for the network address of a fixture.
+	EnvSuffixAddr = "ADDR"
+
+	// EnvSuffixDir is the suffix of the environment variable name for the directory of a fixture.
+	EnvSuffixDir = "DIR"
+
+	// EnvSuffixURL is the suffix of the environment variable name for the base URL of a fixture.
+	EnvSuffixURL = "URL"
+)
+
+// Fixture is a resource integration tests depend on.
+type Fixture interface {
+	// Name returns the fixture name that is used to build the names of the environment variables for the tests.
+	Name() string
+
+	// Start starts the fixture and waits until it is ready.
+	// It returns the environment variables that are passed to the tests.
+	Start(ctx context.Context) (map[string]string, error)
+
+	// Stop stops the fixture and releases all of its resources.
+	// It must be safe to call even when the fixture has not been started or failed to start.
+	Stop() error
+}
+
+// Group is a group of fixtures that are started in order and stopped in reverse order.
+type Group struct {
+	fixtures []Fixture
+	started  []Fixture
+}
+
+// Run starts all fixtures, runs the given function with the environment variables of all fixtures and stops all
+// fixtures afterwards, even when the function failed or the given context has been canceled.
+// Interrupt and termination signals are handled while the fixtures are running so that they are stopped reliably
+// before the process exits. When the context has been canceled or a signal has been received before the function
+// returned, the context passed to the function is canceled and the fixtures are only stopped after the function
+// returned so that it never runs without its fixtures. An error of kind ErrCanceled is returned in this case.
+func (g *Group) Run(ctx context.Context, fn func(ctx context.Context, env map[string]string) error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	env, startErr := g.Start(ctx)
+	if startErr != nil {
+		return startErr
+	}
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- fn(fnCtx, env) }()
+
+	var runErr error
+	select {
+	case runErr = <-done:
+	case <-ctx.Done():
+		cancel()
+		<-done
+		runErr = fmt.Errorf("run with fixtures: %w", ErrCanceled)
+	}
+
+	if stopErr := g.Stop(); stopErr != nil {
+		if runErr != nil {
+			return fmt.Errorf("%w (%v)", runErr, stopErr)
+		}
+		return stopErr
+	}
+	return runErr
+}
+
+// Start starts all fixtures in order and returns the merged environment variables of all fixtures.
+// When any fixture fails to start all already started fixtures are stopped in reverse order.
+func (g *Group) Start(ctx context.Context) (map[string]string, error) {
+	names := make(map[string]bool, len(g.fixtures))
+	for _, f := range g.fixtures {
+		if names[f.Name()] {
+			return nil, &ErrFixture{Err: errors.New("duplicate fixture name"), Kind: ErrInvalidOpts, Name: f.Name()}
+		}
+		names[f.Name()] = true
+	}
+
+	env := make(map[string]string)
+	for _, f := range g.fixtures {
+		g.started = append(g.started, f)
+		fEnv, err := f.Start(ctx)
+		if err != nil {
+			if stopErr := g.Stop(); stopErr != nil {
+				return nil, fmt.Errorf("%w (%v)", err, stopErr)
+			}
+			return nil, err
+		}
+		for k, v := range fEnv {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+// Stop stops all started fixtures in reverse order.
+// All fixtures are stopped even when any of them fails to stop. The error of the first fixture that failed to stop is
+// returned along with the number of further failures.
+func (g *Group) Stop() error {
+	var errs []error
+	for i := len(g.started) - 1; i >= 0; i-- {
+		if err := g.started[i].Stop(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	g.started = nil
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("%w (and %d more fixtures failed to stop)", errs[0], len(errs)-1)
+	}
+}
+
+// EnvName returns the name of the environment variable with the given suffix for the fixture with the given name.
+// The fixture name is converted to upper case and all characters that are not letters or digits are replaced with
+// underscores, e.g. "API_ADDR" for the fixture "api" and the suffix EnvSuffixAddr.
+func EnvName(fixtureName, suffix string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, fixtureName)
+	return name + "_" + suffix
+}
+
+// NewGroup creates a new group of the given fixtures.
+func NewGroup(fixtures ...Fixture) *Group {
+	return &Group{fixtures: fixtures}
+}
+
+// FreeAddr returns a TCP address on the given host with a port that is currently not in use.
+func FreeAddr(host string) (string, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return "", fmt.Errorf("find free port: %w", err)
+	}
+	addr := l.Addr().String()
+	if err := l.Close(); err != nil {
+		return "", fmt.Errorf("find free port: %w", err)
+	}
+	return addr, nil
+}
+
+// WaitHTTP waits until a HTTP "GET" request to the given URL responds with a status code less than 500.
+// It returns an error of kind ErrNotReady when the given context is done before.
+func WaitHTTP(ctx context.Context, url string, interval time.Duration) error {
+	client := &http.Client{Timeout: interval * 10}
+	return wait(ctx, interval, func() error {
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if reqErr != nil {
+			return reqErr
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status %q", resp.Status)
+		}
+		return nil
+	})
+}
+
+// WaitTCP waits until a TCP connection to the given address can be established.
+// It returns an error of kind ErrNotReady when the given context is done before.
+func WaitTCP(ctx context.Context, addr string, interval time.Duration) error {
+	var d net.Dialer
+	return wait(ctx, interval, func() error {
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
+
+// wait runs the given check in the given interval until it succeeds or the given context is done.
+func wait(ctx context.Context, interval time.Duration, check func() error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w in time, last check failed: %v", ErrNotReady, err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a fixture that records the order of start and stop calls.
+type recorder struct {
+	calls    *[]string
+	name     string
+	startErr error
+}
+
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Start(context.Context) (map[string]string, error) {
+	*r.calls = append(*r.calls, "start "+r.name)
+	if r.startErr != nil {
+		return nil, r.startErr
+	}
+	return map[string]string{EnvName(r.name, EnvSuffixAddr): r.name + ":0"}, nil
+}
+
+func (r *recorder) Stop() error {
+	*r.calls = append(*r.calls, "stop "+r.name)
+	return nil
+}
+
+func TestGroupRun(t *testing.T) {
+	errRun := errors.New("tests failed")
+	errStart := errors.New("port in use")
+
+	tests := []struct {
+		name     string
+		startErr error
+		fnErr    error
+		calls    []string
+		wantErr  error
+	}{
+		{
+			name:  "teardown in reverse order",
+			calls: []string{"start db", "start api", "run", "stop api", "stop db"},
+		},
+		{
+			name:    "teardown when function fails",
+			fnErr:   errRun,
+			calls:   []string{"start db", "start api", "run", "stop api", "stop db"},
+			wantErr: errRun,
+		},
+		{
+			name:     "teardown of started fixtures when start fails",
+			startErr: errStart,
+			calls:    []string{"start db", "start api", "stop api", "stop db"},
+			wantErr:  errStart,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			g := NewGroup(&recorder{calls: &calls, name: "db"}, &recorder{calls: &calls, name: "api", startErr: tc.startErr})
+			err := g.Run(context.Background(), func(_ context.Context, env map[string]string) error {
+				require.Equal(t, map[string]string{"DB_ADDR": "db:0", "API_ADDR": "api:0"}, env)
+				calls = append(calls, "run")
+				return tc.fnErr
+			})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.calls, calls)
+		})
+	}
+}
+
+func TestGroupRunCanceled(t *testing.T) {
+	var calls []string
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGroup(&recorder{calls: &calls, name: "api"})
+
+	err := g.Run(ctx, func(fnCtx context.Context, _ map[string]string) error {
+		cancel()
+		<-fnCtx.Done()
+		// The fixtures must still be running until the function returned.
+		calls = append(calls, "run canceled")
+		return fnCtx.Err()
+	})
+	require.ErrorIs(t, err, ErrCanceled)
+	require.Equal(t, []string{"start api", "run canceled", "stop api"}, calls)
+}
+
+func TestGroupStartDuplicateNames(t *testing.T) {
+	var calls []string
+	_, err := NewGroup(&recorder{calls: &calls, name: "api"}, &recorder{calls: &calls, name: "api"}).
+		Start(context.Background())
+	require.ErrorIs(t, err, ErrInvalidOpts)
+	require.Empty(t, calls)
+}
+
+func TestWaitTCP(t *testing.T) {
+	l, err := net.Listen("tcp", net.JoinHostPort(DefaultHost, "0"))
+	require.NoError(t, err)
+	addr := l.Addr().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, WaitTCP(ctx, addr, 10*time.Millisecond))
+
+	require.NoError(t, l.Close())
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, WaitTCP(ctx, addr, 10*time.Millisecond), ErrNotReady)
+}
+
+func TestWaitHTTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			err := WaitHTTP(ctx, srv.URL, 10*time.Millisecond)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrNotReady)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBuiltProcessBuildError(t *testing.T) {
+	errBuild := errors.New("compile error")
+	p := NewBuiltProcess("app", func() (string, error) { return "", errBuild })
+
+	_, err := p.Start(context.Background())
+	require.ErrorIs(t, err, ErrStart)
+	require.ErrorIs(t, err, errBuild)
+	require.NoError(t, p.Stop())
+}
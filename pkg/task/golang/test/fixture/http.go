@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// HTTPStub is a fixture for a local HTTP server that is run within the current process, e.g. to stub external APIs.
+// The network address and base URL are passed to the tests in the environment variables with the EnvSuffixAddr and
+// EnvSuffixURL suffixes.
+type HTTPStub struct {
+	handler http.Handler
+	name    string
+	server  *http.Server
+}
+
+// Name returns the fixture name.
+func (s *HTTPStub) Name() string {
+	return s.name
+}
+
+// Start starts the HTTP server on a free port.
+// The server is ready as soon as this method returns since the port is already listening.
+func (s *HTTPStub) Start(_ context.Context) (map[string]string, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(DefaultHost, "0"))
+	if err != nil {
+		return nil, &ErrFixture{Err: fmt.Errorf("listen: %w", err), Kind: ErrStart, Name: s.name}
+	}
+
+	s.server = &http.Server{Handler: s.handler, ReadHeaderTimeout: DefaultStopTimeout}
+	go func(srv *http.Server) {
+		if serveErr := srv.Serve(l); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			_ = srv.Close()
+		}
+	}(s.server)
+
+	addr := l.Addr().String()
+	return map[string]string{
+		EnvName(s.name, EnvSuffixAddr): addr,
+		EnvName(s.name, EnvSuffixURL):  "http://" + addr,
+	}, nil
+}
+
+// Stop shuts the HTTP server down gracefully and closes it when active connections are not finished within the
+// DefaultStopTimeout.
+func (s *HTTPStub) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	defer func() { s.server = nil }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultStopTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		if closeErr := s.server.Close(); closeErr != nil {
+			return &ErrFixture{Err: fmt.Errorf("close HTTP server: %w", closeErr), Kind: ErrStop, Name: s.name}
+		}
+	}
+	return nil
+}
+
+// Response is a static HTTP response.
+type Response struct {
+	// Body is the response body.
+	Body string
+
+	// Header are the response header fields.
+	Header map[string]string
+
+	// Status is the response status code.
+	// Defaults to http.StatusOK when zero.
+	Status int
+}
+
+// NewHTTPStub creates a new fixture for a local HTTP server with the given handler.
+func NewHTTPStub(name string, handler http.Handler) *HTTPStub {
+	return &HTTPStub{handler: handler, name: name}
+}
+
+// StaticHandler returns a HTTP handler that responds with the given static responses mapped by their path.
+// The paths are matched like patterns of http.ServeMux, e.g. "/api/" matches all paths within "/api/".
+func StaticHandler(routes map[string]Response) http.Handler {
+	mux := http.NewServeMux()
+	for path, resp := range routes {
+		resp := resp
+		mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			for k, v := range resp.Header {
+				w.Header().Set(k, v)
+			}
+			status := resp.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(resp.Body))
+		})
+	}
+	return mux
+}
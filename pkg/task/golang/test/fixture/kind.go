@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProbeNameHTTP is the Probe name for HTTP requests.
+	ProbeNameHTTP = "http"
+	// ProbeNameNone is the Probe name for no readiness check.
+	ProbeNameNone = "none"
+	// ProbeNameTCP is the Probe name for TCP connections.
+	ProbeNameTCP = "tcp"
+	// ProbeNameUnknown is the name for a unknown Probe.
+	ProbeNameUnknown = "unknown"
+)
+
+const (
+	// ProbeTCP is the Probe that waits until a TCP connection to the address can be established.
+	ProbeTCP Probe = iota
+	// ProbeHTTP is the Probe that waits until a HTTP "GET" request responds with a status code less than 500.
+	ProbeHTTP
+	// ProbeNone is the Probe that considers a fixture to be ready as soon as it has been started.
+	ProbeNone
+)
+
+// Probe defines a readiness check of a fixture.
+type Probe uint32
+
+// MarshalText returns the textual representation of itself.
+func (p Probe) MarshalText() ([]byte, error) {
+	switch p {
+	case ProbeTCP:
+		return []byte(ProbeNameTCP), nil
+	case ProbeHTTP:
+		return []byte(ProbeNameHTTP), nil
+	case ProbeNone:
+		return []byte(ProbeNameNone), nil
+	}
+
+	return nil, fmt.Errorf("not a valid probe %d", p)
+}
+
+func (p Probe) String() string {
+	if b, err := p.MarshalText(); err == nil {
+		return string(b)
+	}
+	return ProbeNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (p *Probe) UnmarshalText(text []byte) error {
+	parsed, err := ParseProbe(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// ParseProbe takes a probe name and returns the Probe constant.
+func ParseProbe(name string) (Probe, error) {
+	switch strings.ToLower(name) {
+	case ProbeNameTCP:
+		return ProbeTCP, nil
+	case ProbeNameHTTP:
+		return ProbeHTTP, nil
+	case ProbeNameNone:
+		return ProbeNone, nil
+	}
+
+	var p Probe
+	return p, fmt.Errorf("not a valid probe: %q", name)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	// AddrPlaceholder is the placeholder in process arguments that is replaced with the network address of the process.
+	AddrPlaceholder = "{addr}"
+
+	// DefaultHost is the default host for network addresses of fixtures.
+	DefaultHost = "127.0.0.1"
+
+	// DefaultProbePath is the default path for HTTP readiness checks.
+	DefaultProbePath = "/"
+
+	// PortPlaceholder is the placeholder in process arguments that is replaced with the port of the process.
+	PortPlaceholder = "{port}"
+)
+
+// ProcessOption is a option for process fixtures.
+type ProcessOption func(*ProcessOptions)
+
+// ProcessOptions are options for process fixtures.
+type ProcessOptions struct {
+	// Addr is the network address the process listens on.
+	// When empty a free port on DefaultHost is used.
+	Addr string
+
+	// Args are the arguments for the process.
+	// The placeholders AddrPlaceholder and PortPlaceholder are replaced with the network address and port of the
+	// process.
+	Args []string
+
+	// Env is the additional environment for the process.
+	Env map[string]string
+
+	// Output is the writer for the standard output and error of the process.
+	Output io.Writer
+
+	// PortEnv is the name of the environment variable that is passed to the process with its port, e.g. "PORT".
+	PortEnv string
+
+	// Probe is the readiness check of the process.
+	Probe Probe
+
+	// ProbeInterval is the interval between readiness checks.
+	ProbeInterval time.Duration
+
+	// ProbePath is the path for HTTP readiness checks.
+	ProbePath string
+
+	// ReadyTimeout is the maximum duration to wait for the process to become ready.
+	ReadyTimeout time.Duration
+
+	// StopTimeout is the maximum duration to wait for the process to exit gracefully after an interrupt signal before
+	// it is killed.
+	StopTimeout time.Duration
+
+	// WorkingDir is the working directory of the process.
+	WorkingDir string
+}
+
+// NewProcessOptions creates new options for process fixtures.
+func NewProcessOptions(opts ...ProcessOption) *ProcessOptions {
+	opt := &ProcessOptions{
+		Env:           make(map[string]string),
+		Output:        os.Stderr,
+		Probe:         ProbeTCP,
+		ProbeInterval: DefaultProbeInterval,
+		ProbePath:     DefaultProbePath,
+		ReadyTimeout:  DefaultReadyTimeout,
+		StopTimeout:   DefaultStopTimeout,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithProcessAddr sets the network address the process listens on.
+// Defaults to a free port on DefaultHost.
+func WithProcessAddr(addr string) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.Addr = addr
+	}
+}
+
+// WithProcessArgs sets the arguments for the process.
+// The placeholders AddrPlaceholder and PortPlaceholder are replaced with the network address and port of the process.
+func WithProcessArgs(args ...string) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.Args = append(o.Args, args...)
+	}
+}
+
+// WithProcessEnv sets the additional environment for the process.
+func WithProcessEnv(env map[string]string) ProcessOption {
+	return func(o *ProcessOptions) {
+		for k, v := range env {
+			o.Env[k] = v
+		}
+	}
+}
+
+// WithProcessOutput sets the writer for the standard output and error of the process.
+// Defaults to os.Stderr.
+func WithProcessOutput(w io.Writer) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.Output = w
+	}
+}
+
+// WithProcessPortEnv sets the name of the environment variable that is passed to the process with its port.
+func WithProcessPortEnv(name string) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.PortEnv = name
+	}
+}
+
+// WithProcessProbe sets the readiness check of the process.
+// Defaults to ProbeTCP.
+func WithProcessProbe(probe Probe) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.Probe = probe
+	}
+}
+
+// WithProcessProbeInterval sets the interval between readiness checks.
+// Defaults to DefaultProbeInterval.
+func WithProcessProbeInterval(interval time.Duration) ProcessOption {
+	return func(o *ProcessOptions) {
+		if interval > 0 {
+			o.ProbeInterval = interval
+		}
+	}
+}
+
+// WithProcessProbePath sets the path for HTTP readiness checks.
+// Defaults to DefaultProbePath.
+func WithProcessProbePath(path string) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.ProbePath = path
+	}
+}
+
+// WithProcessReadyTimeout sets the maximum duration to wait for the process to become ready.
+// Defaults to DefaultReadyTimeout.
+func WithProcessReadyTimeout(timeout time.Duration) ProcessOption {
+	return func(o *ProcessOptions) {
+		if timeout > 0 {
+			o.ReadyTimeout = timeout
+		}
+	}
+}
+
+// WithProcessStopTimeout sets the maximum duration to wait for the process to exit gracefully after an interrupt
+// signal before it is killed.
+// Defaults to DefaultStopTimeout.
+func WithProcessStopTimeout(timeout time.Duration) ProcessOption {
+	return func(o *ProcessOptions) {
+		if timeout > 0 {
+			o.StopTimeout = timeout
+		}
+	}
+}
+
+// WithProcessWorkingDir sets the working directory of the process.
+func WithProcessWorkingDir(dir string) ProcessOption {
+	return func(o *ProcessOptions) {
+		o.WorkingDir = dir
+	}
+}
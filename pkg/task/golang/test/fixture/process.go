@@ -0,0 +1,184 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Process is a fixture for a local helper process, e.g. a built application executable, that listens on a network
+// address.
+// The network address and base URL are passed to the tests in the environment variables with the EnvSuffixAddr and
+// EnvSuffixURL suffixes.
+type Process struct {
+	build   func() (string, error)
+	cmd     *exec.Cmd
+	exec    string
+	exited  chan struct{}
+	name    string
+	opts    *ProcessOptions
+	waitErr error
+}
+
+// Name returns the fixture name.
+func (p *Process) Name() string {
+	return p.name
+}
+
+// Start starts the process and waits until it is ready.
+// The executable is built first when the fixture has been created with NewBuiltProcess.
+func (p *Process) Start(ctx context.Context) (map[string]string, error) {
+	if p.build != nil {
+		path, buildErr := p.build()
+		if buildErr != nil {
+			return nil, &ErrFixture{Err: fmt.Errorf("build executable: %w", buildErr), Kind: ErrStart, Name: p.name}
+		}
+		p.exec = path
+	}
+
+	addr := p.opts.Addr
+	if addr == "" {
+		var addrErr error
+		if addr, addrErr = FreeAddr(DefaultHost); addrErr != nil {
+			return nil, &ErrFixture{Err: addrErr, Kind: ErrStart, Name: p.name}
+		}
+	}
+	_, port, splitErr := net.SplitHostPort(addr)
+	if splitErr != nil {
+		return nil, &ErrFixture{Err: fmt.Errorf("parse address %q: %w", addr, splitErr), Kind: ErrInvalidOpts, Name: p.name}
+	}
+
+	args := make([]string, 0, len(p.opts.Args))
+	r := strings.NewReplacer(AddrPlaceholder, addr, PortPlaceholder, port)
+	for _, arg := range p.opts.Args {
+		args = append(args, r.Replace(arg))
+	}
+
+	cmd := exec.Command(p.exec, args...)
+	cmd.Dir = p.opts.WorkingDir
+	cmd.Stdout = p.opts.Output
+	cmd.Stderr = p.opts.Output
+	cmd.Env = os.Environ()
+	for k, v := range p.opts.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	if p.opts.PortEnv != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", p.opts.PortEnv, port))
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, &ErrFixture{Err: fmt.Errorf("run %q: %w", cmd.String(), err), Kind: ErrStart, Name: p.name}
+	}
+	p.cmd = cmd
+	p.exited = make(chan struct{})
+	go func() {
+		p.waitErr = cmd.Wait()
+		close(p.exited)
+	}()
+
+	if err := p.waitReady(ctx, addr); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		EnvName(p.name, EnvSuffixAddr): addr,
+		EnvName(p.name, EnvSuffixURL):  "http://" + addr,
+	}, nil
+}
+
+// Stop sends an interrupt signal to the process and kills it when it did not exit within the stop timeout.
+// The exit status of the process is not checked since processes commonly exit with a non-zero status when interrupted.
+func (p *Process) Stop() error {
+	if p.cmd == nil {
+		return nil
+	}
+	defer func() { p.cmd = nil }()
+
+	select {
+	case <-p.exited:
+		return nil
+	default:
+	}
+
+	// Interrupt signals are not supported on all platforms, e.g. Windows, so the process is killed right away.
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil {
+		return p.kill()
+	}
+	select {
+	case <-p.exited:
+		return nil
+	case <-time.After(p.opts.StopTimeout):
+		return p.kill()
+	}
+}
+
+func (p *Process) kill() error {
+	if err := p.cmd.Process.Kill(); err != nil {
+		select {
+		case <-p.exited:
+			return nil
+		default:
+			return &ErrFixture{Err: fmt.Errorf("kill process: %w", err), Kind: ErrStop, Name: p.name}
+		}
+	}
+	<-p.exited
+	return nil
+}
+
+// waitReady waits until the process is ready or has exited.
+func (p *Process) waitReady(ctx context.Context, addr string) error {
+	if p.opts.Probe == ProbeNone {
+		return nil
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, p.opts.ReadyTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.exited:
+			cancel()
+		case <-readyCtx.Done():
+		}
+	}()
+
+	var err error
+	switch p.opts.Probe {
+	case ProbeHTTP:
+		path := "/" + strings.TrimPrefix(p.opts.ProbePath, "/")
+		err = WaitHTTP(readyCtx, "http://"+addr+path, p.opts.ProbeInterval)
+	default:
+		err = WaitTCP(readyCtx, addr, p.opts.ProbeInterval)
+	}
+	if err == nil {
+		return nil
+	}
+
+	select {
+	case <-p.exited:
+		return &ErrFixture{
+			Err:  fmt.Errorf("process exited before it was ready: %v", p.waitErr),
+			Kind: ErrStart,
+			Name: p.name,
+		}
+	default:
+		return &ErrFixture{Err: err, Kind: ErrStart, Name: p.name}
+	}
+}
+
+// NewProcess creates a new fixture for the process of the given executable.
+func NewProcess(name, exec string, opts ...ProcessOption) *Process {
+	return &Process{exec: exec, name: name, opts: NewProcessOptions(opts...)}
+}
+
+// NewBuiltProcess creates a new fixture for the process of the executable that is built by the given function when the
+// fixture is started, e.g. the application executable built by the Go toolchain "build" command.
+// The function returns the path to the built executable.
+func NewBuiltProcess(name string, build func() (string, error), opts ...ProcessOption) *Process {
+	return &Process{build: build, name: name, opts: NewProcessOptions(opts...)}
+}
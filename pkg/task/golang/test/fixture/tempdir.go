@@ -0,0 +1,54 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package fixture
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// TempDir is a fixture for a temporary directory that is removed with all of its content when the fixture is stopped.
+// The path of the directory is passed to the tests in the environment variable with the EnvSuffixDir suffix.
+type TempDir struct {
+	dir  string
+	name string
+}
+
+// Dir returns the path of the temporary directory or an empty string when the fixture has not been started.
+func (d *TempDir) Dir() string {
+	return d.dir
+}
+
+// Name returns the fixture name.
+func (d *TempDir) Name() string {
+	return d.name
+}
+
+// Start creates the temporary directory.
+func (d *TempDir) Start(_ context.Context) (map[string]string, error) {
+	dir, err := os.MkdirTemp("", fmt.Sprintf("wand-fixture-%s-", d.name))
+	if err != nil {
+		return nil, &ErrFixture{Err: fmt.Errorf("create temporary directory: %w", err), Kind: ErrStart, Name: d.name}
+	}
+	d.dir = dir
+	return map[string]string{EnvName(d.name, EnvSuffixDir): dir}, nil
+}
+
+// Stop removes the temporary directory with all of its content.
+func (d *TempDir) Stop() error {
+	if d.dir == "" {
+		return nil
+	}
+	if err := os.RemoveAll(d.dir); err != nil {
+		return &ErrFixture{Err: fmt.Errorf("remove temporary directory %q: %w", d.dir, err), Kind: ErrStop, Name: d.name}
+	}
+	d.dir = ""
+	return nil
+}
+
+// NewTempDir creates a new fixture for a temporary directory.
+func NewTempDir(name string) *TempDir {
+	return &TempDir{name: name}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FlakyTest is a test that has been classified as flaky.
+type FlakyTest struct {
+	Test
+
+	// Count is the number of runs the test has been classified as flaky.
+	Count int `json:"count"`
+
+	// LastSeen is the time of the last run the test has been classified as flaky.
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// History is the test history that stores the durations of tests, used to balance shards, and the tests that have
+// been classified as flaky.
+type History struct {
+	// Durations are the durations of the last passed attempt of tests mapped by the test key.
+	Durations map[string]time.Duration `json:"durations"`
+
+	// Flaky are the tests that have been classified as flaky mapped by the test key.
+	Flaky map[string]FlakyTest `json:"flaky"`
+}
+
+// Save stores the history as JSON file at the given path and creates all parent directories.
+func (h *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(path), err)
+	}
+
+	data, marshalErr := json.MarshalIndent(h, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("encode test history: %w", marshalErr)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write test history %q: %w", path, err)
+	}
+	return nil
+}
+
+// Update updates the history with the results of the given report at the given time.
+// The durations of passed and flaky tests are replaced and flaky tests are recorded.
+func (h *History) Update(r *Report, now time.Time) {
+	for _, t := range r.Tests {
+		switch t.Status {
+		case StatusPass:
+			h.Durations[t.Key()] = t.Elapsed
+		case StatusFlaky:
+			h.Durations[t.Key()] = t.Elapsed
+			ft := h.Flaky[t.Key()]
+			ft.Test = t.Test
+			ft.Count++
+			ft.LastSeen = now
+			h.Flaky[t.Key()] = ft
+		}
+	}
+}
+
+// LoadHistory loads the history from the JSON file at the given path.
+// An empty history is returned when the file does not exist.
+func LoadHistory(path string) (*History, error) {
+	h := &History{Durations: make(map[string]time.Duration), Flaky: make(map[string]FlakyTest)}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		if errors.Is(readErr, os.ErrNotExist) {
+			return h, nil
+		}
+		return nil, fmt.Errorf("read test history %q: %w", path, readErr)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("decode test history %q: %w", path, err)
+	}
+	if h.Durations == nil {
+		h.Durations = make(map[string]time.Duration)
+	}
+	if h.Flaky == nil {
+		h.Flaky = make(map[string]FlakyTest)
+	}
+	return h, nil
+}
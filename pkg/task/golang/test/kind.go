@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// StatusNameFail is the Status name for tests that failed on every attempt.
+	StatusNameFail = "fail"
+	// StatusNameFlaky is the Status name for tests that failed at first but passed on a retry.
+	StatusNameFlaky = "flaky"
+	// StatusNamePass is the Status name for tests that passed on the first attempt.
+	StatusNamePass = "pass"
+	// StatusNameSkip is the Status name for skipped tests.
+	StatusNameSkip = "skip"
+	// StatusNameUnknown is the name for a unknown Status.
+	StatusNameUnknown = "unknown"
+)
+
+const (
+	// StatusPass is the Status for tests that passed on the first attempt.
+	StatusPass Status = iota
+	// StatusFail is the Status for tests that failed on every attempt.
+	StatusFail
+	// StatusFlaky is the Status for tests that failed at first but passed on a retry.
+	StatusFlaky
+	// StatusSkip is the Status for skipped tests.
+	StatusSkip
+)
+
+// Status defines the result status of a test.
+type Status uint32
+
+// MarshalText returns the textual representation of itself.
+func (s Status) MarshalText() ([]byte, error) {
+	switch s {
+	case StatusPass:
+		return []byte(StatusNamePass), nil
+	case StatusFail:
+		return []byte(StatusNameFail), nil
+	case StatusFlaky:
+		return []byte(StatusNameFlaky), nil
+	case StatusSkip:
+		return []byte(StatusNameSkip), nil
+	}
+
+	return nil, fmt.Errorf("not a valid status %d", s)
+}
+
+func (s Status) String() string {
+	if b, err := s.MarshalText(); err == nil {
+		return string(b)
+	}
+	return StatusNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (s *Status) UnmarshalText(text []byte) error {
+	parsed, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+	return nil
+}
+
+// ParseStatus takes a status name and returns the Status constant.
+func ParseStatus(name string) (Status, error) {
+	switch strings.ToLower(name) {
+	case StatusNamePass:
+		return StatusPass, nil
+	case StatusNameFail:
+		return StatusFail, nil
+	case StatusNameFlaky:
+		return StatusFlaky, nil
+	case StatusNameSkip:
+		return StatusSkip, nil
+	}
+
+	var s Status
+	return s, fmt.Errorf("not a valid status: %q", name)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+const (
+	// listPatternAll is the regular expression to list all tests, benchmarks, examples and fuzz targets.
+	listPatternAll = "."
+
+	// listTaskName is the name of the task to list tests.
+	listTaskName = "go/test/list"
+)
+
+// listPrefixesDefault are the name prefixes of tests, examples and fuzz targets that are run by default.
+var listPrefixesDefault = []string{"Example", "Fuzz", "Test"}
+
+// ListTask is a task to list tests with the Go toolchain "test" command.
+type ListTask struct {
+	ac   app.Config
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+// The configured run pattern is used to list only the tests that would be run.
+func (t *ListTask) BuildParams() []string {
+	pattern := t.opts.RunPattern
+	if pattern == "" {
+		pattern = listPatternAll
+	}
+
+	params := []string{"test"}
+	params = append(params, t.opts.Params()...)
+	params = append(params, fmt.Sprintf("-list=%s", pattern))
+	return append(params, t.opts.Pkgs...)
+}
+
+// Env returns the task specific environment.
+func (t *ListTask) Env() map[string]string {
+	return t.opts.Env
+}
+
+// Kind returns the task kind.
+func (t *ListTask) Kind() task.Kind {
+	return task.KindExec
+}
+
+// Name returns the unique task name.
+func (t *ListTask) Name() string {
+	return listTaskName
+}
+
+// Options returns the task options.
+func (t *ListTask) Options() task.Options {
+	return *t.opts
+}
+
+// Test is a top-level test, example or fuzz target of a package.
+type Test struct {
+	// Name is the name of the test function.
+	Name string `json:"name"`
+
+	// Pkg is the import path of the package.
+	Pkg string `json:"pkg"`
+}
+
+// Key returns the key that identifies the test across runs.
+func (t Test) Key() string {
+	return t.Pkg + "." + t.Name
+}
+
+func (t Test) String() string {
+	return t.Key()
+}
+
+// NewList creates a new task to list tests with the Go toolchain "test" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func NewList(ac app.Config, opts ...Option) (*ListTask, error) {
+	t, err := New(ac, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ListTask{ac: ac, opts: t.opts}, nil
+}
+
+// ParseList parses the output of the Go toolchain "test" command with the "-list" flag into tests whose names start
+// with any of the given prefixes, e.g. "Fuzz" to only include fuzz targets.
+// When no prefix is given tests, examples and fuzz targets are included but no benchmarks since they are not run by
+// default.
+func ParseList(r io.Reader, prefixes ...string) ([]Test, error) {
+	if len(prefixes) == 0 {
+		prefixes = listPrefixesDefault
+	}
+
+	var tests []Test
+	var pending []string
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		switch {
+		case len(fields) == 1 && hasAnyPrefix(fields[0], prefixes):
+			pending = append(pending, fields[0])
+		// The names are followed by the result line of the package, e.g. "ok  	example.com/pkg	0.003s".
+		case len(fields) >= 2 && fields[0] == "ok":
+			for _, name := range pending {
+				tests = append(tests, Test{Name: name, Pkg: fields[1]})
+			}
+			pending = nil
+		case len(fields) >= 2 && (fields[0] == "FAIL" || fields[0] == "?"):
+			pending = nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read test list: %w", err)
+	}
+	return tests, nil
+}
+
+// hasAnyPrefix indicates whether the given name starts with any of the given prefixes.
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
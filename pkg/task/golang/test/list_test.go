@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		prefixes []string
+		tests    []Test
+	}{
+		{
+			name: "tests examples and fuzz targets",
+			output: strings.Join([]string{
+				"TestParse",
+				"BenchmarkParse",
+				"ExampleParse",
+				"FuzzParse",
+				"ok  	example.com/parser	0.002s",
+				"TestEncode",
+				"ok  	example.com/codec	(cached)",
+			}, "\n"),
+			tests: []Test{
+				{Name: "TestParse", Pkg: "example.com/parser"},
+				{Name: "ExampleParse", Pkg: "example.com/parser"},
+				{Name: "FuzzParse", Pkg: "example.com/parser"},
+				{Name: "TestEncode", Pkg: "example.com/codec"},
+			},
+		},
+		{
+			name: "packages without test files and failing packages",
+			output: strings.Join([]string{
+				"?   	example.com/cmd	[no test files]",
+				"TestBroken",
+				"FAIL	example.com/broken [build failed]",
+			}, "\n"),
+		},
+		{
+			name: "fuzz targets only",
+			output: strings.Join([]string{
+				"FuzzDecode",
+				"FuzzEncode",
+				"TestDecode",
+				"ok  	example.com/codec	0.003s",
+				"FuzzBroken",
+				"FAIL	example.com/broken [build failed]",
+				"ExampleParse",
+				"FuzzParse",
+				"ok  	example.com/parser	(cached)",
+			}, "\n"),
+			prefixes: []string{"Fuzz"},
+			tests: []Test{
+				{Name: "FuzzDecode", Pkg: "example.com/codec"},
+				{Name: "FuzzEncode", Pkg: "example.com/codec"},
+				{Name: "FuzzParse", Pkg: "example.com/parser"},
+			},
+		},
+		{name: "empty output", output: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseList(strings.NewReader(tc.output), tc.prefixes...)
+			require.NoError(t, err)
+			require.Equal(t, tc.tests, parsed)
+		})
+	}
+}
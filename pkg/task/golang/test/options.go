@@ -4,7 +4,12 @@
 package test
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/svengreb/wand/pkg/task"
 	taskGo "github.com/svengreb/wand/pkg/task/golang"
+	"github.com/svengreb/wand/pkg/task/golang/test/fixture"
 )
 
 const (
@@ -20,6 +25,10 @@ const (
 	// DefaultCPUProfileOutputFileName is the default file name for the CPU profile file.
 	DefaultCPUProfileOutputFileName = "cpu_profile.out"
 
+	// DefaultHistoryFileName is the default file name for the test history file that stores the durations of tests and
+	// the tests that have been classified as flaky.
+	DefaultHistoryFileName = "history.json"
+
 	// DefaultMemoryProfileOutputFileName is the default file name for the memory profile file.
 	DefaultMemoryProfileOutputFileName = "mem_profile.out"
 
@@ -85,6 +94,13 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Testing_flags
 	EnableCPUProfile bool
 
+	// EnableJSONOutput indicates whether the test output should be converted to JSON suitable for automated processing.
+	//
+	// See `go help test`, `go doc test2json` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	//   - https://golang.org/cmd/test2json
+	EnableJSONOutput bool
+
 	// EnableMemoryProfile indicates whether the tests should be run with memory profiling.
 	//
 	// See `go help test` and the `go` command documentations for more details:
@@ -109,6 +125,15 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Testing_flags
 	EnableVerboseOutput bool
 
+	// Fixtures are the fixtures that are started before and stopped after the tests run.
+	// Their environment variables, e.g. network addresses, are passed to the tests.
+	Fixtures []fixture.Fixture
+
+	// FixturesTimeout is the maximum duration the fixtures are started and the tests are run with them.
+	// When exceeded no further test runs are started and the fixtures are stopped after the current run finished.
+	// There is no timeout when the value is zero.
+	FixturesTimeout time.Duration
+
 	// Flags are additional flags that are passed to the Go `test` command along with the base Go flags.
 	//
 	// See `go help test` and the `go` command documentations for more details:
@@ -116,6 +141,10 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Compile_packages_and_dependencies
 	Flags []string
 
+	// HistoryFile is the path to the file that stores the durations of tests, used to balance shards, and the tests that
+	// have been classified as flaky.
+	HistoryFile string
+
 	// MemoryProfileOutputFileName is the file name for the memory profile file.
 	//
 	// See `go help test` and the `go` command documentations for more details:
@@ -144,6 +173,23 @@ type Options struct {
 	//   - https://golang.org/cmd/go/#hdr-Testing_flags
 	Pkgs []string
 
+	// RetryCount is the maximum number of times a failing test is run again.
+	// Tests that pass on any retry are classified as flaky instead of failed.
+	RetryCount int
+
+	// RunPattern is the regular expression to select the tests, examples and fuzz targets to run.
+	//
+	// See `go help testflag` and the `go` command documentations for more details:
+	//   - https://golang.org/cmd/go/#hdr-Testing_flags
+	RunPattern string
+
+	// ShardCount is the number of shards the tests are split into.
+	// Sharding is disabled when the value is less than two.
+	ShardCount int
+
+	// ShardIndex is the zero-based index of the shard whose tests are run.
+	ShardIndex int
+
 	// taskGoOpts are shared Go toolchain task options.
 	taskGoOpts []taskGo.Option
 
@@ -155,7 +201,8 @@ type Options struct {
 }
 
 // NewOptions creates new task options.
-func NewOptions(opts ...Option) *Options {
+// It returns an error of type *task.ErrTask when the shard or the shared Go toolchain task options are invalid.
+func NewOptions(opts ...Option) (*Options, error) {
 	opt := &Options{
 		BlockProfileOutputFileName:    DefaultBlockProfileOutputFileName,
 		CoverageProfileOutputFileName: DefaultCoverageOutputFileName,
@@ -169,9 +216,20 @@ func NewOptions(opts ...Option) *Options {
 		o(opt)
 	}
 
-	opt.Options = taskGo.NewOptions(opt.taskGoOpts...)
+	if (opt.ShardCount != 0 || opt.ShardIndex != 0) && (opt.ShardIndex < 0 || opt.ShardIndex >= opt.ShardCount) {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("invalid shard index %d for %d shards", opt.ShardIndex, opt.ShardCount),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	goOpts, goOptsErr := taskGo.NewOptions(opt.taskGoOpts...)
+	if goOptsErr != nil {
+		return nil, goOptsErr
+	}
+	opt.Options = goOpts
 
-	return opt
+	return opt, nil
 }
 
 // WithBlockProfile indicates whether the tests should be run with a Goroutine blocking profiling.
@@ -237,6 +295,21 @@ func WithCPUProfileOutputFileName(cpuProfileOutputFileName string) Option {
 	}
 }
 
+// WithFixtures adds fixtures that are started before and stopped after the tests run.
+// Their environment variables, e.g. network addresses, are passed to the tests.
+func WithFixtures(fixtures ...fixture.Fixture) Option {
+	return func(o *Options) {
+		o.Fixtures = append(o.Fixtures, fixtures...)
+	}
+}
+
+// WithFixturesTimeout sets the maximum duration the fixtures are started and the tests are run with them.
+func WithFixturesTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.FixturesTimeout = timeout
+	}
+}
+
 // WithFlags sets additional flags that are passed to the Go "test" command along with the shared Go flags.
 //
 // See `go help test` and the `go` command documentations for more details:
@@ -248,6 +321,15 @@ func WithFlags(flags ...string) Option {
 	}
 }
 
+// WithHistoryFile sets the path to the file that stores the durations of tests and the tests that have been
+// classified as flaky.
+// Defaults to DefaultHistoryFileName within the test directory of the wand specific data directory.
+func WithHistoryFile(historyFile string) Option {
+	return func(o *Options) {
+		o.HistoryFile = historyFile
+	}
+}
+
 // WithGoOptions sets shared Go toolchain task options.
 func WithGoOptions(goOpts ...taskGo.Option) Option {
 	return func(o *Options) {
@@ -255,6 +337,17 @@ func WithGoOptions(goOpts ...taskGo.Option) Option {
 	}
 }
 
+// WithJSONOutput indicates whether the test output should be converted to JSON suitable for automated processing.
+//
+// See `go help test`, `go doc test2json` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+//   - https://golang.org/cmd/test2json
+func WithJSONOutput(withJSONOutput bool) Option {
+	return func(o *Options) {
+		o.EnableJSONOutput = withJSONOutput
+	}
+}
+
 // WithMemoryProfile indicates whether the tests should be run with memory profiling.
 //
 // See `go help test` and the `go` command documentations for more details:
@@ -329,6 +422,49 @@ func WithPkgs(pkgs ...string) Option {
 	}
 }
 
+// WithPkgsReplaced sets the list of packages to test and replaces all previously set packages.
+//
+// See `go help test` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithPkgsReplaced(pkgs ...string) Option {
+	return func(o *Options) {
+		o.Pkgs = pkgs
+	}
+}
+
+// WithRetryCount sets the maximum number of times a failing test is run again.
+// Tests that pass on any retry are classified as flaky instead of failed.
+func WithRetryCount(retryCount int) Option {
+	return func(o *Options) {
+		if retryCount >= 0 {
+			o.RetryCount = retryCount
+		}
+	}
+}
+
+// WithRunPattern sets the regular expression to select the tests, examples and fuzz targets to run.
+//
+// See `go help testflag` and the `go` command documentations for more details:
+//   - https://golang.org/cmd/go/#hdr-Testing_flags
+func WithRunPattern(runPattern string) Option {
+	return func(o *Options) {
+		o.RunPattern = runPattern
+	}
+}
+
+// WithShard sets the zero-based index of the shard whose tests are run and the number of shards the tests are split
+// into.
+// The tests are split deterministically and balanced by their durations stored in the history file which is only read
+// by sharded runs so that all shards are partitioned from the same snapshot.
+// Invalid values where the index is negative or not less than the count result in an error of kind
+// task.ErrInvalidTaskOpts when creating the task options.
+func WithShard(index, count int) Option {
+	return func(o *Options) {
+		o.ShardIndex = index
+		o.ShardCount = count
+	}
+}
+
 // WithTraceProfile indicates whether the tests should be run with trace profiling.
 //
 // See `go help test` and the `go` command documentations for more details:
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/svengreb/wand/pkg/task"
+)
+
+func TestWithShard(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		count   int
+		wantErr bool
+	}{
+		{name: "first shard", index: 0, count: 3},
+		{name: "last shard", index: 2, count: 3},
+		{name: "single shard", index: 0, count: 1},
+		{name: "disabled", index: 0, count: 0},
+		{name: "index equals count", index: 3, count: 3, wantErr: true},
+		{name: "negative index", index: -1, count: 3, wantErr: true},
+		{name: "negative count", index: 0, count: -2, wantErr: true},
+		{name: "index without count", index: 1, count: 0, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := NewOptions(WithShard(tc.index, tc.count))
+			if tc.wantErr {
+				var tErr *task.ErrTask
+				require.ErrorAs(t, err, &tErr)
+				require.ErrorIs(t, tErr.Kind, task.ErrInvalidTaskOpts)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.index, opts.ShardIndex)
+			require.Equal(t, tc.count, opts.ShardCount)
+		})
+	}
+}
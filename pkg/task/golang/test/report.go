@@ -0,0 +1,316 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// eventActionFail is the action of an event for a failed test or package.
+	eventActionFail = "fail"
+	// eventActionOutput is the action of an event for printed output.
+	eventActionOutput = "output"
+	// eventActionPass is the action of an event for a passed test or package.
+	eventActionPass = "pass"
+	// eventActionSkip is the action of an event for a skipped test or package.
+	eventActionSkip = "skip"
+
+	// shardReportFileNameFormat is the format of the file name for the stored report of a shard.
+	shardReportFileNameFormat = "shard-%d-of-%d.json"
+)
+
+// Event is a event of the Go toolchain "test" command output in JSON format.
+//
+// See `go doc test2json` and https://golang.org/cmd/test2json for more details.
+type Event struct {
+	// Action is the action of the event, e.g. "run", "output", "pass" or "fail".
+	Action string `json:"Action"`
+
+	// Elapsed is the duration in seconds for "pass" and "fail" events.
+	Elapsed float64 `json:"Elapsed,omitempty"`
+
+	// Output is the printed output for "output" events.
+	Output string `json:"Output,omitempty"`
+
+	// Package is the import path of the package.
+	Package string `json:"Package,omitempty"`
+
+	// Test is the name of the test, or empty for package events.
+	Test string `json:"Test,omitempty"`
+
+	// Time is the time of the event.
+	Time time.Time `json:"Time"`
+}
+
+// PkgFailure is a package that failed without any failing test, e.g. because it does not compile, a "TestMain"
+// function failed or the test binary panicked outside of a test.
+type PkgFailure struct {
+	// Output is the output of the package.
+	Output string `json:"output"`
+
+	// Pkg is the import path of the package.
+	Pkg string `json:"pkg"`
+}
+
+// Report is a report of a test run.
+type Report struct {
+	// HistoryFile is the path to the file that stores the durations of tests and the tests that have been classified
+	// as flaky.
+	HistoryFile string `json:"historyFile,omitempty"`
+
+	// PkgFailures are the packages that failed without any failing test.
+	PkgFailures []PkgFailure `json:"pkgFailures,omitempty"`
+
+	// ShardCount is the number of shards the tests were split into, or zero when sharding was disabled.
+	ShardCount int `json:"shardCount,omitempty"`
+
+	// ShardIndex is the zero-based index of the shard whose tests were run.
+	ShardIndex int `json:"shardIndex,omitempty"`
+
+	// Tests are the results of all top-level tests.
+	Tests []TestResult `json:"tests"`
+}
+
+// Failed returns the results of all tests that failed on every attempt.
+func (r *Report) Failed() []TestResult {
+	return r.withStatus(StatusFail)
+}
+
+// Flaky returns the results of all tests that failed at first but passed on a retry.
+func (r *Report) Flaky() []TestResult {
+	return r.withStatus(StatusFlaky)
+}
+
+// Save stores the report as JSON file at the given path and creates all parent directories.
+func (r *Report) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(path), err)
+	}
+
+	data, marshalErr := json.MarshalIndent(r, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("encode test report: %w", marshalErr)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write test report %q: %w", path, err)
+	}
+	return nil
+}
+
+// String returns a summary with the number of tests per status followed by all flaky and failed tests.
+func (r *Report) String() string {
+	counts := make(map[Status]int)
+	for _, t := range r.Tests {
+		counts[t.Status]++
+	}
+
+	var b strings.Builder
+	if r.ShardCount > 0 {
+		fmt.Fprintf(&b, "Shard %d/%d: ", r.ShardIndex+1, r.ShardCount)
+	}
+	fmt.Fprintf(&b, "%d passed, %d skipped, %d flaky, %d failed",
+		counts[StatusPass], counts[StatusSkip], counts[StatusFlaky], counts[StatusFail],
+	)
+	if len(r.PkgFailures) > 0 {
+		fmt.Fprintf(&b, ", %d packages failed", len(r.PkgFailures))
+	}
+	for _, t := range r.Flaky() {
+		fmt.Fprintf(&b, "\n  %s %s (passed on attempt %d)", StatusNameFlaky, t.Test, t.Attempts)
+	}
+	for _, t := range r.Failed() {
+		fmt.Fprintf(&b, "\n  %s %s (%d attempts)", StatusNameFail, t.Test, t.Attempts)
+	}
+	for _, p := range r.PkgFailures {
+		fmt.Fprintf(&b, "\n  %s %s", StatusNameFail, p.Pkg)
+	}
+	return b.String()
+}
+
+func (r *Report) withStatus(status Status) []TestResult {
+	var results []TestResult
+	for _, t := range r.Tests {
+		if t.Status == status {
+			results = append(results, t)
+		}
+	}
+	return results
+}
+
+// TestResult is the result of a top-level test.
+type TestResult struct {
+	Test
+
+	// Attempts is the number of times the test has been run.
+	Attempts int `json:"attempts"`
+
+	// Elapsed is the duration of the last attempt.
+	Elapsed time.Duration `json:"elapsed"`
+
+	// Output is the output of the last failed attempt, including the output of all subtests.
+	Output string `json:"output,omitempty"`
+
+	// Status is the result status.
+	Status Status `json:"status"`
+}
+
+// LoadReport loads the report from the JSON file at the given path.
+func LoadReport(path string) (*Report, error) {
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("read test report %q: %w", path, readErr)
+	}
+
+	r := &Report{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("decode test report %q: %w", path, err)
+	}
+	return r, nil
+}
+
+// MergeReports merges the given reports, e.g. of all shards of a test run, into a single report.
+// The results of tests and packages that are included in multiple reports are taken from the last report. The results
+// are sorted by the test key and the package import path.
+func MergeReports(reports ...*Report) *Report {
+	merged := &Report{}
+	tests := make(map[string]TestResult)
+	pkgFailures := make(map[string]PkgFailure)
+	for _, r := range reports {
+		if merged.HistoryFile == "" {
+			merged.HistoryFile = r.HistoryFile
+		}
+		for _, t := range r.Tests {
+			tests[t.Key()] = t
+		}
+		for _, pf := range r.PkgFailures {
+			pkgFailures[pf.Pkg] = pf
+		}
+	}
+
+	merged.Tests = make([]TestResult, 0, len(tests))
+	for _, t := range tests {
+		merged.Tests = append(merged.Tests, t)
+	}
+	sort.Slice(merged.Tests, func(i, j int) bool { return merged.Tests[i].Key() < merged.Tests[j].Key() })
+	for _, pf := range pkgFailures {
+		merged.PkgFailures = append(merged.PkgFailures, pf)
+	}
+	sort.Slice(merged.PkgFailures, func(i, j int) bool { return merged.PkgFailures[i].Pkg < merged.PkgFailures[j].Pkg })
+	return merged
+}
+
+// ParseEvents parses the output of the Go toolchain "test" command in JSON format into events.
+// Lines that are not in JSON format, e.g. printed by the Go toolchain when a package does not compile, are ignored.
+//
+// See `go doc test2json` and https://golang.org/cmd/test2json for more details.
+func ParseEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, fmt.Errorf("decode test event %q: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read test events: %w", err)
+	}
+	return events, nil
+}
+
+// Results collects the results of all top-level tests and the packages that failed without any failing test from the
+// given events. Each result counts as a single attempt and the output is only kept for failed tests.
+// The results are sorted by the test key.
+func Results(events []Event) ([]TestResult, []PkgFailure) {
+	results := make(map[string]*TestResult)
+	outputs := make(map[string]*strings.Builder)
+	output := func(key string) *strings.Builder {
+		b, ok := outputs[key]
+		if !ok {
+			b = &strings.Builder{}
+			outputs[key] = b
+		}
+		return b
+	}
+	failedPkgs := make(map[string]bool)
+	pkgFailed := make(map[string]bool)
+
+	for _, ev := range events {
+		// Subtests are accounted to their top-level test.
+		name, _, isSubtest := strings.Cut(ev.Test, "/")
+		key := ev.Package
+		if name != "" {
+			key = Test{Name: name, Pkg: ev.Package}.Key()
+		}
+
+		switch ev.Action {
+		case eventActionOutput:
+			output(key).WriteString(ev.Output)
+		case eventActionPass, eventActionFail, eventActionSkip:
+			if name == "" {
+				if ev.Action == eventActionFail {
+					pkgFailed[ev.Package] = true
+				}
+				continue
+			}
+			if isSubtest {
+				continue
+			}
+			res := &TestResult{
+				Attempts: 1,
+				Elapsed:  time.Duration(ev.Elapsed * float64(time.Second)),
+				Status:   StatusPass,
+				Test:     Test{Name: name, Pkg: ev.Package},
+			}
+			switch ev.Action {
+			case eventActionFail:
+				res.Status = StatusFail
+				failedPkgs[ev.Package] = true
+			case eventActionSkip:
+				res.Status = StatusSkip
+			}
+			results[key] = res
+		}
+	}
+
+	tests := make([]TestResult, 0, len(results))
+	for key, res := range results {
+		if res.Status == StatusFail {
+			res.Output = output(key).String()
+		}
+		tests = append(tests, *res)
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].Key() < tests[j].Key() })
+
+	var pkgFailures []PkgFailure
+	for pkg := range pkgFailed {
+		if !failedPkgs[pkg] {
+			pkgFailures = append(pkgFailures, PkgFailure{Output: output(pkg).String(), Pkg: pkg})
+		}
+	}
+	sort.Slice(pkgFailures, func(i, j int) bool { return pkgFailures[i].Pkg < pkgFailures[j].Pkg })
+
+	return tests, pkgFailures
+}
+
+// ShardReportFileName returns the file name for the stored report of the shard with the given zero-based index.
+func ShardReportFileName(index, count int) string {
+	return fmt.Sprintf(shardReportFileNameFormat, index+1, count)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeReports(t *testing.T) {
+	a := Test{Name: "TestA", Pkg: "example.com/pkg"}
+	b := Test{Name: "TestB", Pkg: "example.com/pkg"}
+	c := Test{Name: "TestC", Pkg: "example.com/other"}
+
+	tests := []struct {
+		name    string
+		reports []*Report
+		merged  *Report
+	}{
+		{
+			name: "disjoint shards",
+			reports: []*Report{
+				{
+					HistoryFile: "history.json",
+					ShardCount:  2,
+					Tests:       []TestResult{{Test: b, Attempts: 1, Status: StatusPass}},
+				},
+				{
+					PkgFailures: []PkgFailure{{Output: "build failed", Pkg: "example.com/broken"}},
+					ShardCount:  2,
+					ShardIndex:  1,
+					Tests: []TestResult{
+						{Test: c, Attempts: 2, Status: StatusFlaky},
+						{Test: a, Attempts: 3, Status: StatusFail},
+					},
+				},
+			},
+			merged: &Report{
+				HistoryFile: "history.json",
+				PkgFailures: []PkgFailure{{Output: "build failed", Pkg: "example.com/broken"}},
+				Tests: []TestResult{
+					{Test: c, Attempts: 2, Status: StatusFlaky},
+					{Test: a, Attempts: 3, Status: StatusFail},
+					{Test: b, Attempts: 1, Status: StatusPass},
+				},
+			},
+		},
+		{
+			name: "duplicate results are taken from the last report",
+			reports: []*Report{
+				{Tests: []TestResult{{Test: a, Attempts: 1, Status: StatusFail}}},
+				{Tests: []TestResult{{Test: a, Attempts: 1, Status: StatusPass}}},
+			},
+			merged: &Report{Tests: []TestResult{{Test: a, Attempts: 1, Status: StatusPass}}},
+		},
+		{name: "no reports", merged: &Report{Tests: []TestResult{}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.merged, MergeReports(tc.reports...))
+		})
+	}
+}
+
+func TestReportSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", ShardReportFileName(0, 2))
+	r := &Report{
+		ShardCount: 2,
+		Tests: []TestResult{
+			{Test: Test{Name: "TestA", Pkg: "example.com/pkg"}, Attempts: 1, Elapsed: time.Second, Status: StatusPass},
+		},
+	}
+	require.NoError(t, r.Save(path))
+	require.Equal(t, "shard-1-of-2.json", filepath.Base(path))
+
+	loaded, err := LoadReport(path)
+	require.NoError(t, err)
+	require.Equal(t, r, loaded)
+
+	_, err = LoadReport(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestHistoryUpdateWithMergedReports(t *testing.T) {
+	a := Test{Name: "TestA", Pkg: "example.com/pkg"}
+	b := Test{Name: "TestB", Pkg: "example.com/pkg"}
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	h, err := LoadHistory(filepath.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	h.Durations[a.Key()] = time.Minute
+
+	h.Update(MergeReports(
+		&Report{Tests: []TestResult{{Test: a, Elapsed: time.Second, Status: StatusPass}}},
+		&Report{Tests: []TestResult{{Test: b, Elapsed: 2 * time.Second, Status: StatusFlaky}}},
+	), now)
+
+	require.Equal(t, map[string]time.Duration{a.Key(): time.Second, b.Key(): 2 * time.Second}, h.Durations)
+	require.Equal(t, map[string]FlakyTest{b.Key(): {Test: b, Count: 1, LastSeen: now}}, h.Flaky)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunPatternOf returns the regular expression that matches exactly the top-level tests with the given names.
+// Subtests of matched tests are run as well.
+func RunPatternOf(names ...string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
+// Shard splits the given tests deterministically into the given number of shards.
+// The shards are balanced by the given durations mapped by the test key: the tests are assigned in descending order of
+// their duration to the shard with the lowest total duration so far. Tests without known duration are assumed to take
+// the average duration of all tests with known duration.
+// The tests of each shard are sorted by their key.
+func Shard(tests []Test, durations map[string]time.Duration, count int) [][]Test {
+	if count < 1 {
+		count = 1
+	}
+
+	var known time.Duration
+	var knownCount int
+	for _, t := range tests {
+		if d, ok := durations[t.Key()]; ok {
+			known += d
+			knownCount++
+		}
+	}
+	// Use a non-zero fallback so that tests without any known duration are still distributed evenly.
+	fallback := time.Second
+	if knownCount > 0 && known > 0 {
+		fallback = known / time.Duration(knownCount)
+	}
+	duration := func(t Test) time.Duration {
+		if d, ok := durations[t.Key()]; ok {
+			return d
+		}
+		return fallback
+	}
+
+	sorted := append([]Test(nil), tests...)
+	sort.Slice(sorted, func(i, j int) bool {
+		di, dj := duration(sorted[i]), duration(sorted[j])
+		if di != dj {
+			return di > dj
+		}
+		return sorted[i].Key() < sorted[j].Key()
+	})
+
+	shards := make([][]Test, count)
+	totals := make([]time.Duration, count)
+	for _, t := range sorted {
+		target := 0
+		for i := 1; i < count; i++ {
+			if totals[i] < totals[target] {
+				target = i
+			}
+		}
+		shards[target] = append(shards[target], t)
+		totals[target] += duration(t)
+	}
+
+	for _, s := range shards {
+		sort.Slice(s, func(i, j int) bool { return s[i].Key() < s[j].Key() })
+	}
+	return shards
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShard(t *testing.T) {
+	a := Test{Name: "TestA", Pkg: "example.com/pkg"}
+	b := Test{Name: "TestB", Pkg: "example.com/pkg"}
+	c := Test{Name: "TestC", Pkg: "example.com/pkg"}
+	d := Test{Name: "TestD", Pkg: "example.com/other"}
+
+	tests := []struct {
+		name      string
+		tests     []Test
+		durations map[string]time.Duration
+		count     int
+		shards    [][]Test
+	}{
+		{
+			name:  "balanced by durations",
+			tests: []Test{a, b, c, d},
+			durations: map[string]time.Duration{
+				a.Key(): 10 * time.Second,
+				b.Key(): 4 * time.Second,
+				c.Key(): 3 * time.Second,
+				d.Key(): 2 * time.Second,
+			},
+			count:  2,
+			shards: [][]Test{{a}, {d, b, c}},
+		},
+		{
+			name:      "unknown durations fall back to average",
+			tests:     []Test{a, b, c},
+			durations: map[string]time.Duration{a.Key(): 6 * time.Second, b.Key(): 2 * time.Second},
+			count:     2,
+			shards:    [][]Test{{a}, {b, c}},
+		},
+		{
+			name:   "no durations",
+			tests:  []Test{d, c, b, a},
+			count:  2,
+			shards: [][]Test{{d, b}, {a, c}},
+		},
+		{
+			name:   "more shards than tests",
+			tests:  []Test{a},
+			count:  3,
+			shards: [][]Test{{a}, nil, nil},
+		},
+		{
+			name:   "single shard",
+			tests:  []Test{b, a},
+			count:  0,
+			shards: [][]Test{{a, b}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.shards, Shard(tc.tests, tc.durations, tc.count))
+		})
+	}
+}
+
+func TestShardIsDeterministic(t *testing.T) {
+	tests := []Test{
+		{Name: "TestA", Pkg: "example.com/pkg"},
+		{Name: "TestB", Pkg: "example.com/pkg"},
+		{Name: "TestC", Pkg: "example.com/pkg"},
+		{Name: "TestD", Pkg: "example.com/other"},
+		{Name: "TestE", Pkg: "example.com/other"},
+	}
+	reversed := make([]Test, len(tests))
+	for i, t := range tests {
+		reversed[len(tests)-1-i] = t
+	}
+	durations := map[string]time.Duration{tests[0].Key(): time.Second, tests[3].Key(): 3 * time.Second}
+
+	shards := Shard(tests, durations, 3)
+	require.Equal(t, shards, Shard(reversed, durations, 3))
+
+	var total int
+	for _, s := range shards {
+		total += len(s)
+	}
+	require.Equal(t, len(tests), total)
+}
+
+func TestRunPatternOf(t *testing.T) {
+	require.Equal(t, "^(TestA|Test\\.B)$", RunPatternOf("TestA", "Test.B"))
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/svengreb/wand/pkg/app"
 	"github.com/svengreb/wand/pkg/task"
-	taskGo "github.com/svengreb/wand/pkg/task/golang"
 )
 
 // Task is a task for the Go toolchain "test" command.
@@ -29,16 +28,24 @@ type Task struct {
 func (t *Task) BuildParams() []string {
 	params := []string{"test"}
 
-	params = append(params, taskGo.BuildGoOptions(t.opts.taskGoOpts...)...)
+	params = append(params, t.opts.Params()...)
 
 	if t.opts.EnableVerboseOutput {
 		params = append(params, "-v")
 	}
 
+	if t.opts.EnableJSONOutput {
+		params = append(params, "-json")
+	}
+
 	if t.opts.DisableCache {
 		params = append(params, "-count=1")
 	}
 
+	if t.opts.RunPattern != "" {
+		params = append(params, fmt.Sprintf("-run=%s", t.opts.RunPattern))
+	}
+
 	if t.opts.EnableBlockProfile {
 		params = append(params,
 			fmt.Sprintf(
@@ -119,14 +126,34 @@ func (t *Task) Options() task.Options {
 }
 
 // New creates a new task for the Go toolchain "test" command.
+// It returns an error of type *task.ErrTask when the task options are invalid.
 //nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
-func New(ac app.Config, opts ...Option) *Task {
-	opt := NewOptions(opts...)
+func New(ac app.Config, opts ...Option) (*Task, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
 
 	// Store test profiles and reports within the application specific subdirectory.
 	if opt.OutputDir == "" {
 		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
 	}
 
-	return &Task{ac: ac, opts: opt}
+	return &Task{ac: ac, opts: opt}, nil
+}
+
+// NewAttempt creates a new task for the Go toolchain "test" command that runs the tests matched by the given run
+// pattern in the given packages with output in JSON format, e.g. to run a single shard or to retry failed tests.
+// The configured packages and run pattern are replaced.
+// It returns an error of type *task.ErrTask when the task options are invalid.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func NewAttempt(ac app.Config, pkgs []string, runPattern string, opts ...Option) (*Task, error) {
+	t, err := New(ac, opts...)
+	if err != nil {
+		return nil, err
+	}
+	t.opts.EnableJSONOutput = true
+	t.opts.Pkgs = pkgs
+	t.opts.RunPattern = runPattern
+	return t, nil
 }
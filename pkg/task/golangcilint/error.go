@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golangcilint
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrIssues indicates that linters found issues.
+const ErrIssues = wErr.ErrString("linters found issues")
@@ -24,7 +24,21 @@ type Task struct {
 
 // BuildParams builds the parameters.
 func (t *Task) BuildParams() []string {
-	return t.opts.args
+	params := append([]string{}, t.opts.args...)
+
+	if t.opts.newFromPatch != "" {
+		params = append(params, "--new-from-patch="+t.opts.newFromPatch)
+	}
+
+	if t.opts.newFromRev != "" {
+		params = append(params, "--new-from-rev="+t.opts.newFromRev)
+	}
+
+	if t.opts.EnableJSONOutput {
+		params = append(params, "--out-format=json", "--issues-exit-code=0")
+	}
+
+	return params
 }
 
 // Env returns the task specific environment.
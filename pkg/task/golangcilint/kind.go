@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golangcilint
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ReportFormatNameCheckstyle is the ReportFormat name for Checkstyle XML reports.
+	ReportFormatNameCheckstyle = "checkstyle"
+	// ReportFormatNameSARIF is the ReportFormat name for SARIF reports.
+	ReportFormatNameSARIF = "sarif"
+	// ReportFormatNameUnknown is the name for a unknown ReportFormat.
+	ReportFormatNameUnknown = "unknown"
+)
+
+const (
+	// ReportFormatCheckstyle is the ReportFormat for Checkstyle XML reports.
+	ReportFormatCheckstyle ReportFormat = iota
+	// ReportFormatSARIF is the ReportFormat for reports in the Static Analysis Results Interchange Format (SARIF).
+	ReportFormatSARIF
+)
+
+// ReportFormat defines the format of a report file.
+type ReportFormat uint32
+
+// FileName returns the name of the report file for the format.
+func (f ReportFormat) FileName() string {
+	switch f {
+	case ReportFormatCheckstyle:
+		return "golangci-lint.checkstyle.xml"
+	case ReportFormatSARIF:
+		return "golangci-lint.sarif"
+	}
+	return "golangci-lint." + f.String()
+}
+
+// MarshalText returns the textual representation of itself.
+func (f ReportFormat) MarshalText() ([]byte, error) {
+	switch f {
+	case ReportFormatCheckstyle:
+		return []byte(ReportFormatNameCheckstyle), nil
+	case ReportFormatSARIF:
+		return []byte(ReportFormatNameSARIF), nil
+	}
+
+	return nil, fmt.Errorf("not a valid report format %d", f)
+}
+
+func (f ReportFormat) String() string {
+	if b, err := f.MarshalText(); err == nil {
+		return string(b)
+	}
+	return ReportFormatNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (f *ReportFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseReportFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = parsed
+	return nil
+}
+
+// ParseReportFormat takes a report format name and returns the ReportFormat constant.
+func ParseReportFormat(name string) (ReportFormat, error) {
+	switch strings.ToLower(name) {
+	case ReportFormatNameCheckstyle:
+		return ReportFormatCheckstyle, nil
+	case ReportFormatNameSARIF:
+		return ReportFormatSARIF, nil
+	}
+
+	var f ReportFormat
+	return f, fmt.Errorf("not a valid report format: %q", name)
+}
@@ -34,6 +34,10 @@ type Options struct {
 	// args are arguments passed to the command.
 	args []string
 
+	// EnableJSONOutput indicates whether the command should print issues in JSON format to parse them into typed issues.
+	// The exit code for found issues is set to zero so that failures of the command itself can be distinguished.
+	EnableJSONOutput bool
+
 	// env is the task specific environment.
 	env map[string]string
 
@@ -43,6 +47,19 @@ type Options struct {
 	// name is the task name.
 	name string
 
+	// newFromPatch is the path to a patch file for which only new issues are reported.
+	newFromPatch string
+
+	// newFromRev is the revision from which on only new issues are reported.
+	newFromRev string
+
+	// ReportDir is the path to the directory for report files.
+	// Defaults to the base output directory of the project.
+	ReportDir string
+
+	// ReportFormats are the formats of report files that are written for parsed issues.
+	ReportFormats []ReportFormat
+
 	// verbose indicates whether the output should be verbose.
 	verbose bool
 }
@@ -91,6 +108,14 @@ func WithEnv(env map[string]string) Option {
 	}
 }
 
+// WithJSONOutput indicates whether the command should print issues in JSON format to parse them into typed issues.
+// Defaults to false.
+func WithJSONOutput(enableJSONOutput bool) Option {
+	return func(o *Options) {
+		o.EnableJSONOutput = enableJSONOutput
+	}
+}
+
 // WithModulePath sets the module import path.
 // Defaults to DefaultGoModulePath.
 func WithModulePath(path string) Option {
@@ -111,6 +136,42 @@ func WithModuleVersion(version *semver.Version) Option {
 	}
 }
 
+// WithNewFromPatch sets the path to a patch file, e.g. the unified diff of all staged changes, for which only new
+// issues are reported.
+//
+// See https://golangci-lint.run/usage/configuration for more details.
+func WithNewFromPatch(path string) Option {
+	return func(o *Options) {
+		o.newFromPatch = path
+	}
+}
+
+// WithNewFromRev sets the revision from which on only new issues are reported, e.g. the merge base of the current
+// branch.
+//
+// See https://golangci-lint.run/usage/configuration for more details.
+func WithNewFromRev(rev string) Option {
+	return func(o *Options) {
+		o.newFromRev = rev
+	}
+}
+
+// WithReportDir sets the path to the directory for report files.
+// Defaults to the base output directory of the project.
+func WithReportDir(dir string) Option {
+	return func(o *Options) {
+		o.ReportDir = dir
+	}
+}
+
+// WithReportFormats sets the formats of report files that are written for parsed issues.
+// Note that reports can only be written when the JSON output is enabled.
+func WithReportFormats(formats ...ReportFormat) Option {
+	return func(o *Options) {
+		o.ReportFormats = append(o.ReportFormats, formats...)
+	}
+}
+
 // WithVerboseOutput indicates whether the output should be verbose.
 func WithVerboseOutput(verbose bool) Option {
 	return func(o *Options) {
@@ -0,0 +1,405 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golangcilint
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// SARIFSchemaURI is the URI of the JSON schema for SARIF reports.
+	SARIFSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// SARIFVersion is the version of the SARIF specification that is used for SARIF reports.
+	SARIFVersion = "2.1.0"
+
+	// SeverityDefault is the severity of issues for which no severity has been configured.
+	// It matches the severity that "golangci-lint" uses in its own Checkstyle printer.
+	SeverityDefault = "error"
+)
+
+// InlineFix is a suggested fix that replaces a part of a single line.
+type InlineFix struct {
+	// Length is the number of bytes to replace.
+	Length int `json:"Length"`
+
+	// NewString is the replacement text.
+	NewString string `json:"NewString"`
+
+	// StartCol is the zero-based column at which the replacement starts.
+	StartCol int `json:"StartCol"`
+}
+
+// Issue is a issue found by a linter.
+type Issue struct {
+	// Column is the column of the issue.
+	Column int
+
+	// File is the path to the file of the issue.
+	File string
+
+	// Fix is the suggested fix of the issue, if any.
+	Fix *SuggestedFix
+
+	// Line is the line of the issue.
+	Line int
+
+	// Linter is the name of the linter that found the issue.
+	Linter string
+
+	// Message is the issue message.
+	Message string
+
+	// Severity is the severity of the issue.
+	// It is only set when configured through the "severity" section of the "golangci-lint" configuration.
+	Severity string
+
+	// SourceLines are the source lines affected by the issue.
+	SourceLines []string
+}
+
+// SeverityOrDefault returns the severity of the issue or SeverityDefault when no severity has been configured.
+func (i Issue) SeverityOrDefault() string {
+	if i.Severity == "" {
+		return SeverityDefault
+	}
+	return i.Severity
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s (%s)", i.File, i.Line, i.Column, i.Message, i.Linter)
+}
+
+// Report is the parsed JSON output of "golangci-lint".
+type Report struct {
+	// Error is the error that occurred while running linters, if any.
+	Error string
+
+	// Issues are all found issues.
+	Issues []Issue
+
+	// Warnings are warnings that occurred while running linters.
+	Warnings []string
+}
+
+// SuggestedFix is a suggested fix of an issue.
+type SuggestedFix struct {
+	// Inline is a replacement of a part of a single line, if any.
+	Inline *InlineFix
+
+	// NeedOnlyDelete indicates whether the affected lines only need to be deleted.
+	NeedOnlyDelete bool
+
+	// NewLines are the lines that replace the affected lines when Inline is not set.
+	NewLines []string
+
+	// lineFrom is the first line affected by the fix.
+	lineFrom int
+
+	// lineTo is the last line affected by the fix.
+	lineTo int
+}
+
+// jsonIssue is a issue in the JSON output of "golangci-lint".
+type jsonIssue struct {
+	FromLinter string `json:"FromLinter"`
+	LineRange  *struct {
+		From int `json:"From"`
+		To   int `json:"To"`
+	} `json:"LineRange"`
+	Pos struct {
+		Column   int    `json:"Column"`
+		Filename string `json:"Filename"`
+		Line     int    `json:"Line"`
+	} `json:"Pos"`
+	Replacement *struct {
+		Inline         *InlineFix `json:"Inline"`
+		NeedOnlyDelete bool       `json:"NeedOnlyDelete"`
+		NewLines       []string   `json:"NewLines"`
+	} `json:"Replacement"`
+	Severity    string   `json:"Severity"`
+	SourceLines []string `json:"SourceLines"`
+	Text        string   `json:"Text"`
+}
+
+// jsonOutput is the JSON output of "golangci-lint".
+type jsonOutput struct {
+	Issues []jsonIssue `json:"Issues"`
+	Report *struct {
+		Error    string `json:"Error"`
+		Warnings []struct {
+			Tag  string `json:"Tag"`
+			Text string `json:"Text"`
+		} `json:"Warnings"`
+	} `json:"Report"`
+}
+
+// ParseOutput parses the JSON output of "golangci-lint" into a typed report.
+func ParseOutput(r io.Reader) (*Report, error) {
+	var out jsonOutput
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode JSON output: %w", err)
+	}
+
+	report := &Report{}
+	if out.Report != nil {
+		report.Error = out.Report.Error
+		for _, w := range out.Report.Warnings {
+			if w.Tag != "" {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %s", w.Tag, w.Text))
+				continue
+			}
+			report.Warnings = append(report.Warnings, w.Text)
+		}
+	}
+
+	for _, ji := range out.Issues {
+		issue := Issue{
+			Column:      ji.Pos.Column,
+			File:        ji.Pos.Filename,
+			Line:        ji.Pos.Line,
+			Linter:      ji.FromLinter,
+			Message:     ji.Text,
+			Severity:    ji.Severity,
+			SourceLines: ji.SourceLines,
+		}
+		if ji.Replacement != nil {
+			issue.Fix = &SuggestedFix{
+				Inline:         ji.Replacement.Inline,
+				NeedOnlyDelete: ji.Replacement.NeedOnlyDelete,
+				NewLines:       ji.Replacement.NewLines,
+				lineFrom:       ji.Pos.Line,
+				lineTo:         ji.Pos.Line,
+			}
+			if ji.LineRange != nil {
+				issue.Fix.lineFrom, issue.Fix.lineTo = ji.LineRange.From, ji.LineRange.To
+			}
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	return report, nil
+}
+
+// checkstyleError is a error element of a Checkstyle XML report.
+type checkstyleError struct {
+	Column   int    `xml:"column,attr"`
+	Line     int    `xml:"line,attr"`
+	Message  string `xml:"message,attr"`
+	Severity string `xml:"severity,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleFile is a file element of a Checkstyle XML report.
+type checkstyleFile struct {
+	Errors []checkstyleError `xml:"error"`
+	Name   string            `xml:"name,attr"`
+}
+
+// checkstyleReport is the root element of a Checkstyle XML report.
+type checkstyleReport struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Files   []*checkstyleFile `xml:"file"`
+	Version string            `xml:"version,attr"`
+}
+
+// WriteCheckstyle writes the given issues as Checkstyle XML report.
+func WriteCheckstyle(w io.Writer, issues []Issue) error {
+	files := make(map[string]*checkstyleFile)
+	report := checkstyleReport{Version: "5.0"}
+	for _, i := range issues {
+		f, ok := files[i.File]
+		if !ok {
+			f = &checkstyleFile{Name: i.File}
+			files[i.File] = f
+			report.Files = append(report.Files, f)
+		}
+		f.Errors = append(f.Errors, checkstyleError{
+			Column:   i.Column,
+			Line:     i.Line,
+			Message:  i.Message,
+			Severity: i.SeverityOrDefault(),
+			Source:   i.Linter,
+		})
+	}
+	sort.Slice(report.Files, func(a, b int) bool { return report.Files[a].Name < report.Files[b].Name })
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encode Checkstyle report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// sarifArtifactChange is a change to a single artifact of a SARIF fix.
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+// sarifArtifactLocation is the location of a artifact in a SARIF report.
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifFix is a suggested fix in a SARIF report.
+type sarifFix struct {
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+// sarifLocation is the location of a result in a SARIF report.
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// sarifMessage is a message in a SARIF report.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifRegion is a region of a artifact in a SARIF report.
+type sarifRegion struct {
+	EndColumn   int `json:"endColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	StartLine   int `json:"startLine"`
+}
+
+// sarifReplacement is a replacement of a region in a SARIF fix.
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// sarifReport is the root object of a SARIF report.
+type sarifReport struct {
+	Runs    []sarifRun `json:"runs"`
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+}
+
+// sarifResult is a result in a SARIF report.
+type sarifResult struct {
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+	Level     string          `json:"level"`
+	Locations []sarifLocation `json:"locations"`
+	Message   sarifMessage    `json:"message"`
+	RuleID    string          `json:"ruleId"`
+}
+
+// sarifRule is a rule of a tool in a SARIF report.
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+// sarifRun is a run of a tool in a SARIF report.
+type sarifRun struct {
+	Results []sarifResult `json:"results"`
+	Tool    struct {
+		Driver struct {
+			InformationURI string      `json:"informationUri"`
+			Name           string      `json:"name"`
+			Rules          []sarifRule `json:"rules"`
+			Version        string      `json:"version,omitempty"`
+		} `json:"driver"`
+	} `json:"tool"`
+}
+
+// WriteSARIF writes the given issues as report in the Static Analysis Results Interchange Format (SARIF).
+// The version is the version of "golangci-lint" that found the issues.
+//
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html for more details about SARIF.
+func WriteSARIF(w io.Writer, issues []Issue, version string) error {
+	run := sarifRun{Results: []sarifResult{}}
+	run.Tool.Driver.InformationURI = "https://golangci-lint.run"
+	run.Tool.Driver.Name = "golangci-lint"
+	run.Tool.Driver.Version = strings.TrimPrefix(version, "v")
+
+	rules := make(map[string]bool)
+	for _, i := range issues {
+		if !rules[i.Linter] {
+			rules[i.Linter] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: i.Linter})
+		}
+
+		var loc sarifLocation
+		loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(i.File)
+		loc.PhysicalLocation.Region = sarifRegion{StartLine: i.Line, StartColumn: i.Column}
+		result := sarifResult{
+			Level:     sarifLevel(i.SeverityOrDefault()),
+			Locations: []sarifLocation{loc},
+			Message:   sarifMessage{Text: i.Message},
+			RuleID:    i.Linter,
+		}
+		if i.Fix != nil {
+			result.Fixes = []sarifFix{{ArtifactChanges: []sarifArtifactChange{{
+				ArtifactLocation: loc.PhysicalLocation.ArtifactLocation,
+				Replacements:     []sarifReplacement{sarifFixReplacement(i)},
+			}}}}
+		}
+		run.Results = append(run.Results, result)
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(a, b int) bool {
+		return run.Tool.Driver.Rules[a].ID < run.Tool.Driver.Rules[b].ID
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sarifReport{Runs: []sarifRun{run}, Schema: SARIFSchemaURI, Version: SARIFVersion}); err != nil {
+		return fmt.Errorf("encode SARIF report: %w", err)
+	}
+	return nil
+}
+
+// sarifFixReplacement returns the SARIF replacement for the suggested fix of the given issue.
+// Columns of SARIF regions are one-based while the start column of inline fixes is zero-based.
+func sarifFixReplacement(i Issue) sarifReplacement {
+	if i.Fix.Inline != nil {
+		return sarifReplacement{
+			DeletedRegion: sarifRegion{
+				EndColumn:   i.Fix.Inline.StartCol + i.Fix.Inline.Length + 1,
+				EndLine:     i.Fix.lineFrom,
+				StartColumn: i.Fix.Inline.StartCol + 1,
+				StartLine:   i.Fix.lineFrom,
+			},
+			InsertedContent: &sarifMessage{Text: i.Fix.Inline.NewString},
+		}
+	}
+
+	// Replace the affected lines including their line terminators.
+	r := sarifReplacement{
+		DeletedRegion: sarifRegion{EndColumn: 1, EndLine: i.Fix.lineTo + 1, StartColumn: 1, StartLine: i.Fix.lineFrom},
+	}
+	if !i.Fix.NeedOnlyDelete {
+		r.InsertedContent = &sarifMessage{Text: strings.Join(i.Fix.NewLines, "\n") + "\n"}
+	}
+	return r
+}
+
+// sarifLevel returns the SARIF result level for the given issue severity.
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "warning":
+		return "warning"
+	case "info", "note":
+		return "note"
+	case "none":
+		return "none"
+	}
+	return "error"
+}
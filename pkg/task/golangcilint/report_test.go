@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package golangcilint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		report  *Report
+		wantErr bool
+	}{
+		{
+			name: "issues with fixes",
+			output: `{"Issues":[` +
+				`{"FromLinter":"gofmt","Text":"File is not gofmt-ed","Severity":"",` +
+				`"SourceLines":["a :=  1"],"Pos":{"Filename":"main.go","Line":3,"Column":1},` +
+				`"LineRange":{"From":3,"To":4},"Replacement":{"NeedOnlyDelete":false,"NewLines":["a := 1"]}},` +
+				`{"FromLinter":"misspell","Text":"misspelling","Severity":"warning",` +
+				`"Pos":{"Filename":"doc.go","Line":7,"Column":4},` +
+				`"Replacement":{"Inline":{"StartCol":3,"Length":4,"NewString":"the"}}}],` +
+				`"Report":{"Warnings":[{"Tag":"runner","Text":"deprecated linter"},{"Text":"plain"}]}}`,
+			report: &Report{
+				Issues: []Issue{
+					{
+						Column:      1,
+						File:        "main.go",
+						Fix:         &SuggestedFix{NewLines: []string{"a := 1"}, lineFrom: 3, lineTo: 4},
+						Line:        3,
+						Linter:      "gofmt",
+						Message:     "File is not gofmt-ed",
+						SourceLines: []string{"a :=  1"},
+					},
+					{
+						Column:   4,
+						File:     "doc.go",
+						Fix:      &SuggestedFix{Inline: &InlineFix{Length: 4, NewString: "the", StartCol: 3}, lineFrom: 7, lineTo: 7},
+						Line:     7,
+						Linter:   "misspell",
+						Message:  "misspelling",
+						Severity: "warning",
+					},
+				},
+				Warnings: []string{"runner: deprecated linter", "plain"},
+			},
+		},
+		{
+			name:   "run error",
+			output: `{"Issues":null,"Report":{"Error":"can't load config"}}`,
+			report: &Report{Error: "can't load config"},
+		},
+		{
+			name:    "invalid JSON",
+			output:  "level=error msg=\"timeout\"",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := ParseOutput(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.report, report)
+		})
+	}
+}
+
+func TestWriteCheckstyle(t *testing.T) {
+	issues := []Issue{
+		{Column: 2, File: "b.go", Line: 1, Linter: "errcheck", Message: `unchecked "error"`},
+		{Column: 1, File: "a.go", Line: 5, Linter: "revive", Message: "exported", Severity: "warning"},
+		{Column: 3, File: "b.go", Line: 9, Linter: "govet", Message: "shadow"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCheckstyle(&buf, issues))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="a.go">
+    <error column="1" line="5" message="exported" severity="warning" source="revive"></error>
+  </file>
+  <file name="b.go">
+    <error column="2" line="1" message="unchecked &#34;error&#34;" severity="error" source="errcheck"></error>
+    <error column="3" line="9" message="shadow" severity="error" source="govet"></error>
+  </file>
+</checkstyle>
+`, buf.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	issues := []Issue{
+		{
+			Column:  4,
+			File:    "pkg/doc.go",
+			Fix:     &SuggestedFix{Inline: &InlineFix{Length: 4, NewString: "the", StartCol: 3}, lineFrom: 7, lineTo: 7},
+			Line:    7,
+			Linter:  "misspell",
+			Message: "misspelling",
+		},
+		{
+			Column:   1,
+			File:     "main.go",
+			Fix:      &SuggestedFix{NeedOnlyDelete: true, lineFrom: 3, lineTo: 4},
+			Line:     3,
+			Linter:   "gofmt",
+			Message:  "File is not gofmt-ed",
+			Severity: "info",
+		},
+		{Column: 2, File: "main.go", Line: 9, Linter: "gofmt", Message: "again", Severity: "warning"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, issues, "v1.50.1"))
+
+	var report sarifReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Equal(t, SARIFSchemaURI, report.Schema)
+	require.Equal(t, SARIFVersion, report.Version)
+	require.Len(t, report.Runs, 1)
+
+	run := report.Runs[0]
+	require.Equal(t, "1.50.1", run.Tool.Driver.Version)
+	require.Equal(t, []sarifRule{{ID: "gofmt"}, {ID: "misspell"}}, run.Tool.Driver.Rules)
+	require.Len(t, run.Results, 3)
+
+	levels := make([]string, 0, len(run.Results))
+	for _, r := range run.Results {
+		levels = append(levels, r.Level)
+	}
+	require.Equal(t, []string{"error", "note", "warning"}, levels)
+
+	inline := run.Results[0]
+	require.Equal(t, "pkg/doc.go", inline.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, sarifRegion{StartLine: 7, StartColumn: 4}, inline.Locations[0].PhysicalLocation.Region)
+	require.Equal(t, []sarifReplacement{{
+		DeletedRegion:   sarifRegion{EndColumn: 8, EndLine: 7, StartColumn: 4, StartLine: 7},
+		InsertedContent: &sarifMessage{Text: "the"},
+	}}, inline.Fixes[0].ArtifactChanges[0].Replacements)
+
+	deletion := run.Results[1]
+	require.Equal(t, []sarifReplacement{{
+		DeletedRegion: sarifRegion{EndColumn: 1, EndLine: 5, StartColumn: 1, StartLine: 3},
+	}}, deletion.Fixes[0].ArtifactChanges[0].Replacements)
+
+	require.Empty(t, run.Results[2].Fixes)
+}
+
+func TestWriteSARIFWithoutIssues(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, nil, ""))
+	require.Contains(t, buf.String(), `"results": []`)
+}
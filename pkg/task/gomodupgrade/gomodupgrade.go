@@ -6,6 +6,10 @@
 //
 // See https://pkg.go.dev/github.com/oligot/go-mod-upgrade for more details about "go-mod-upgrade".
 // The source code of "go-mod-upgrade" is available at https://github.com/oligot/go-mod-upgrade.
+//
+// Since "go-mod-upgrade" is interactive it is not suitable for non-interactive environments like CI pipelines.
+// Therefore this package also provides a report, based on the Go toolchain "list -m -u -json all" command, that
+// classifies available updates as patch, minor or major and can be written as Markdown or JSON.
 package gomodupgrade
 
 import (
@@ -0,0 +1,144 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package gomodupgrade
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ReportFormatNameJSON is the ReportFormat name for JSON reports.
+	ReportFormatNameJSON = "json"
+	// ReportFormatNameMarkdown is the ReportFormat name for Markdown reports.
+	ReportFormatNameMarkdown = "markdown"
+	// ReportFormatNameUnknown is the name for a unknown ReportFormat.
+	ReportFormatNameUnknown = "unknown"
+)
+
+const (
+	// ReportFormatMarkdown is the ReportFormat for Markdown reports.
+	ReportFormatMarkdown ReportFormat = iota
+	// ReportFormatJSON is the ReportFormat for JSON reports.
+	ReportFormatJSON
+)
+
+const (
+	// UpdateClassNameMajor is the UpdateClass name for major updates.
+	UpdateClassNameMajor = "major"
+	// UpdateClassNameMinor is the UpdateClass name for minor updates.
+	UpdateClassNameMinor = "minor"
+	// UpdateClassNamePatch is the UpdateClass name for patch updates.
+	UpdateClassNamePatch = "patch"
+	// UpdateClassNameUnknown is the name for a unknown UpdateClass.
+	UpdateClassNameUnknown = "unknown"
+)
+
+const (
+	// UpdateClassPatch is the UpdateClass for updates that only change the patch version.
+	UpdateClassPatch UpdateClass = iota
+	// UpdateClassMinor is the UpdateClass for updates that change the minor version.
+	UpdateClassMinor
+	// UpdateClassMajor is the UpdateClass for updates that change the major version, or the minor version of major
+	// version zero.
+	UpdateClassMajor
+)
+
+// ReportFormat defines the format of a dependency update report.
+type ReportFormat uint32
+
+// MarshalText returns the textual representation of itself.
+func (f ReportFormat) MarshalText() ([]byte, error) {
+	switch f {
+	case ReportFormatJSON:
+		return []byte(ReportFormatNameJSON), nil
+	case ReportFormatMarkdown:
+		return []byte(ReportFormatNameMarkdown), nil
+	}
+
+	return nil, fmt.Errorf("not a valid report format %d", f)
+}
+
+func (f ReportFormat) String() string {
+	if b, err := f.MarshalText(); err == nil {
+		return string(b)
+	}
+	return ReportFormatNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (f *ReportFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseReportFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = parsed
+	return nil
+}
+
+// ParseReportFormat takes a report format name and returns the ReportFormat constant.
+// The name "md" is accepted as alias for ReportFormatMarkdown.
+func ParseReportFormat(name string) (ReportFormat, error) {
+	switch strings.ToLower(name) {
+	case ReportFormatNameJSON:
+		return ReportFormatJSON, nil
+	case ReportFormatNameMarkdown, "md":
+		return ReportFormatMarkdown, nil
+	}
+
+	var f ReportFormat
+	return f, fmt.Errorf("not a valid report format: %q", name)
+}
+
+// UpdateClass defines the class of a dependency update based on the semantic version difference.
+// Higher values indicate updates that are more likely to contain breaking changes.
+type UpdateClass uint32
+
+// MarshalText returns the textual representation of itself.
+func (c UpdateClass) MarshalText() ([]byte, error) {
+	switch c {
+	case UpdateClassMajor:
+		return []byte(UpdateClassNameMajor), nil
+	case UpdateClassMinor:
+		return []byte(UpdateClassNameMinor), nil
+	case UpdateClassPatch:
+		return []byte(UpdateClassNamePatch), nil
+	}
+
+	return nil, fmt.Errorf("not a valid update class %d", c)
+}
+
+func (c UpdateClass) String() string {
+	if b, err := c.MarshalText(); err == nil {
+		return string(b)
+	}
+	return UpdateClassNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (c *UpdateClass) UnmarshalText(text []byte) error {
+	parsed, err := ParseUpdateClass(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
+
+// ParseUpdateClass takes a update class name and returns the UpdateClass constant.
+func ParseUpdateClass(name string) (UpdateClass, error) {
+	switch strings.ToLower(name) {
+	case UpdateClassNameMajor:
+		return UpdateClassMajor, nil
+	case UpdateClassNameMinor:
+		return UpdateClassMinor, nil
+	case UpdateClassNamePatch:
+		return UpdateClassPatch, nil
+	}
+
+	var c UpdateClass
+	return c, fmt.Errorf("not a valid update class: %q", name)
+}
@@ -5,6 +5,8 @@ package gomodupgrade
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -26,6 +28,9 @@ const (
 // Option is a task option.
 type Option func(*Options)
 
+// ReportOption is a option for the non-interactive dependency update report.
+type ReportOption func(*ReportOptions)
+
 // Options are task options.
 type Options struct {
 	// env is the task specific environment.
@@ -98,3 +103,146 @@ func WithModuleVersion(version *semver.Version) Option {
 		}
 	}
 }
+
+// ReportOptions are options for the non-interactive dependency update report.
+type ReportOptions struct {
+	// ApplyClass is the highest class of updates that are applied.
+	ApplyClass UpdateClass
+
+	// EnableApply indicates whether updates up to the ApplyClass should be applied using the Go toolchain "get" command.
+	EnableApply bool
+
+	// EnableDirect indicates whether updates of direct dependencies should be included.
+	EnableDirect bool
+
+	// EnableIndirect indicates whether updates of indirect dependencies should be included.
+	EnableIndirect bool
+
+	// EnableMajorProbe indicates whether the module proxy should be queried for new major versions that use a different
+	// module path, e.g. "example.com/mod/v2" for "example.com/mod".
+	EnableMajorProbe bool
+
+	// Env is the report specific environment.
+	Env map[string]string
+
+	// Format is the format of the report file.
+	Format ReportFormat
+
+	// GoProxy is the value of the "GOPROXY" environment variable used to query for updates.
+	// Defaults to the value of the current environment.
+	GoProxy string
+
+	// OutputFile is the path to the report file.
+	// No report file is written when empty.
+	OutputFile string
+
+	// WorkingDir is the path to the module directory.
+	// Defaults to the project root directory.
+	WorkingDir string
+}
+
+// NewReportOptions creates new report options.
+func NewReportOptions(opts ...ReportOption) *ReportOptions {
+	opt := &ReportOptions{
+		EnableDirect: true,
+		Env:          make(map[string]string),
+		Format:       ReportFormatMarkdown,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// IncludesDependency checks whether updates of a direct or indirect dependency are included based on the configured
+// dependency filters.
+func (o *ReportOptions) IncludesDependency(indirect bool) bool {
+	if indirect {
+		return o.EnableIndirect
+	}
+	return o.EnableDirect
+}
+
+// Applies checks whether the given update should be applied.
+// Updates to a different module path, like new major versions found by probing, and updates of replaced modules are
+// never applied since they require changes to import paths or the "replace" directives of the "go.mod" file.
+func (o *ReportOptions) Applies(u Update) bool {
+	return o.EnableApply && u.Class <= o.ApplyClass && u.UpdatePath == u.Path && !u.Replaced
+}
+
+// WithReportApply sets the highest class of updates that are applied, e.g. UpdateClassMinor to apply all minor and patch
+// updates.
+func WithReportApply(class UpdateClass) ReportOption {
+	return func(o *ReportOptions) {
+		o.ApplyClass = class
+		o.EnableApply = true
+	}
+}
+
+// WithReportDirect indicates whether updates of direct dependencies should be included.
+func WithReportDirect(enableDirect bool) ReportOption {
+	return func(o *ReportOptions) {
+		o.EnableDirect = enableDirect
+	}
+}
+
+// WithReportEnv sets the report specific environment.
+func WithReportEnv(env map[string]string) ReportOption {
+	return func(o *ReportOptions) {
+		o.Env = env
+	}
+}
+
+// WithReportFormat sets the format of the report file.
+func WithReportFormat(format ReportFormat) ReportOption {
+	return func(o *ReportOptions) {
+		o.Format = format
+	}
+}
+
+// WithReportGoProxy sets the value of the "GOPROXY" environment variable used to query for updates.
+func WithReportGoProxy(goProxy string) ReportOption {
+	return func(o *ReportOptions) {
+		o.GoProxy = goProxy
+	}
+}
+
+// WithReportGoProxyDir sets the path to a local module proxy directory, e.g. for air-gapped environments.
+// Relative paths are resolved against the working directory of the current process.
+func WithReportGoProxyDir(dir string) ReportOption {
+	return func(o *ReportOptions) {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		o.GoProxy = (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String()
+	}
+}
+
+// WithReportIndirect indicates whether updates of indirect dependencies should be included.
+func WithReportIndirect(enableIndirect bool) ReportOption {
+	return func(o *ReportOptions) {
+		o.EnableIndirect = enableIndirect
+	}
+}
+
+// WithReportMajorProbe indicates whether the module proxy should be queried for new major versions that use a
+// different module path.
+func WithReportMajorProbe(enableMajorProbe bool) ReportOption {
+	return func(o *ReportOptions) {
+		o.EnableMajorProbe = enableMajorProbe
+	}
+}
+
+// WithReportOutputFile sets the path to the report file.
+func WithReportOutputFile(path string) ReportOption {
+	return func(o *ReportOptions) {
+		o.OutputFile = path
+	}
+}
+
+// WithReportWorkingDir sets the path to the module directory.
+func WithReportWorkingDir(dir string) ReportOption {
+	return func(o *ReportOptions) {
+		o.WorkingDir = dir
+	}
+}
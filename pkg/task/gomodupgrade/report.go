@@ -0,0 +1,275 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package gomodupgrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"golang.org/x/mod/module"
+
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+)
+
+// LookupError is an error that occurred while looking up updates for a module.
+type LookupError struct {
+	// Err is the error message.
+	Err string `json:"err"`
+
+	// Path is the module path.
+	Path string `json:"path"`
+}
+
+// Report is a non-interactive dependency update report.
+type Report struct {
+	// LookupErrors are errors that occurred while looking up updates, e.g. when a module proxy does not provide
+	// information about a module.
+	LookupErrors []LookupError `json:"lookupErrors,omitempty"`
+
+	// MainModule is the path of the main module.
+	MainModule string `json:"mainModule"`
+
+	// Updates are all available updates sorted by class, from major to patch, and module path.
+	Updates []Update `json:"updates"`
+}
+
+// ByClass returns all updates of the given class.
+func (r *Report) ByClass(class UpdateClass) []Update {
+	var updates []Update
+	for _, u := range r.Updates {
+		if u.Class == class {
+			updates = append(updates, u)
+		}
+	}
+	return updates
+}
+
+// Write writes the report in the given format.
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportFormatJSON:
+		return r.WriteJSON(w)
+	case ReportFormatMarkdown:
+		return r.WriteMarkdown(w)
+	}
+	return fmt.Errorf("not a valid report format %d", format)
+}
+
+// WriteJSON writes the report in JSON format.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes the report in Markdown format.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Dependency Updates for `%s`\n\n", r.MainModule)
+
+	if len(r.Updates) == 0 {
+		b.WriteString("All dependencies are up to date.\n")
+	} else {
+		r.writeMarkdownUpdates(&b)
+	}
+
+	if len(r.LookupErrors) > 0 {
+		b.WriteString("\n## Lookup Errors\n\n")
+		for _, le := range r.LookupErrors {
+			fmt.Fprintf(&b, "- `%s`: %s\n", le.Path, strings.Join(strings.Fields(le.Err), " "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownUpdates writes the table of all updates in Markdown format.
+func (r *Report) writeMarkdownUpdates(b *strings.Builder) {
+	fmt.Fprintf(
+		b, "%d updates available: %d major, %d minor, %d patch.\n\n",
+		len(r.Updates), len(r.ByClass(UpdateClassMajor)), len(r.ByClass(UpdateClassMinor)),
+		len(r.ByClass(UpdateClassPatch)),
+	)
+	b.WriteString("| Module | Current | Update | Class | Dependency | Applied |\n")
+	b.WriteString("| ------ | ------- | ------ | ----- | ---------- | ------- |\n")
+	for _, u := range r.Updates {
+		dep := "direct"
+		if u.Indirect {
+			dep = "indirect"
+		}
+		applied := "no"
+		if u.Applied {
+			applied = "yes"
+		}
+		update := fmt.Sprintf("`%s`", u.UpdateVersion)
+		if u.UpdatePath != u.Path {
+			update = fmt.Sprintf("`%s@%s`", u.UpdatePath, u.UpdateVersion)
+		}
+		fmt.Fprintf(b, "| `%s` | `%s` | %s | %s | %s | %s |\n", u.Path, u.Version, update, u.Class, dep, applied)
+	}
+}
+
+// Update is an available update of a dependency.
+type Update struct {
+	// Applied indicates whether the update has been applied.
+	Applied bool `json:"applied"`
+
+	// Class is the class of the update.
+	Class UpdateClass `json:"class"`
+
+	// Deprecated is the deprecation message of the current module version, if any.
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// Indirect indicates whether the module is only an indirect dependency of the main module.
+	Indirect bool `json:"indirect"`
+
+	// Path is the module path.
+	Path string `json:"path"`
+
+	// Replaced indicates whether the module is replaced through a "replace" directive.
+	Replaced bool `json:"replaced"`
+
+	// UpdatePath is the module path of the update.
+	// It only differs from Path for new major versions with a different module path.
+	UpdatePath string `json:"updatePath"`
+
+	// UpdateVersion is the version of the update.
+	UpdateVersion string `json:"updateVersion"`
+
+	// Version is the current module version.
+	Version string `json:"version"`
+}
+
+// ClassifyUpdate returns the class of the update from the current to the given version.
+// Note that minor version changes of major version zero are classified as UpdateClassMajor since these versions are not
+// considered to be stable and may therefore contain breaking changes.
+//
+// See https://semver.org/#spec-item-4 for more details.
+func ClassifyUpdate(current, update string) (UpdateClass, error) {
+	cv, err := semver.NewVersion(current)
+	if err != nil {
+		return UpdateClassPatch, fmt.Errorf("parse version %q: %w", current, err)
+	}
+	uv, err := semver.NewVersion(update)
+	if err != nil {
+		return UpdateClassPatch, fmt.Errorf("parse version %q: %w", update, err)
+	}
+
+	switch {
+	case uv.Major() != cv.Major():
+		return UpdateClassMajor, nil
+	case uv.Minor() != cv.Minor() && cv.Major() == 0:
+		return UpdateClassMajor, nil
+	case uv.Minor() != cv.Minor():
+		return UpdateClassMinor, nil
+	}
+	return UpdateClassPatch, nil
+}
+
+// NextMajorPath returns the module path of the next major version for the module with the given path and version,
+// e.g. "example.com/mod/v2" for "example.com/mod" at version "v1.2.3" or "gopkg.in/yaml.v3" for "gopkg.in/yaml.v2".
+// It returns false when the path has no valid major version suffix.
+func NextMajorPath(path, version string) (string, bool) {
+	prefix, pathMajor, ok := module.SplitPathVersion(path)
+	if !ok {
+		return "", false
+	}
+
+	var major uint64
+	if pathMajor != "" {
+		m, err := strconv.ParseUint(strings.TrimLeft(pathMajor, "/.v"), 10, 64)
+		if err != nil {
+			return "", false
+		}
+		major = m
+	} else {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			return "", false
+		}
+		major = v.Major()
+	}
+	if major < 1 {
+		major = 1
+	}
+
+	sep := "/v"
+	if strings.HasPrefix(pathMajor, ".") {
+		sep = ".v"
+	}
+	return fmt.Sprintf("%s%s%d", prefix, sep, major+1), true
+}
+
+// NewReport creates a new report from the modules listed by the Go toolchain "list -m -u -json" command.
+// The probes are the modules listed for the paths of the next major versions, see NextMajorPath, where modules with
+// errors are ignored since the next major version does not exist.
+// Only updates of dependencies that are included by the given options are added.
+func NewReport(modules, probes []taskGoList.Module, opts *ReportOptions) *Report {
+	probed := make(map[string]taskGoList.Module)
+	for _, p := range probes {
+		if p.Error == nil && p.Version != "" {
+			probed[p.Path] = p
+		}
+	}
+
+	r := &Report{}
+	for _, m := range modules {
+		if m.Main {
+			r.MainModule = m.Path
+			continue
+		}
+		if !opts.IncludesDependency(m.Indirect) {
+			continue
+		}
+		if m.Error != nil {
+			r.LookupErrors = append(r.LookupErrors, LookupError{Err: m.Error.Err, Path: m.Path})
+		}
+
+		if m.Update != nil {
+			if class, err := ClassifyUpdate(m.Version, m.Update.Version); err == nil {
+				r.Updates = append(r.Updates, newUpdate(m, m.Update, class))
+			}
+		}
+		if next, ok := NextMajorPath(m.Path, m.Version); ok {
+			if p, ok := probed[next]; ok {
+				r.Updates = append(r.Updates, newUpdate(m, &p, UpdateClassMajor))
+			}
+		}
+	}
+
+	sort.SliceStable(r.Updates, func(i, j int) bool {
+		if r.Updates[i].Class != r.Updates[j].Class {
+			return r.Updates[i].Class > r.Updates[j].Class
+		}
+		return r.Updates[i].Path < r.Updates[j].Path
+	})
+	return r
+}
+
+// newUpdate creates a new update for the given module.
+func newUpdate(m taskGoList.Module, update *taskGoList.Module, class UpdateClass) Update {
+	updatePath := update.Path
+	if updatePath == "" {
+		updatePath = m.Path
+	}
+	return Update{
+		Class:         class,
+		Deprecated:    m.Deprecated,
+		Indirect:      m.Indirect,
+		Path:          m.Path,
+		Replaced:      m.Replace != nil,
+		UpdatePath:    updatePath,
+		UpdateVersion: update.Version,
+		Version:       m.Version,
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package gomodupgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	taskGoList "github.com/svengreb/wand/pkg/task/golang/list"
+)
+
+func TestClassifyUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		update  string
+		class   UpdateClass
+		wantErr bool
+	}{
+		{name: "patch", current: "v1.2.3", update: "v1.2.4", class: UpdateClassPatch},
+		{name: "minor", current: "v1.2.3", update: "v1.3.0", class: UpdateClassMinor},
+		{name: "major", current: "v1.2.3", update: "v2.0.0", class: UpdateClassMajor},
+		{name: "major version zero patch", current: "v0.2.3", update: "v0.2.4", class: UpdateClassPatch},
+		{name: "major version zero minor", current: "v0.2.3", update: "v0.3.0", class: UpdateClassMajor},
+		{name: "major version zero to one", current: "v0.9.1", update: "v1.0.0", class: UpdateClassMajor},
+		{
+			name:    "pseudo-version",
+			current: "v0.0.0-20210101000000-abcdefabcdef",
+			update:  "v0.0.0-20220101000000-fedcbafedcba",
+			class:   UpdateClassPatch,
+		},
+		{name: "invalid current version", current: "latest", update: "v1.0.0", wantErr: true},
+		{name: "invalid update version", current: "v1.0.0", update: "master", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			class, err := ClassifyUpdate(tc.current, tc.update)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.class, class)
+		})
+	}
+}
+
+func TestNextMajorPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		version string
+		next    string
+		ok      bool
+	}{
+		{name: "major version one", path: "example.com/mod", version: "v1.2.3", next: "example.com/mod/v2", ok: true},
+		{name: "major version zero", path: "example.com/mod", version: "v0.2.3", next: "example.com/mod/v2", ok: true},
+		{name: "major version suffix", path: "example.com/mod/v2", version: "v2.0.1", next: "example.com/mod/v3", ok: true},
+		{name: "gopkg.in", path: "gopkg.in/yaml.v2", version: "v2.4.0", next: "gopkg.in/yaml.v3", ok: true},
+		{name: "invalid path", path: "example.com/mod/v1", version: "v1.0.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := NextMajorPath(tc.path, tc.version)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.next, next)
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	modules := []taskGoList.Module{
+		{Main: true, Path: "example.com/app"},
+		{Path: "example.com/a", Version: "v1.0.0", Update: &taskGoList.Module{Version: "v1.0.1"}},
+		{Path: "example.com/b", Version: "v0.1.0", Update: &taskGoList.Module{Version: "v0.2.0"}},
+		{Path: "example.com/c", Version: "v1.0.0", Indirect: true, Update: &taskGoList.Module{Version: "v1.1.0"}},
+		{Path: "example.com/d", Version: "v1.0.0", Error: &taskGoList.ModuleError{Err: "not found"}},
+	}
+	probes := []taskGoList.Module{
+		{Path: "example.com/a/v2", Version: "v2.0.0"},
+		{Path: "example.com/d/v2", Error: &taskGoList.ModuleError{Err: "not found"}},
+	}
+
+	report := NewReport(modules, probes, &ReportOptions{EnableDirect: true})
+	require.Equal(t, "example.com/app", report.MainModule)
+	require.Equal(t, []LookupError{{Err: "not found", Path: "example.com/d"}}, report.LookupErrors)
+
+	type update struct {
+		class UpdateClass
+		path  string
+		to    string
+	}
+	var updates []update
+	for _, u := range report.Updates {
+		updates = append(updates, update{class: u.Class, path: u.UpdatePath, to: u.UpdateVersion})
+	}
+	require.Equal(t, []update{
+		{class: UpdateClassMajor, path: "example.com/a/v2", to: "v2.0.0"},
+		{class: UpdateClassMajor, path: "example.com/b", to: "v0.2.0"},
+		{class: UpdateClassPatch, path: "example.com/a", to: "v1.0.1"},
+	}, updates)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package generic provides a task for arbitrary Go module commands like "golang.org/x/tools/cmd/stringer",
+// "github.com/golang/mock/mockgen" or "golang.org/x/vuln/cmd/govulncheck".
+// It allows to run any Go module-based "main" package through the [github.com/svengreb/wand/pkg/task/gotool.Runner]
+// without the need to implement a dedicated [task.GoModule].
+package generic
+
+import (
+	"fmt"
+
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Task is a task for an arbitrary Go module command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+func (t *Task) BuildParams() []string {
+	return t.opts.args
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// ID returns the identifier of the Go module.
+func (t *Task) ID() *project.GoModuleID {
+	return t.opts.goModule
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindGoModule
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the working directory of the command.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the Go module command with the given import path.
+// The path must be a valid Go module import path, that can optionally include the version suffix, in the "pkg@version"
+// format. The latest version is used when the suffix is omitted.
+// It returns an error of type *task.ErrTask when the import path is not valid.
+func New(importPath string, opts ...Option) (*Task, error) {
+	gm, gmErr := project.GoModuleFromImportPath(importPath)
+	if gmErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("parse Go module import path %q: %w", importPath, gmErr),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+	if gm.Path == "" {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("empty Go module path in import path %q", importPath),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	return &Task{opts: NewOptions(gm, opts...)}, nil
+}
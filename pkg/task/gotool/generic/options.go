@@ -0,0 +1,78 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package generic
+
+import (
+	"github.com/svengreb/wand/pkg/project"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// args are arguments passed to the command.
+	args []string
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// goModule is the Go module identifier.
+	goModule *project.GoModuleID
+
+	// name is the task name.
+	name string
+
+	// workingDir is the path to the working directory of the command.
+	workingDir string
+}
+
+// NewOptions creates new task options for the given Go module.
+// The task name defaults to the name of the compiled executable of the Go module.
+func NewOptions(goModule *project.GoModuleID, opts ...Option) *Options {
+	opt := &Options{
+		env:      make(map[string]string),
+		goModule: goModule,
+		name:     goModule.ExecName(),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// WithArgs sets additional arguments to pass to the command.
+func WithArgs(args ...string) Option {
+	return func(o *Options) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		for k, v := range env {
+			o.env[k] = v
+		}
+	}
+}
+
+// WithName sets the task name.
+// Defaults to the name of the compiled executable of the Go module.
+func WithName(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.name = name
+		}
+	}
+}
+
+// WithWorkingDir sets the path to the working directory of the command.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
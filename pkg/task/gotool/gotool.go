@@ -104,10 +104,10 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/magefile/mage/sh"
 	glFS "github.com/svengreb/golib/pkg/io/fs"
 
 	osSupport "github.com/svengreb/wand/internal/support/os"
+	shSupport "github.com/svengreb/wand/internal/support/sh"
 	"github.com/svengreb/wand/pkg/project"
 	"github.com/svengreb/wand/pkg/task"
 	taskGo "github.com/svengreb/wand/pkg/task/golang"
@@ -209,6 +209,21 @@ type Runner struct {
 	opts     *RunnerOptions
 }
 
+// Executable returns the path to the cached executable of the given Go module.
+// The executable is installed into the cache directory first when it does not exist yet.
+// It returns an error of type *task.ErrRunner when any error occurs during the installation.
+func (r *Runner) Executable(goModule *project.GoModuleID) (string, error) {
+	execPath, err := r.prepareExec(goModule, r.opts.Env)
+	if err != nil {
+		return "", &task.ErrRunner{
+			Err:  fmt.Errorf("runner %q: %w", RunnerName, err),
+			Kind: task.ErrRun,
+		}
+	}
+
+	return execPath, nil
+}
+
 // Handles returns the supported task kind.
 func (r *Runner) Handles() task.Kind {
 	return task.KindGoModule
@@ -219,7 +234,7 @@ func (r *Runner) Handles() task.Kind {
 //
 // // See https://pkg.go.dev/cmd/go#hdr-Compile_and_install_packages_and_dependencies for more details.
 func (r *Runner) Install(goModule *project.GoModuleID) error {
-	_, err := r.prepareExec(goModule)
+	_, err := r.prepareExec(goModule, r.opts.Env)
 	if err != nil {
 		return &task.ErrRunner{
 			Err:  fmt.Errorf("runner %q: %w", RunnerName, err),
@@ -233,16 +248,16 @@ func (r *Runner) Install(goModule *project.GoModuleID) error {
 // Run runs the command.
 // It returns an error of type *task.ErrRunner when any error occurs during the command execution.
 func (r *Runner) Run(t task.Task) error {
-	tGM, tErr := r.prepareTask(t)
+	tGM, env, tErr := r.prepareTask(t)
 	if tErr != nil {
 		return fmt.Errorf("runner %q: %w", RunnerName, tErr)
 	}
 
 	if !r.opts.enableCache {
-		return r.run(tGM, tGM.BuildParams()...)
+		return r.run(tGM, env, tGM.BuildParams()...)
 	}
 
-	execPath, preExecErr := r.prepareExec(tGM.ID())
+	execPath, preExecErr := r.prepareExec(tGM.ID(), env)
 	if preExecErr != nil {
 		return &task.ErrRunner{
 			Err:  fmt.Errorf("runner %q: %w", RunnerName, preExecErr),
@@ -250,8 +265,9 @@ func (r *Runner) Run(t task.Task) error {
 		}
 	}
 
+	dir := workingDir(t)
 	if r.opts.Quiet {
-		if err := sh.RunWith(r.opts.Env, execPath, tGM.BuildParams()...); err != nil {
+		if err := shSupport.RunWith(env, dir, execPath, tGM.BuildParams()...); err != nil {
 			return &task.ErrRunner{
 				Err:  fmt.Errorf("run task %q: %w", t.Name(), err),
 				Kind: task.ErrRun,
@@ -259,7 +275,7 @@ func (r *Runner) Run(t task.Task) error {
 		}
 		return nil
 	}
-	if err := sh.RunWithV(r.opts.Env, execPath, tGM.BuildParams()...); err != nil {
+	if err := shSupport.RunWithV(env, dir, execPath, tGM.BuildParams()...); err != nil {
 		return &task.ErrRunner{
 			Err:  fmt.Errorf("run task %q: %w", t.Name(), err),
 			Kind: task.ErrRun,
@@ -270,13 +286,15 @@ func (r *Runner) Run(t task.Task) error {
 
 // RunOut runs the command and returns its output.
 // It returns an error of type *task.ErrRunner when any error occurs during the command execution.
+// Note that the output is also returned when the command failed since some commands report findings as part of their
+// machine-readable output and indicate them with a non-zero exit code.
 func (r *Runner) RunOut(t task.Task) (string, error) {
-	tGM, tErr := r.prepareTask(t)
+	tGM, env, tErr := r.prepareTask(t)
 	if tErr != nil {
 		return "", fmt.Errorf("runner %q: %w", RunnerName, tErr)
 	}
 
-	execPath, preExecErr := r.prepareExec(tGM.ID())
+	execPath, preExecErr := r.prepareExec(tGM.ID(), env)
 	if preExecErr != nil {
 		return "", &task.ErrRunner{
 			Err:  fmt.Errorf("runner %q: %w", RunnerName, preExecErr),
@@ -284,9 +302,9 @@ func (r *Runner) RunOut(t task.Task) (string, error) {
 		}
 	}
 
-	out, runErr := sh.OutputWith(r.opts.Env, execPath, tGM.BuildParams()...)
+	out, runErr := shSupport.OutputWith(env, workingDir(t), execPath, tGM.BuildParams()...)
 	if runErr != nil {
-		return "", &task.ErrRunner{
+		return out, &task.ErrRunner{
 			Err:  fmt.Errorf("run task %q: %w", t.Name(), runErr),
 			Kind: task.ErrRun,
 		}
@@ -313,9 +331,9 @@ func (r *Runner) buildExecDir(goModule *project.GoModuleID) string {
 
 // install installs the compiled executable of a Go module-based "main" package.
 // It returns an error of type *task.ErrRunner when any error occurs during the installation.
-func (r *Runner) install(execDir string, goModule *project.GoModuleID) error {
+func (r *Runner) install(execDir string, goModule *project.GoModuleID, taskEnv map[string]string) error {
 	env := osSupport.EnvSliceToMap(os.Environ())
-	for k, v := range r.opts.Env {
+	for k, v := range taskEnv {
 		env[k] = v
 	}
 	// Override the "GOBIN" environment variable to use the given path for the compiled executable.
@@ -335,7 +353,8 @@ func (r *Runner) install(execDir string, goModule *project.GoModuleID) error {
 }
 
 // prepareExec prepares the ensure that the executable exists and returns the path.
-func (r *Runner) prepareExec(goModule *project.GoModuleID) (string, error) {
+// The given environment is used when the executable must be installed.
+func (r *Runner) prepareExec(goModule *project.GoModuleID, env map[string]string) (string, error) {
 	execDir := r.buildExecDir(goModule)
 	execPath := filepath.Join(execDir, goModule.ExecName())
 
@@ -349,7 +368,7 @@ func (r *Runner) prepareExec(goModule *project.GoModuleID) (string, error) {
 			return "", fmt.Errorf("create directory structure %q for execuable: %w", execDir, err)
 		}
 
-		if err := r.install(execDir, goModule); err != nil {
+		if err := r.install(execDir, goModule, env); err != nil {
 			return "", fmt.Errorf("install executable %q: %w", execPath, err)
 		}
 	}
@@ -358,28 +377,34 @@ func (r *Runner) prepareExec(goModule *project.GoModuleID) (string, error) {
 }
 
 // prepareTask checks if the given task is of type task.GoModule and prepares the task specific environment.
+// The returned environment is a copy of the runner environment merged with the task specific environment so that the
+// environment of a task does not leak into subsequently run tasks.
 // It returns an error of type *task.ErrRunner when any error occurs during the execution.
-func (r *Runner) prepareTask(t task.Task) (task.GoModule, error) {
+func (r *Runner) prepareTask(t task.Task) (task.GoModule, map[string]string, error) {
 	tGM, ok := t.(task.GoModule)
 	if t.Kind() != task.KindGoModule || !ok {
-		return nil, &task.ErrRunner{
+		return nil, nil, &task.ErrRunner{
 			Err:  fmt.Errorf("expected %q but got %q", r.Handles(), t.Kind()),
 			Kind: task.ErrUnsupportedTaskKind,
 		}
 	}
 
+	env := make(map[string]string, len(r.opts.Env))
+	for k, v := range r.opts.Env {
+		env[k] = v
+	}
 	for k, v := range tGM.Env() {
-		r.opts.Env[k] = v
+		env[k] = v
 	}
 
-	return tGM, nil
+	return tGM, env, nil
 }
 
 // run runs a Go module-based "main" package.
 // It returns an error of type [*task.ErrRunner] when any error occurs during the execution.
-func (r *Runner) run(gm task.GoModule, args ...string) error {
+func (r *Runner) run(gm task.GoModule, taskEnv map[string]string, args ...string) error {
 	env := osSupport.EnvSliceToMap(os.Environ())
-	for k, v := range r.opts.Env {
+	for k, v := range taskEnv {
 		env[k] = v
 	}
 
@@ -388,6 +413,7 @@ func (r *Runner) run(gm task.GoModule, args ...string) error {
 		taskGoRun.WithModulePath(gm.ID().Path),
 		taskGoRun.WithModuleVersion(gm.ID().Version),
 		taskGoRun.WithArgs(args...),
+		taskGoRun.WithWorkingDir(workingDir(gm)),
 	)
 
 	if err := r.goRunner.Run(t); err != nil {
@@ -396,6 +422,15 @@ func (r *Runner) run(gm task.GoModule, args ...string) error {
 	return nil
 }
 
+// workingDir returns the working directory of the given task or an empty string when the task does not implement
+// task.WorkingDir.
+func workingDir(t task.Task) string {
+	if tWD, ok := t.(task.WorkingDir); ok {
+		return tWD.WorkingDir()
+	}
+	return ""
+}
+
 // NewRunner creates a new command runner for Go module-based tools.
 // It returns an error of type *task.ErrRunner when any error occurs during the creation.
 func NewRunner(goRunner *taskGo.Runner, opts ...RunnerOption) (*Runner, error) {
@@ -0,0 +1,86 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package govulncheck provides a task for the "golang.org/x/vuln/cmd/govulncheck" Go module command.
+// "govulncheck" reports known vulnerabilities that affect Go code. It uses static analysis of source code or a binary's
+// symbol table to narrow down reports to only those that could affect the application.
+// The JSON output of the command is parsed into a typed Report that can be evaluated against a Policy with an
+// allowlist. Local vulnerability database directories are supported as well to allow scans in air-gapped
+// environments.
+//
+// See https://pkg.go.dev/golang.org/x/vuln/cmd/govulncheck for more details about "govulncheck".
+// The source code of "govulncheck" is available at https://go.googlesource.com/vuln.
+package govulncheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Task is a task for the "golang.org/x/vuln/cmd/govulncheck" Go module command.
+type Task struct {
+	opts *Options
+}
+
+// BuildParams builds the parameters.
+// Note that the JSON output format is always enabled in order to parse the results.
+func (t *Task) BuildParams() []string {
+	params := []string{"-json"}
+
+	if t.opts.db != "" {
+		params = append(params, fmt.Sprintf("-db=%s", t.opts.db))
+	}
+
+	params = append(params, fmt.Sprintf("-scan=%s", t.opts.scanLevel))
+
+	if len(t.opts.tags) > 0 {
+		params = append(params, fmt.Sprintf("-tags=%s", strings.Join(t.opts.tags, ",")))
+	}
+
+	// Include additionally configured arguments.
+	params = append(params, t.opts.extraArgs...)
+
+	return append(params, t.opts.pkgs...)
+}
+
+// Env returns the task specific environment.
+func (t *Task) Env() map[string]string {
+	return t.opts.env
+}
+
+// ID returns the identifier of the Go module.
+func (t *Task) ID() *project.GoModuleID {
+	return t.opts.goModule
+}
+
+// Kind returns the task kind.
+func (t *Task) Kind() task.Kind {
+	return task.KindGoModule
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// WorkingDir returns the path to the working directory of the command.
+func (t *Task) WorkingDir() string {
+	return t.opts.workingDir
+}
+
+// New creates a new task for the "golang.org/x/vuln/cmd/govulncheck" Go module command.
+func New(opts ...Option) (*Task, error) {
+	opt, optErr := NewOptions(opts...)
+	if optErr != nil {
+		return nil, optErr
+	}
+	return &Task{opts: opt}, nil
+}
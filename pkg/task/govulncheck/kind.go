@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package govulncheck
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ReachabilityNameModule is the Reachability name for vulnerabilities in required modules.
+	ReachabilityNameModule = "module"
+	// ReachabilityNamePackage is the Reachability name for vulnerabilities in imported packages.
+	ReachabilityNamePackage = "package"
+	// ReachabilityNameSymbol is the Reachability name for vulnerabilities in called symbols.
+	ReachabilityNameSymbol = "symbol"
+	// ReachabilityNameUnknown is the name for a unknown Reachability.
+	ReachabilityNameUnknown = "unknown"
+)
+
+const (
+	// ReachabilityModule is the Reachability for vulnerabilities in modules that are required by the code.
+	ReachabilityModule Reachability = iota
+	// ReachabilityPackage is the Reachability for vulnerabilities in packages that are imported by the code.
+	ReachabilityPackage
+	// ReachabilitySymbol is the Reachability for vulnerabilities in symbols that are called by the code.
+	ReachabilitySymbol
+)
+
+const (
+	// SeverityNameCritical is the Severity name for critical vulnerabilities.
+	SeverityNameCritical = "critical"
+	// SeverityNameHigh is the Severity name for high vulnerabilities.
+	SeverityNameHigh = "high"
+	// SeverityNameLow is the Severity name for low vulnerabilities.
+	SeverityNameLow = "low"
+	// SeverityNameModerate is the Severity name for moderate vulnerabilities.
+	SeverityNameModerate = "moderate"
+	// SeverityNameUnknown is the Severity name for vulnerabilities without severity information.
+	SeverityNameUnknown = "unknown"
+)
+
+const (
+	// SeverityUnknown is the Severity for vulnerabilities without severity information.
+	SeverityUnknown Severity = iota
+	// SeverityLow is the Severity for low vulnerabilities.
+	SeverityLow
+	// SeverityModerate is the Severity for moderate vulnerabilities.
+	SeverityModerate
+	// SeverityHigh is the Severity for high vulnerabilities.
+	SeverityHigh
+	// SeverityCritical is the Severity for critical vulnerabilities.
+	SeverityCritical
+)
+
+// Reachability defines how deep vulnerable code is reachable from the scanned code.
+// Higher values indicate a more precise and therefore more relevant finding.
+type Reachability uint32
+
+// MarshalText returns the textual representation of itself.
+func (r Reachability) MarshalText() ([]byte, error) {
+	switch r {
+	case ReachabilityModule:
+		return []byte(ReachabilityNameModule), nil
+	case ReachabilityPackage:
+		return []byte(ReachabilityNamePackage), nil
+	case ReachabilitySymbol:
+		return []byte(ReachabilityNameSymbol), nil
+	}
+
+	return nil, fmt.Errorf("not a valid reachability %d", r)
+}
+
+func (r Reachability) String() string {
+	if b, err := r.MarshalText(); err == nil {
+		return string(b)
+	}
+	return ReachabilityNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (r *Reachability) UnmarshalText(text []byte) error {
+	parsed, err := ParseReachability(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+	return nil
+}
+
+// ParseReachability takes a reachability name and returns the Reachability constant.
+func ParseReachability(name string) (Reachability, error) {
+	switch strings.ToLower(name) {
+	case ReachabilityNameModule:
+		return ReachabilityModule, nil
+	case ReachabilityNamePackage:
+		return ReachabilityPackage, nil
+	case ReachabilityNameSymbol:
+		return ReachabilitySymbol, nil
+	}
+
+	var r Reachability
+	return r, fmt.Errorf("not a valid reachability: %q", name)
+}
+
+// Severity defines the severity of a vulnerability.
+type Severity uint32
+
+// MarshalText returns the textual representation of itself.
+func (s Severity) MarshalText() ([]byte, error) {
+	switch s {
+	case SeverityUnknown:
+		return []byte(SeverityNameUnknown), nil
+	case SeverityLow:
+		return []byte(SeverityNameLow), nil
+	case SeverityModerate:
+		return []byte(SeverityNameModerate), nil
+	case SeverityHigh:
+		return []byte(SeverityNameHigh), nil
+	case SeverityCritical:
+		return []byte(SeverityNameCritical), nil
+	}
+
+	return nil, fmt.Errorf("not a valid severity %d", s)
+}
+
+func (s Severity) String() string {
+	if b, err := s.MarshalText(); err == nil {
+		return string(b)
+	}
+	return SeverityNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+	return nil
+}
+
+// ParseSeverity takes a severity name and returns the Severity constant.
+// The name "medium" is accepted as alias for SeverityNameModerate to support CVSS qualitative severity ratings.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(name) {
+	case SeverityNameUnknown:
+		return SeverityUnknown, nil
+	case SeverityNameLow:
+		return SeverityLow, nil
+	case SeverityNameModerate, "medium":
+		return SeverityModerate, nil
+	case SeverityNameHigh:
+		return SeverityHigh, nil
+	case SeverityNameCritical:
+		return SeverityCritical, nil
+	}
+
+	var s Severity
+	return s, fmt.Errorf("not a valid severity: %q", name)
+}
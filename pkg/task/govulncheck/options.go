@@ -0,0 +1,210 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package govulncheck
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+const (
+	// DefaultGoModulePath is the default module import path.
+	DefaultGoModulePath = "golang.org/x/vuln/cmd/govulncheck"
+
+	// DefaultGoModuleVersion is the default module version.
+	DefaultGoModuleVersion = "v1.0.1"
+
+	// taskName is the name of the task.
+	taskName = "govulncheck"
+)
+
+// DefaultPkgs are the default package patterns to scan.
+var DefaultPkgs = []string{"./..."}
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// allowlistFile is the path to the file with identifiers of vulnerabilities that are accepted by the Policy.
+	allowlistFile string
+
+	// db is the URL of the vulnerability database.
+	db string
+
+	// env is the task specific environment.
+	env map[string]string
+
+	// extraArgs are additional arguments passed to the command.
+	extraArgs []string
+
+	// goModule is the Go module identifier.
+	goModule *project.GoModuleID
+
+	// name is the task name.
+	name string
+
+	// pkgs are the package patterns to scan.
+	pkgs []string
+
+	// Policy is the policy to decide which findings should fail the task.
+	Policy Policy
+
+	// scanLevel is the level of the scan.
+	scanLevel Reachability
+
+	// tags are Go build tags.
+	tags []string
+
+	// workingDir is the path to the working directory of the command.
+	workingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) (*Options, error) {
+	version, versionErr := semver.NewVersion(DefaultGoModuleVersion)
+	if versionErr != nil {
+		return nil, &task.ErrTask{
+			Err:  fmt.Errorf("parsing default module version %q: %w", DefaultGoModulePath, versionErr),
+			Kind: task.ErrInvalidTaskOpts,
+		}
+	}
+
+	opt := &Options{
+		env: make(map[string]string),
+		goModule: &project.GoModuleID{
+			Path:    DefaultGoModulePath,
+			Version: version,
+		},
+		name:      taskName,
+		Policy:    DefaultPolicy,
+		scanLevel: ReachabilitySymbol,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if len(opt.pkgs) == 0 {
+		opt.pkgs = append(opt.pkgs, DefaultPkgs...)
+	}
+
+	if opt.allowlistFile != "" {
+		allowlist, allowlistErr := LoadAllowlist(opt.allowlistFile)
+		if allowlistErr != nil {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("load allowlist: %w", allowlistErr),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		opt.Policy.Allowlist = append(opt.Policy.Allowlist, allowlist...)
+	}
+
+	return opt, nil
+}
+
+// WithAllowlistFile sets the path to the file with identifiers of vulnerabilities that are accepted by the policy.
+// See LoadAllowlist for details about the file format.
+func WithAllowlistFile(path string) Option {
+	return func(o *Options) {
+		o.allowlistFile = path
+	}
+}
+
+// WithDB sets the URL of the vulnerability database.
+// Defaults to the official Go vulnerability database at https://vuln.go.dev.
+func WithDB(dbURL string) Option {
+	return func(o *Options) {
+		o.db = dbURL
+	}
+}
+
+// WithDBDir sets the path to a local vulnerability database directory, e.g. for air-gapped environments.
+// Relative paths are resolved against the working directory of the current process.
+func WithDBDir(dir string) Option {
+	return func(o *Options) {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		o.db = (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String()
+	}
+}
+
+// WithEnv sets the task specific environment.
+func WithEnv(env map[string]string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithExtraArgs sets additional arguments to pass to the command.
+func WithExtraArgs(extraArgs ...string) Option {
+	return func(o *Options) {
+		o.extraArgs = append(o.extraArgs, extraArgs...)
+	}
+}
+
+// WithModulePath sets the module import path.
+// Defaults to DefaultGoModulePath.
+func WithModulePath(path string) Option {
+	return func(o *Options) {
+		if path != "" {
+			o.goModule.Path = path
+		}
+	}
+}
+
+// WithModuleVersion sets the module version.
+// Defaults to DefaultGoModuleVersion.
+func WithModuleVersion(version *semver.Version) Option {
+	return func(o *Options) {
+		if version != nil {
+			o.goModule.Version = version
+		}
+	}
+}
+
+// WithPkgs sets the package patterns to scan.
+// Defaults to DefaultPkgs.
+func WithPkgs(pkgs ...string) Option {
+	return func(o *Options) {
+		o.pkgs = append(o.pkgs, pkgs...)
+	}
+}
+
+// WithPolicy sets the policy to decide which findings should fail the task.
+// Defaults to DefaultPolicy.
+func WithPolicy(policy Policy) Option {
+	return func(o *Options) {
+		o.Policy = policy
+	}
+}
+
+// WithScanLevel sets the level of the scan.
+// Defaults to ReachabilitySymbol.
+func WithScanLevel(level Reachability) Option {
+	return func(o *Options) {
+		o.scanLevel = level
+	}
+}
+
+// WithTags sets Go build tags.
+func WithTags(tags ...string) Option {
+	return func(o *Options) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// WithWorkingDir sets the path to the working directory of the command.
+// Defaults to the working directory of the current process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.workingDir = dir
+	}
+}
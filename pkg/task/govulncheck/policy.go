@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package govulncheck
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrPolicyViolation indicates that vulnerabilities violate the policy.
+const ErrPolicyViolation = wErr.ErrString("vulnerabilities violate policy")
+
+// DefaultPolicy is the default policy that fails for vulnerabilities of any severity in symbols that are called by the
+// scanned code, which matches the default behavior of "govulncheck".
+var DefaultPolicy = Policy{
+	FailOnReachability: ReachabilitySymbol,
+	FailOnSeverity:     SeverityUnknown,
+}
+
+// Policy decides which vulnerabilities should fail a scan.
+type Policy struct {
+	// Allowlist are identifiers, or aliases like CVE or GHSA identifiers, of accepted vulnerabilities.
+	Allowlist []string
+
+	// FailOnReachability is the minimum reachability of vulnerabilities to fail a scan.
+	FailOnReachability Reachability
+
+	// FailOnSeverity is the minimum severity of vulnerabilities to fail a scan.
+	// Note that vulnerabilities without severity information only fail a scan when the value is SeverityUnknown.
+	FailOnSeverity Severity
+}
+
+// Allows indicates whether the given vulnerability is accepted through the allowlist.
+func (p Policy) Allows(v Vulnerability) bool {
+	for _, id := range p.Allowlist {
+		if strings.EqualFold(id, v.ID) {
+			return true
+		}
+		for _, alias := range v.Aliases {
+			if strings.EqualFold(id, alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Evaluate returns all vulnerabilities of the report that violate the policy.
+func (p Policy) Evaluate(r *Report) []Vulnerability {
+	var violations []Vulnerability
+	for _, v := range r.Vulnerabilities() {
+		if v.Reachability < p.FailOnReachability || v.Severity < p.FailOnSeverity || p.Allows(v) {
+			continue
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// LoadAllowlist loads the identifiers of accepted vulnerabilities from the file at the given path.
+// The file contains one identifier, or alias like a CVE or GHSA identifier, per line. Empty lines and text following a
+// "#" character are ignored which allows to document the reason for accepting a vulnerability, e.g.:
+//
+//	# Not reachable through our usage of the affected API.
+//	GO-2023-1840
+//	CVE-2023-29403 # Fixed in the next Go release.
+func LoadAllowlist(path string) ([]string, error) {
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return nil, fmt.Errorf("open allowlist file %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read allowlist file %q: %w", path, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package govulncheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Config is the configuration of a "govulncheck" scan.
+type Config struct {
+	// DB is the URL of the vulnerability database.
+	DB string `json:"db,omitempty"`
+
+	// DBLastModified is the time when the vulnerability database has been modified the last time.
+	DBLastModified string `json:"db_last_modified,omitempty"`
+
+	// GoVersion is the version of the Go toolchain used for the scan.
+	GoVersion string `json:"go_version,omitempty"`
+
+	// ProtocolVersion is the version of the JSON output protocol.
+	ProtocolVersion string `json:"protocol_version,omitempty"`
+
+	// ScanLevel is the level of the scan.
+	ScanLevel string `json:"scan_level,omitempty"`
+
+	// ScannerName is the name of the scanner.
+	ScannerName string `json:"scanner_name,omitempty"`
+
+	// ScannerVersion is the version of the scanner.
+	ScannerVersion string `json:"scanner_version,omitempty"`
+}
+
+// Finding is a vulnerability finding with the call stack from the vulnerable code to the scanned code.
+type Finding struct {
+	// FixedVersion is the module version that fixes the vulnerability.
+	// The value is empty when no fix is available.
+	FixedVersion string `json:"fixed_version,omitempty"`
+
+	// OSV is the identifier of the OSV entry of the vulnerability.
+	OSV string `json:"osv"`
+
+	// Trace is the call stack starting at the vulnerable code.
+	Trace []Frame `json:"trace,omitempty"`
+}
+
+// Reachability returns how deep the vulnerable code is reachable from the scanned code.
+func (f Finding) Reachability() Reachability {
+	if len(f.Trace) == 0 {
+		return ReachabilityModule
+	}
+	switch {
+	case f.Trace[0].Function != "":
+		return ReachabilitySymbol
+	case f.Trace[0].Package != "":
+		return ReachabilityPackage
+	default:
+		return ReachabilityModule
+	}
+}
+
+// Frame is a single frame of a Finding call stack.
+type Frame struct {
+	// Function is the name of the function.
+	Function string `json:"function,omitempty"`
+
+	// Module is the path of the module.
+	Module string `json:"module"`
+
+	// Package is the import path of the package.
+	Package string `json:"package,omitempty"`
+
+	// Position is the position of the call within a source file.
+	Position *Position `json:"position,omitempty"`
+
+	// Receiver is the name of the receiver type of a method.
+	Receiver string `json:"receiver,omitempty"`
+
+	// Version is the version of the module.
+	Version string `json:"version,omitempty"`
+}
+
+// Symbol returns the fully qualified name of the symbol.
+func (f Frame) Symbol() string {
+	switch {
+	case f.Function == "":
+		return ""
+	case f.Receiver != "":
+		return fmt.Sprintf("%s.%s.%s", f.Package, strings.TrimPrefix(f.Receiver, "*"), f.Function)
+	default:
+		return fmt.Sprintf("%s.%s", f.Package, f.Function)
+	}
+}
+
+// Position is a position within a source file.
+type Position struct {
+	// Column is the column number starting at 1.
+	Column int `json:"column,omitempty"`
+
+	// Filename is the name of the source file.
+	Filename string `json:"filename,omitempty"`
+
+	// Line is the line number starting at 1.
+	Line int `json:"line,omitempty"`
+
+	// Offset is the byte offset starting at 0.
+	Offset int `json:"offset,omitempty"`
+}
+
+// OSV is an entry of the vulnerability database in the Open Source Vulnerability format.
+//
+// See https://ossf.github.io/osv-schema for more details.
+type OSV struct {
+	// Aliases are identifiers of the same vulnerability in other databases, e.g. CVE or GHSA identifiers.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// DatabaseSpecific are additional information specific to the vulnerability database.
+	DatabaseSpecific struct {
+		// Severity is the qualitative severity rating, e.g. as used by GitHub security advisories.
+		Severity string `json:"severity,omitempty"`
+
+		// URL is the URL of the vulnerability report.
+		URL string `json:"url,omitempty"`
+	} `json:"database_specific,omitempty"`
+
+	// Details is the detailed description of the vulnerability.
+	Details string `json:"details,omitempty"`
+
+	// ID is the unique identifier of the vulnerability.
+	ID string `json:"id"`
+
+	// Severity are the quantitative severity ratings of the vulnerability.
+	Severity []struct {
+		// Score is the severity score, e.g. a CVSS vector string.
+		Score string `json:"score"`
+
+		// Type is the type of the severity score, e.g. "CVSS_V3".
+		Type string `json:"type"`
+	} `json:"severity,omitempty"`
+
+	// Summary is the short summary of the vulnerability.
+	Summary string `json:"summary,omitempty"`
+}
+
+// SeverityLevel returns the severity of the vulnerability.
+// The quantitative CVSS v3 scores take precedence over qualitative ratings of the database, while SeverityUnknown is
+// returned when the entry has no severity information. Note that the official Go vulnerability database does not
+// provide severity information.
+func (o OSV) SeverityLevel() Severity {
+	for _, s := range o.Severity {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if score, err := cvss3BaseScore(s.Score); err == nil {
+			return severityFromScore(score)
+		}
+	}
+	if sev, err := ParseSeverity(o.DatabaseSpecific.Severity); err == nil {
+		return sev
+	}
+	return SeverityUnknown
+}
+
+// Report is the parsed result of a "govulncheck" scan.
+type Report struct {
+	// Config is the configuration of the scan.
+	Config Config
+
+	// Findings are all vulnerability findings.
+	Findings []Finding
+
+	// OSVs are all OSV entries that are referenced by Findings mapped by their identifier.
+	OSVs map[string]OSV
+
+	// Violations are the Vulnerabilities that violate the Policy.
+	// Note that this is only populated when the report has been evaluated against a policy.
+	Violations []Vulnerability
+}
+
+// Vulnerabilities returns all findings aggregated by the OSV identifier and affected module.
+// The returned vulnerabilities are sorted by their identifier and module.
+func (r *Report) Vulnerabilities() []Vulnerability {
+	type key struct{ id, module string }
+	idx := make(map[key]int)
+	var vulns []Vulnerability
+
+	for _, f := range r.Findings {
+		var module, version string
+		if len(f.Trace) > 0 {
+			module, version = f.Trace[0].Module, f.Trace[0].Version
+		}
+		k := key{id: f.OSV, module: module}
+		i, ok := idx[k]
+		if !ok {
+			osv := r.OSVs[f.OSV]
+			vulns = append(vulns, Vulnerability{
+				Aliases:      osv.Aliases,
+				FixedVersion: f.FixedVersion,
+				ID:           f.OSV,
+				Module:       module,
+				Reachability: f.Reachability(),
+				Severity:     osv.SeverityLevel(),
+				Summary:      osv.Summary,
+				URL:          osv.DatabaseSpecific.URL,
+				Version:      version,
+			})
+			i = len(vulns) - 1
+			idx[k] = i
+		}
+
+		v := &vulns[i]
+		if reach := f.Reachability(); reach > v.Reachability {
+			v.Reachability = reach
+		}
+		if sym := f.Trace; len(sym) > 0 && sym[0].Symbol() != "" && !containsString(v.Symbols, sym[0].Symbol()) {
+			v.Symbols = append(v.Symbols, sym[0].Symbol())
+		}
+	}
+
+	sort.Slice(vulns, func(i, j int) bool {
+		if vulns[i].ID != vulns[j].ID {
+			return vulns[i].ID < vulns[j].ID
+		}
+		return vulns[i].Module < vulns[j].Module
+	})
+	for i := range vulns {
+		sort.Strings(vulns[i].Symbols)
+	}
+	return vulns
+}
+
+// Vulnerability is a vulnerability of a module aggregated from all related findings.
+type Vulnerability struct {
+	// Aliases are identifiers of the same vulnerability in other databases, e.g. CVE or GHSA identifiers.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// FixedVersion is the module version that fixes the vulnerability.
+	// The value is empty when no fix is available.
+	FixedVersion string `json:"fixed_version,omitempty"`
+
+	// ID is the identifier of the OSV entry.
+	ID string `json:"id"`
+
+	// Module is the path of the affected module.
+	Module string `json:"module"`
+
+	// Reachability is the highest reachability of all findings.
+	Reachability Reachability `json:"reachability"`
+
+	// Severity is the severity of the vulnerability.
+	Severity Severity `json:"severity"`
+
+	// Summary is the short summary of the vulnerability.
+	Summary string `json:"summary,omitempty"`
+
+	// Symbols are the fully qualified names of affected symbols that are called by the scanned code.
+	Symbols []string `json:"symbols,omitempty"`
+
+	// URL is the URL of the vulnerability report.
+	URL string `json:"url,omitempty"`
+
+	// Version is the version of the affected module that is used by the scanned code.
+	Version string `json:"version,omitempty"`
+}
+
+// message is a single message of the JSON output stream.
+type message struct {
+	Config  *Config  `json:"config,omitempty"`
+	Finding *Finding `json:"finding,omitempty"`
+	OSV     *OSV     `json:"osv,omitempty"`
+}
+
+// ParseOutput parses the JSON output stream of the "govulncheck" command.
+func ParseOutput(r io.Reader) (*Report, error) {
+	report := &Report{OSVs: make(map[string]OSV)}
+
+	dec := json.NewDecoder(r)
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode JSON output: %w", err)
+		}
+
+		switch {
+		case msg.Config != nil:
+			report.Config = *msg.Config
+		case msg.OSV != nil:
+			report.OSVs[msg.OSV.ID] = *msg.OSV
+		case msg.Finding != nil:
+			report.Findings = append(report.Findings, *msg.Finding)
+		}
+	}
+
+	return report, nil
+}
+
+// containsString indicates whether the slice contains the given string.
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// cvss3BaseScore computes the base score of the given CVSS v3 vector string.
+//
+// See https://www.first.org/cvss/v3.1/specification-document#7-4-Metric-Values for more details.
+func cvss3BaseScore(vector string) (float64, error) {
+	weights := map[string]map[string]float64{
+		"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+		"AC": {"L": 0.77, "H": 0.44},
+		"UI": {"N": 0.85, "R": 0.62},
+		"C":  {"H": 0.56, "L": 0.22, "N": 0},
+		"I":  {"H": 0.56, "L": 0.22, "N": 0},
+		"A":  {"H": 0.56, "L": 0.22, "N": 0},
+	}
+
+	metrics := make(map[string]string)
+	for _, part := range strings.Split(vector, "/") {
+		if kv := strings.SplitN(part, ":", 2); len(kv) == 2 {
+			metrics[kv[0]] = kv[1]
+		}
+	}
+	if !strings.HasPrefix(metrics["CVSS"], "3") {
+		return 0, fmt.Errorf("not a CVSS v3 vector: %q", vector)
+	}
+
+	values := make(map[string]float64)
+	for metric, w := range weights {
+		v, ok := w[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("invalid or missing metric %q in CVSS vector %q", metric, vector)
+		}
+		values[metric] = v
+	}
+
+	scopeChanged := metrics["S"] == "C"
+	if metrics["S"] != "C" && metrics["S"] != "U" {
+		return 0, fmt.Errorf("invalid or missing metric %q in CVSS vector %q", "S", vector)
+	}
+	pr := map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}
+	if scopeChanged {
+		pr = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+	}
+	prValue, ok := pr[metrics["PR"]]
+	if !ok {
+		return 0, fmt.Errorf("invalid or missing metric %q in CVSS vector %q", "PR", vector)
+	}
+
+	iss := 1 - (1-values["C"])*(1-values["I"])*(1-values["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+	exploitability := 8.22 * values["AV"] * values["AC"] * prValue * values["UI"]
+
+	if scopeChanged {
+		return cvssRoundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return cvssRoundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// cvssRoundUp returns the smallest number, specified to one decimal place, that is equal to or higher than its input.
+//
+// See https://www.first.org/cvss/v3.1/specification-document#Appendix-A---Floating-Point-Rounding for more details.
+func cvssRoundUp(v float64) float64 {
+	i := int(math.Round(v * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return (math.Floor(float64(i)/10000) + 1) / 10
+}
+
+// severityFromScore returns the Severity for the given CVSS score based on the qualitative severity rating scale.
+//
+// See https://www.first.org/cvss/v3.1/specification-document#5-Qualitative-Severity-Rating-Scale for more details.
+func severityFromScore(score float64) Severity {
+	switch {
+	case score >= 9:
+		return SeverityCritical
+	case score >= 7:
+		return SeverityHigh
+	case score >= 4:
+		return SeverityModerate
+	case score > 0:
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
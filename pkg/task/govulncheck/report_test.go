@@ -0,0 +1,186 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package govulncheck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCVSS3BaseScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		vector  string
+		score   float64
+		wantErr bool
+	}{
+		{name: "critical", vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", score: 9.8},
+		{name: "scope changed", vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", score: 10},
+		{name: "scope changed rounded up", vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", score: 6.1},
+		{name: "moderate", vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:L/I:N/A:N", score: 4.3},
+		{name: "version 3.0", vector: "CVSS:3.0/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:N/A:N", score: 5.9},
+		{name: "no impact", vector: "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:N/I:N/A:N", score: 0},
+		{name: "version 2", vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P", wantErr: true},
+		{name: "missing scope", vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/C:H/I:H/A:H", wantErr: true},
+		{name: "invalid metric value", vector: "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			score, err := cvss3BaseScore(tc.vector)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.score, score)
+		})
+	}
+}
+
+func TestOSVSeverityLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		osv      string
+		severity Severity
+	}{
+		{
+			name:     "cvss score",
+			osv:      `{"id":"GO-1","severity":[{"type":"CVSS_V3","score":"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}]}`,
+			severity: SeverityCritical,
+		},
+		{
+			name: "cvss score takes precedence over database rating",
+			osv: `{"id":"GO-1","database_specific":{"severity":"LOW"},` +
+				`"severity":[{"type":"CVSS_V3","score":"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:L/I:N/A:N"}]}`,
+			severity: SeverityModerate,
+		},
+		{
+			name:     "database rating",
+			osv:      `{"id":"GO-1","database_specific":{"severity":"HIGH"}}`,
+			severity: SeverityHigh,
+		},
+		{
+			name:     "invalid cvss score falls back to database rating",
+			osv:      `{"id":"GO-1","database_specific":{"severity":"low"},"severity":[{"type":"CVSS_V3","score":"invalid"}]}`,
+			severity: SeverityLow,
+		},
+		{
+			name:     "unknown",
+			osv:      `{"id":"GO-1"}`,
+			severity: SeverityUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := ParseOutput(strings.NewReader(`{"osv":` + tc.osv + `}`))
+			require.NoError(t, err)
+			require.Equal(t, tc.severity, report.OSVs["GO-1"].SeverityLevel())
+		})
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		vulns   []Vulnerability
+		wantErr bool
+	}{
+		{
+			name: "aggregated findings",
+			output: `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck","scan_level":"symbol"}}
+{"osv":{"id":"GO-2023-0002","aliases":["CVE-2023-0002"],"summary":"Second",` +
+				`"database_specific":{"url":"https://pkg.go.dev/vuln/GO-2023-0002"}}}
+{"osv":{"id":"GO-2023-0001","summary":"First"}}
+{"finding":{"osv":"GO-2023-0002","fixed_version":"v1.2.0","trace":[{"module":"example.com/b","version":"v1.1.0"}]}}
+{"finding":{"osv":"GO-2023-0002","fixed_version":"v1.2.0",` +
+				`"trace":[{"module":"example.com/b","version":"v1.1.0","package":"example.com/b/pkg"}]}}
+{"finding":{"osv":"GO-2023-0002","fixed_version":"v1.2.0","trace":[{"module":"example.com/b","version":"v1.1.0",` +
+				`"package":"example.com/b/pkg","function":"Do","receiver":"*Client"},{"module":"example.com/app"}]}}
+{"finding":{"osv":"GO-2023-0001","trace":[{"module":"example.com/a","version":"v0.1.0","package":"example.com/a"}]}}
+`,
+			vulns: []Vulnerability{
+				{
+					ID:           "GO-2023-0001",
+					Module:       "example.com/a",
+					Reachability: ReachabilityPackage,
+					Severity:     SeverityUnknown,
+					Summary:      "First",
+					Version:      "v0.1.0",
+				},
+				{
+					Aliases:      []string{"CVE-2023-0002"},
+					FixedVersion: "v1.2.0",
+					ID:           "GO-2023-0002",
+					Module:       "example.com/b",
+					Reachability: ReachabilitySymbol,
+					Severity:     SeverityUnknown,
+					Summary:      "Second",
+					Symbols:      []string{"example.com/b/pkg.Client.Do"},
+					URL:          "https://pkg.go.dev/vuln/GO-2023-0002",
+					Version:      "v1.1.0",
+				},
+			},
+		},
+		{
+			name:   "empty output",
+			output: "",
+		},
+		{
+			name:    "invalid JSON",
+			output:  `{"config":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := ParseOutput(strings.NewReader(tc.output))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.vulns, report.Vulnerabilities())
+		})
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	report := &Report{
+		Findings: []Finding{
+			{OSV: "GO-1", Trace: []Frame{{Module: "example.com/a"}}},
+			{OSV: "GO-2", Trace: []Frame{{Module: "example.com/b", Package: "example.com/b", Function: "F"}}},
+		},
+		OSVs: map[string]OSV{
+			"GO-1": {ID: "GO-1"},
+			"GO-2": {ID: "GO-2", Aliases: []string{"CVE-2"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		ids    []string
+	}{
+		{name: "default policy", policy: DefaultPolicy, ids: []string{"GO-2"}},
+		{name: "module reachability", policy: Policy{FailOnReachability: ReachabilityModule}, ids: []string{"GO-1", "GO-2"}},
+		{name: "allowlisted alias", policy: Policy{Allowlist: []string{"cve-2"}}, ids: []string{"GO-1"}},
+		{name: "severity threshold", policy: Policy{FailOnSeverity: SeverityHigh}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var ids []string
+			for _, v := range tc.policy.Evaluate(report) {
+				ids = append(ids, v.ID)
+			}
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
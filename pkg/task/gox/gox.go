@@ -10,8 +10,12 @@
 package gox
 
 import (
+	"bytes"
 	"fmt"
+	"path"
+	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/svengreb/wand/pkg/app"
 	"github.com/svengreb/wand/pkg/project"
@@ -27,7 +31,7 @@ type Task struct {
 
 // BuildParams builds the parameters.
 func (t *Task) BuildParams() []string {
-	params := taskGo.BuildGoOptions(t.opts.taskGoOpts...)
+	params := t.opts.Params()
 
 	// Workaround to allow the usage of the "-trimpath" flag that has been introduced in Go 1.13.0.
 	// The currently latest version of "gox" does not support the flag yet.
@@ -49,6 +53,10 @@ func (t *Task) BuildParams() []string {
 		params = append(params, "-verbose")
 	}
 
+	if t.opts.Cgo != nil && t.opts.Cgo.IsEnabled() {
+		params = append(params, "-cgo")
+	}
+
 	if t.opts.goCmd != "" {
 		params = append(params, fmt.Sprintf("-gocmd=%s", t.opts.goCmd))
 	}
@@ -67,8 +75,37 @@ func (t *Task) BuildParams() []string {
 }
 
 // Env returns the task specific environment.
+// When cgo options are set the cgo environment is merged where the C toolchain is only included when there is a single
+// cross-compile platform target. Variables of the task specific environment take precedence.
 func (t *Task) Env() map[string]string {
-	return t.opts.env
+	if t.opts.Cgo == nil {
+		return t.opts.env
+	}
+
+	var platform string
+	if len(t.opts.CrossCompileTargetPlatforms) == 1 {
+		platform = t.opts.CrossCompileTargetPlatforms[0]
+	}
+	env := t.opts.Cgo.Env(platform)
+	for k, v := range t.opts.env {
+		env[k] = v
+	}
+	return env
+}
+
+// ForPlatform returns a copy of the task for the given cross-compile platform target in the "os/arch" format.
+// This allows to run the task once per platform target, e.g. to use a different C toolchain for each cgo-enabled
+// platform target.
+func (t *Task) ForPlatform(platform string) *Task {
+	opts := *t.opts
+	goBuildOpts := *t.opts.Options
+	goBuildOpts.CrossCompileTargetPlatforms = []string{platform}
+	opts.Options = &goBuildOpts
+	opts.env = make(map[string]string, len(t.opts.env))
+	for k, v := range t.opts.env {
+		opts.env[k] = v
+	}
+	return &Task{ac: t.ac, opts: &opts}
 }
 
 // ID returns the identifier of the Go module.
@@ -91,6 +128,29 @@ func (t *Task) Options() task.Options {
 	return *t.opts
 }
 
+// OutputPath returns the path to the binary artifact for the given cross-compile platform target in the "os/arch"
+// format by rendering the output template the same way "gox" does, including the ".exe" suffix for Windows targets.
+func (t *Task) OutputPath(platform string) (string, error) {
+	goos, goarch, ok := strings.Cut(platform, "/")
+	if !ok {
+		return "", fmt.Errorf("not a valid platform %q, expected format is \"os/arch\"", platform)
+	}
+
+	tmpl, parseErr := template.New("output").Parse(t.opts.outputTemplate)
+	if parseErr != nil {
+		return "", fmt.Errorf("parse output template %q: %w", t.opts.outputTemplate, parseErr)
+	}
+	var name bytes.Buffer
+	data := struct{ Arch, Dir, OS string }{Arch: goarch, Dir: path.Base(t.ac.PkgImportPath), OS: goos}
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("render output template %q: %w", t.opts.outputTemplate, err)
+	}
+	if goos == "windows" {
+		name.WriteString(".exe")
+	}
+	return filepath.Join(t.opts.OutputDir, name.String()), nil
+}
+
 // New creates a new task for the "github.com/mitchellh/gox" Go module command.
 //nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
 func New(ac app.Config, opts ...Option) (*Task, error) {
@@ -100,7 +100,11 @@ func NewOptions(opts ...Option) (*Options, error) {
 		[]taskGoBuild.Option{taskGoBuild.WithGoOptions(opt.taskGoOpts...)},
 		opt.taskGoBuildOpts...,
 	)
-	opt.Options = taskGoBuild.NewOptions(goBuildOpts...)
+	goBuildOptions, goBuildOptsErr := taskGoBuild.NewOptions(goBuildOpts...)
+	if goBuildOptsErr != nil {
+		return nil, goBuildOptsErr
+	}
+	opt.Options = goBuildOptions
 
 	if opt.outputTemplate == "" && opt.BinaryArtifactName != "" {
 		opt.outputTemplate = DefaultCrossCompileBinaryNameTemplate(opt.BinaryArtifactName)
@@ -0,0 +1,306 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// layoutBlobsDirName is the name of the directory of content-addressable blobs in an image layout.
+	layoutBlobsDirName = "blobs"
+
+	// layoutFileName is the name of the file that marks the root of an image layout.
+	layoutFileName = "oci-layout"
+
+	// layoutIndexFileName is the name of the file that is the entry point of an image layout.
+	layoutIndexFileName = "index.json"
+
+	// mediaTypeDockerManifest is the media type of a Docker image manifest which is also supported for base images.
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// mediaTypeDockerManifestList is the media type of a Docker manifest list which is also supported for base images.
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// baseImage is an image of a base layout for a single platform.
+type baseImage struct {
+	image  Image
+	layers []Descriptor
+	layout layout
+}
+
+// layout is an image layout on disk.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/image-layout.md for more details.
+type layout struct {
+	dir string
+}
+
+// blobPath returns the path to the blob with the given digest.
+func (l layout) blobPath(digest string) (string, error) {
+	algo, encoded, ok := strings.Cut(digest, ":")
+	if !ok || algo == "" || encoded == "" || strings.ContainsAny(encoded, `/\.`) {
+		return "", fmt.Errorf("not a valid digest %q", digest)
+	}
+	return filepath.Join(l.dir, layoutBlobsDirName, algo, encoded), nil
+}
+
+// copyBlob copies the blob of the given descriptor from the given layout.
+func (l layout) copyBlob(src layout, d Descriptor) error {
+	srcPath, srcErr := src.blobPath(d.Digest)
+	if srcErr != nil {
+		return srcErr
+	}
+	dstPath, dstErr := l.blobPath(d.Digest)
+	if dstErr != nil {
+		return dstErr
+	}
+	if _, err := os.Stat(dstPath); err == nil {
+		return nil
+	}
+
+	data, readErr := os.ReadFile(srcPath)
+	if readErr != nil {
+		return fmt.Errorf("read blob %q: %w", d.Digest, readErr)
+	}
+	// Base images could use other digest algorithms than the one used for blobs written by the task.
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(dstPath), err)
+	}
+	//nolint:gosec // The image layout is meant to be world-readable.
+	if err := os.WriteFile(dstPath, data, 0o644); err != nil {
+		return fmt.Errorf("write blob %q: %w", d.Digest, err)
+	}
+	return nil
+}
+
+// init creates the directory structure and the "oci-layout" file of the layout.
+func (l layout) init() error {
+	blobsDir := filepath.Join(l.dir, layoutBlobsDirName, "sha256")
+	if err := os.MkdirAll(blobsDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", blobsDir, err)
+	}
+	return l.writeFile(layoutFileName, Layout{Version: LayoutVersion})
+}
+
+// readBlob reads the blob of the given descriptor and decodes it as JSON into the given value.
+func (l layout) readBlob(d Descriptor, v interface{}) error {
+	p, pathErr := l.blobPath(d.Digest)
+	if pathErr != nil {
+		return pathErr
+	}
+	data, readErr := os.ReadFile(p)
+	if readErr != nil {
+		return fmt.Errorf("read blob %q: %w", d.Digest, readErr)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode blob %q: %w", d.Digest, err)
+	}
+	return nil
+}
+
+// readIndex reads the "index.json" file of the layout.
+func (l layout) readIndex() (*Index, error) {
+	data, readErr := os.ReadFile(filepath.Join(l.dir, layoutIndexFileName))
+	if readErr != nil {
+		return nil, fmt.Errorf("read image layout index: %w", readErr)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("decode image layout index: %w", err)
+	}
+	return &idx, nil
+}
+
+// resolveBase resolves the image for the given platform from the layout.
+// Only images with the given reference name are considered unless it is empty which requires the layout to contain a
+// single image.
+func (l layout) resolveBase(refName string, p Platform) (*baseImage, error) {
+	idx, idxErr := l.readIndex()
+	if idxErr != nil {
+		return nil, idxErr
+	}
+
+	var candidates []Descriptor
+	refNames := make(map[string]bool)
+	for _, d := range idx.Manifests {
+		name := d.Annotations[AnnotationRefName]
+		if refName == "" || name == refName {
+			candidates = append(candidates, d)
+			refNames[name] = true
+		}
+	}
+	switch {
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("no image with reference name %q in base layout %q", refName, l.dir)
+	case len(refNames) > 1:
+		return nil, fmt.Errorf("base layout %q contains multiple images, a reference name is required", l.dir)
+	}
+
+	for _, d := range candidates {
+		base, err := l.resolveBaseManifest(d, p)
+		if err != nil {
+			return nil, err
+		}
+		if base != nil {
+			return base, nil
+		}
+	}
+	return nil, fmt.Errorf("no image for platform %q in base layout %q", p, l.dir)
+}
+
+// resolveBaseManifest resolves the image for the given platform from the manifest or index of the given descriptor.
+// It returns nil when the descriptor does not reference an image for the platform.
+func (l layout) resolveBaseManifest(d Descriptor, p Platform) (*baseImage, error) {
+	switch d.MediaType {
+	case MediaTypeIndex, mediaTypeDockerManifestList:
+		var idx Index
+		if err := l.readBlob(d, &idx); err != nil {
+			return nil, err
+		}
+		for _, md := range idx.Manifests {
+			base, err := l.resolveBaseManifest(md, p)
+			if err != nil || base != nil {
+				return base, err
+			}
+		}
+		return nil, nil
+
+	case MediaTypeManifest, mediaTypeDockerManifest:
+		if d.Platform != nil && !d.Platform.Matches(p) {
+			return nil, nil
+		}
+		var m Manifest
+		if err := l.readBlob(d, &m); err != nil {
+			return nil, err
+		}
+		var img Image
+		if err := l.readBlob(m.Config, &img); err != nil {
+			return nil, err
+		}
+		if !(Platform{OS: img.OS, Architecture: img.Architecture, Variant: img.Variant}).Matches(p) {
+			return nil, nil
+		}
+		return &baseImage{image: img, layers: m.Layers, layout: l}, nil
+	}
+
+	// Other content like attestation manifests or artifacts is not an image for any platform.
+	return nil, nil
+}
+
+// writeBlob writes the given data as blob and returns its descriptor with the given media type.
+func (l layout) writeBlob(data []byte, mediaType string) (Descriptor, error) {
+	d := Descriptor{Digest: digestOf(data), MediaType: mediaType, Size: int64(len(data))}
+	p, pathErr := l.blobPath(d.Digest)
+	if pathErr != nil {
+		return Descriptor{}, pathErr
+	}
+	//nolint:gosec // The image layout is meant to be world-readable.
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		return Descriptor{}, fmt.Errorf("write blob %q: %w", d.Digest, err)
+	}
+	return d, nil
+}
+
+// writeFile encodes the given value as JSON and writes it to the file with the given name in the layout root.
+func (l layout) writeFile(name string, v interface{}) error {
+	data, marshalErr := json.Marshal(v)
+	if marshalErr != nil {
+		return fmt.Errorf("encode %q: %w", name, marshalErr)
+	}
+	//nolint:gosec // The image layout is meant to be world-readable.
+	if err := os.WriteFile(filepath.Join(l.dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", name, err)
+	}
+	return nil
+}
+
+// writeJSONBlob encodes the given value as JSON, writes it as blob and returns its descriptor with the given media
+// type.
+func (l layout) writeJSONBlob(v interface{}, mediaType string) (Descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("encode %q: %w", mediaType, err)
+	}
+	return l.writeBlob(data, mediaType)
+}
+
+// binaryLayer creates a gzip compressed layer tarball that contains the file at the given source path stored at the
+// given destination path within the image, including all parent directories.
+// It returns the compressed tarball and the digest of the uncompressed tarball.
+// All entries use the given modification time and are owned by root to ensure reproducible layers.
+func binaryLayer(srcPath, dstPath string, modTime time.Time) ([]byte, string, error) {
+	f, openErr := os.Open(srcPath)
+	if openErr != nil {
+		return nil, "", fmt.Errorf("open binary artifact: %w", openErr)
+	}
+	defer func() { _ = f.Close() }()
+	fi, statErr := f.Stat()
+	if statErr != nil {
+		return nil, "", fmt.Errorf("read binary artifact information: %w", statErr)
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, "", errors.New("binary artifact is not a regular file")
+	}
+
+	var gz bytes.Buffer
+	diffID := sha256.New()
+	zw, _ := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+	tw := tar.NewWriter(io.MultiWriter(zw, diffID))
+
+	dstPath = strings.TrimPrefix(path.Clean("/"+dstPath), "/")
+	var dirs []string
+	for dir := path.Dir(dstPath); dir != "."; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, dir := range dirs {
+		hdr := &tar.Header{Typeflag: tar.TypeDir, Name: dir + "/", Mode: 0o755, ModTime: modTime, Format: tar.FormatPAX}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, "", fmt.Errorf("write layer directory %q: %w", dir, err)
+		}
+	}
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     dstPath,
+		Mode:     0o755,
+		Size:     fi.Size(),
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, "", fmt.Errorf("write layer file %q: %w", dstPath, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return nil, "", fmt.Errorf("write layer file %q: %w", dstPath, err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", fmt.Errorf("write layer: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", fmt.Errorf("compress layer: %w", err)
+	}
+
+	return gz.Bytes(), "sha256:" + hex.EncodeToString(diffID.Sum(nil)), nil
+}
+
+// digestOf returns the SHA-256 digest of the given data.
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
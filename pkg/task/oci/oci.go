@@ -0,0 +1,404 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package oci provides a task to assemble OCI images from binary artifacts of applications.
+// Images are written as OCI image layout to disk without the need for a container engine like a Docker daemon, either
+// from scratch or based on an image of a locally available image layout. Cross-compiled binary artifacts for multiple
+// platforms are combined in a multi-platform image index.
+//
+// See https://github.com/opencontainers/image-spec for more details about the OCI image format.
+package oci
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	fsSupport "github.com/svengreb/wand/internal/support/fs"
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Result is the result of an assembled image.
+type Result struct {
+	// Descriptor is the descriptor of the image manifest, or the image index for multiple platforms, that is referenced
+	// by the "index.json" file of the image layout.
+	Descriptor Descriptor
+
+	// Manifests are the descriptors of the image manifests of all platforms.
+	Manifests []Descriptor
+
+	// OutputDir is the absolute path to the image layout.
+	OutputDir string
+
+	// RefName is the reference name of the image in the image layout.
+	RefName string
+}
+
+// Task is a task to assemble OCI images from binary artifacts of applications.
+type Task struct {
+	ac   app.Config
+	opts *Options
+	proj project.Metadata
+}
+
+// target is the image of a single platform.
+type target struct {
+	// artifact is the absolute path to the binary artifact.
+	artifact string
+
+	// base is the base image, nil for images built from scratch.
+	base *baseImage
+
+	// platform is the platform of the image.
+	platform Platform
+}
+
+// Build assembles the image from the binary artifacts of the configured platforms and writes it as image layout to the
+// configured output directory.
+// All binary artifacts and base images are resolved before an existing image layout in the output directory is
+// replaced.
+// It returns an error of type *task.ErrTask for invalid options, like missing binary artifacts or base images, and an
+// error of type *task.ErrRunner when the image layout could not be written.
+func (t *Task) Build() (*Result, error) {
+	rootDir := t.proj.Options().RootDirPathAbs
+	platforms, platformsErr := t.platforms(fsSupport.AbsPath(rootDir, t.opts.ArtifactDir))
+	if platformsErr != nil {
+		return nil, &task.ErrTask{Err: platformsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	out := layout{dir: fsSupport.AbsPath(rootDir, t.opts.OutputDir)}
+	var base *layout
+	if t.opts.BaseLayoutDir != "" {
+		base = &layout{dir: fsSupport.AbsPath(rootDir, t.opts.BaseLayoutDir)}
+		if base.dir == out.dir {
+			return nil, &task.ErrTask{
+				Err:  fmt.Errorf("base layout %q must not be the output directory", base.dir),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+	}
+
+	targets := make([]target, 0, len(platforms))
+	for _, p := range platforms {
+		tg := target{
+			artifact: filepath.Join(fsSupport.AbsPath(rootDir, t.opts.ArtifactDir), t.artifactName(p, len(t.opts.Platforms) > 0)),
+			platform: p,
+		}
+		if _, err := os.Stat(tg.artifact); err != nil {
+			return nil, &task.ErrTask{
+				Err: fmt.Errorf(
+					`binary artifact %q for platform %q not found, run the "go/build" or "gox" task first: %w`,
+					tg.artifact, p, err,
+				),
+				Kind: task.ErrInvalidTaskOpts,
+			}
+		}
+		if base != nil {
+			var baseErr error
+			tg.base, baseErr = base.resolveBase(t.opts.BaseRefName, p)
+			if baseErr != nil {
+				return nil, &task.ErrTask{Err: fmt.Errorf("resolve base image: %w", baseErr), Kind: task.ErrInvalidTaskOpts}
+			}
+		}
+		targets = append(targets, tg)
+	}
+
+	if err := os.RemoveAll(out.dir); err != nil {
+		return nil, &task.ErrRunner{Err: fmt.Errorf("remove image layout %q: %w", out.dir, err), Kind: task.ErrRun}
+	}
+	if err := out.init(); err != nil {
+		return nil, &task.ErrRunner{Err: fmt.Errorf("create image layout %q: %w", out.dir, err), Kind: task.ErrRun}
+	}
+
+	res := &Result{OutputDir: out.dir, RefName: t.opts.RefName}
+	for _, tg := range targets {
+		d, err := t.writeManifest(out, tg)
+		if err != nil {
+			return nil, &task.ErrRunner{
+				Err:  fmt.Errorf("write image for platform %q: %w", tg.platform, err),
+				Kind: task.ErrRun,
+			}
+		}
+		res.Manifests = append(res.Manifests, d)
+	}
+
+	res.Descriptor = res.Manifests[0]
+	if len(res.Manifests) > 1 {
+		d, err := out.writeJSONBlob(
+			Index{Manifests: res.Manifests, MediaType: MediaTypeIndex, SchemaVersion: schemaVersion},
+			MediaTypeIndex,
+		)
+		if err != nil {
+			return nil, &task.ErrRunner{Err: fmt.Errorf("write image index: %w", err), Kind: task.ErrRun}
+		}
+		res.Descriptor = d
+	}
+	res.Descriptor.Annotations = map[string]string{AnnotationRefName: t.opts.RefName}
+
+	idx := Index{Manifests: []Descriptor{res.Descriptor}, MediaType: MediaTypeIndex, SchemaVersion: schemaVersion}
+	if err := out.writeFile(layoutIndexFileName, idx); err != nil {
+		return nil, &task.ErrRunner{Err: fmt.Errorf("write image layout index: %w", err), Kind: task.ErrRun}
+	}
+
+	return res, nil
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// artifactName returns the name of the binary artifact for the given platform.
+// The names of cross-compiled binary artifacts match the default output template of the "gox" task.
+func (t *Task) artifactName(p Platform, crossCompiled bool) string {
+	if !crossCompiled {
+		return t.opts.BinaryArtifactName
+	}
+	name := fmt.Sprintf("%s-%s-%s", t.opts.BinaryArtifactName, p.OS, p.Architecture)
+	if p.OS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// binaryPath returns the path of the binary artifact within the image of the given platform.
+// Binaries for Windows are stored with the ".exe" file extension that is required to execute them.
+func (t *Task) binaryPath(p Platform) string {
+	name := t.opts.BinaryArtifactName
+	if p.OS == "windows" && !strings.HasSuffix(name, ".exe") {
+		name += ".exe"
+	}
+	return path.Join("/", t.opts.BinaryDir, name)
+}
+
+// config returns the image configuration for the given platform based on the given base image configuration.
+func (t *Task) config(baseCfg Config, binaryPath string) Config {
+	cfg := baseCfg
+	cfg.Entrypoint = []string{binaryPath}
+	if len(t.opts.Entrypoint) > 0 {
+		cfg.Entrypoint = t.opts.Entrypoint
+	}
+	// The default arguments of the base image are meant for its own entrypoint.
+	cfg.Cmd = t.opts.Cmd
+	cfg.Env = mergeEnv(baseCfg.Env, t.opts.Env)
+	if t.opts.User != "" {
+		cfg.User = t.opts.User
+	}
+	if t.opts.WorkingDir != "" {
+		cfg.WorkingDir = t.opts.WorkingDir
+	}
+
+	if len(t.opts.ExposedPorts) > 0 {
+		cfg.ExposedPorts = make(map[string]struct{}, len(baseCfg.ExposedPorts)+len(t.opts.ExposedPorts))
+		for port := range baseCfg.ExposedPorts {
+			cfg.ExposedPorts[port] = struct{}{}
+		}
+		for _, port := range t.opts.ExposedPorts {
+			cfg.ExposedPorts[port] = struct{}{}
+		}
+	}
+
+	cfg.Labels = make(map[string]string)
+	for k, v := range baseCfg.Labels {
+		cfg.Labels[k] = v
+	}
+	for k, v := range t.labels() {
+		cfg.Labels[k] = v
+	}
+	return cfg
+}
+
+// labels returns the labels derived from the project metadata merged with the configured labels.
+func (t *Task) labels() map[string]string {
+	labels := map[string]string{
+		LabelTitle:   t.ac.Name,
+		LabelVersion: t.proj.Version(),
+	}
+	if revision := t.proj.Revision(); revision != "" {
+		labels[LabelRevision] = revision
+	}
+	if !t.opts.Created.IsZero() {
+		labels[LabelCreated] = t.opts.Created.UTC().Format(time.RFC3339)
+	}
+	for k, v := range t.opts.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// platforms returns the parsed platforms to build the image for.
+// When no platform is configured the platform the binary artifact of a regular build within the given artifact
+// directory has been built for is returned.
+func (t *Task) platforms(artifactDir string) ([]Platform, error) {
+	if len(t.opts.Platforms) == 0 {
+		artifact := filepath.Join(artifactDir, t.opts.BinaryArtifactName)
+		if _, err := os.Stat(artifact); err != nil {
+			return nil, fmt.Errorf(`binary artifact %q not found, run the "go/build" task first: %w`, artifact, err)
+		}
+		p, err := ArtifactPlatform(artifact)
+		if err != nil {
+			return nil, fmt.Errorf("%w, configure the platforms explicitly", err)
+		}
+		return []Platform{p}, nil
+	}
+
+	var platforms []Platform
+	seen := make(map[string]bool)
+	for _, s := range t.opts.Platforms {
+		p, err := ParsePlatform(s)
+		if err != nil {
+			return nil, err
+		}
+		if seen[p.String()] {
+			continue
+		}
+		seen[p.String()] = true
+		platforms = append(platforms, p)
+	}
+	return platforms, nil
+}
+
+// writeManifest writes the layer, configuration and manifest of the image of the given target and returns the
+// descriptor of the manifest.
+func (t *Task) writeManifest(out layout, tg target) (Descriptor, error) {
+	img := Image{RootFS: RootFS{Type: "layers"}}
+	var layers []Descriptor
+	if tg.base != nil {
+		img = tg.base.image
+		for _, l := range tg.base.layers {
+			if err := out.copyBlob(tg.base.layout, l); err != nil {
+				return Descriptor{}, fmt.Errorf("copy base image layer: %w", err)
+			}
+		}
+		layers = append(layers, tg.base.layers...)
+	}
+
+	// Ensure reproducible layers with a fixed modification time when no creation time is configured.
+	modTime := time.Unix(0, 0)
+	var created *time.Time
+	if !t.opts.Created.IsZero() {
+		c := t.opts.Created.UTC()
+		modTime, created = c, &c
+	}
+
+	binaryPath := t.binaryPath(tg.platform)
+	layerData, diffID, layerErr := binaryLayer(tg.artifact, binaryPath, modTime)
+	if layerErr != nil {
+		return Descriptor{}, fmt.Errorf("create layer: %w", layerErr)
+	}
+	layer, layerWriteErr := out.writeBlob(layerData, MediaTypeLayerGzip)
+	if layerWriteErr != nil {
+		return Descriptor{}, layerWriteErr
+	}
+	layers = append(layers, layer)
+
+	img.Architecture, img.OS, img.Variant = tg.platform.Architecture, tg.platform.OS, tg.platform.Variant
+	img.Config = t.config(img.Config, binaryPath)
+	img.Created = created
+	img.RootFS.DiffIDs = append(append([]string{}, img.RootFS.DiffIDs...), diffID)
+	img.History = append(append([]History{}, img.History...), History{
+		Comment:   fmt.Sprintf("binary artifact of application %q", t.ac.Name),
+		Created:   created,
+		CreatedBy: fmt.Sprintf("wand %s", taskName),
+	})
+
+	cfg, cfgErr := out.writeJSONBlob(img, MediaTypeConfig)
+	if cfgErr != nil {
+		return Descriptor{}, cfgErr
+	}
+	d, mErr := out.writeJSONBlob(
+		Manifest{Config: cfg, Layers: layers, MediaType: MediaTypeManifest, SchemaVersion: schemaVersion},
+		MediaTypeManifest,
+	)
+	if mErr != nil {
+		return Descriptor{}, mErr
+	}
+	d.Platform = &tg.platform
+	return d, nil
+}
+
+// ArtifactPlatform returns the platform the Go binary artifact at the given path has been built for.
+// The platform is read from the build information that is embedded into binaries by the Go toolchain.
+func ArtifactPlatform(path string) (Platform, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return Platform{}, fmt.Errorf("read build information of binary artifact %q: %w", path, err)
+	}
+
+	var p Platform
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "GOOS":
+			p.OS = s.Value
+		case "GOARCH":
+			p.Architecture = s.Value
+		case "GOARM":
+			// The value may contain the floating point mode, e.g. "7,softfloat".
+			version, _, _ := strings.Cut(s.Value, ",")
+			p.Variant = "v" + version
+		}
+	}
+	if p.OS == "" || p.Architecture == "" {
+		return Platform{}, fmt.Errorf("missing target platform in build information of binary artifact %q", path)
+	}
+	return p, nil
+}
+
+// New creates a new task.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(proj project.Metadata, ac app.Config, opts ...Option) *Task {
+	opt := NewOptions(opts...)
+
+	if opt.ArtifactDir == "" {
+		opt.ArtifactDir = ac.BaseOutputDir
+	}
+
+	if opt.BinaryArtifactName == "" {
+		opt.BinaryArtifactName = ac.Name
+	}
+
+	if opt.OutputDir == "" {
+		opt.OutputDir = filepath.Join(ac.BaseOutputDir, DefaultOutputDirName)
+	}
+
+	if opt.RefName == "" {
+		opt.RefName = proj.Version()
+	}
+
+	return &Task{ac: ac, opts: opt, proj: proj}
+}
+
+// mergeEnv merges the given environment variables in the "NAME=value" format where variables of the given overrides
+// replace the base variables with the same name while the order of the variables is kept.
+func mergeEnv(base, overrides []string) []string {
+	if len(overrides) == 0 {
+		return base
+	}
+
+	values := make(map[string]string)
+	var names []string
+	for _, kv := range append(append([]string{}, base...), overrides...) {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = kv
+	}
+
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		env = append(env, values[name])
+	}
+	return env
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactPlatform(t *testing.T) {
+	// The test binary is built for the current platform and embeds the build information like any other Go binary.
+	exe, exeErr := os.Executable()
+	require.NoError(t, exeErr)
+
+	p, err := ArtifactPlatform(exe)
+	require.NoError(t, err)
+	require.Equal(t, runtime.GOOS, p.OS)
+	require.Equal(t, runtime.GOARCH, p.Architecture)
+
+	notGo := filepath.Join(t.TempDir(), "script.sh")
+	require.NoError(t, os.WriteFile(notGo, []byte("#!/bin/sh\n"), 0o600))
+	_, err = ArtifactPlatform(notGo)
+	require.Error(t, err)
+}
+
+func TestTaskBinaryPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		artifact string
+		platform Platform
+		path     string
+	}{
+		{name: "linux", artifact: "app", platform: Platform{OS: "linux", Architecture: "amd64"}, path: "/usr/local/bin/app"},
+		{
+			name:     "windows",
+			artifact: "app",
+			platform: Platform{OS: "windows", Architecture: "amd64"},
+			path:     "/usr/local/bin/app.exe",
+		},
+		{
+			name:     "windows with extension",
+			artifact: "app.exe",
+			platform: Platform{OS: "windows", Architecture: "arm64"},
+			path:     "/usr/local/bin/app.exe",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &Task{opts: NewOptions(WithBinaryArtifactName(tc.artifact))}
+			require.Equal(t, tc.path, task.binaryPath(tc.platform))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package oci
+
+import (
+	"time"
+)
+
+const (
+	// DefaultBinaryDir is the default directory within the image the binary artifact is stored in.
+	DefaultBinaryDir = "/usr/local/bin"
+
+	// DefaultOutputDirName is the default output directory name for image layouts.
+	DefaultOutputDirName = "oci"
+
+	// taskName is the name of the task.
+	taskName = "oci"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// ArtifactDir is the directory, relative to the project root, of the binary artifacts to package.
+	// It defaults to the application output directory which is also used by the Go toolchain "build" and "gox" tasks.
+	ArtifactDir string
+
+	// BaseLayoutDir is the path, relative to the project root, to a locally available image layout that is used as base
+	// image. The image is built from scratch when no base layout is set.
+	BaseLayoutDir string
+
+	// BaseRefName is the reference name of the base image within the base layout.
+	// It is only required when the base layout contains multiple images.
+	BaseRefName string
+
+	// BinaryArtifactName is the name of the binary artifact.
+	// The names of cross-compiled binary artifacts are derived from it by appending the platform in the
+	// "name-os-arch" format which matches the default output template of the "gox" task.
+	BinaryArtifactName string
+
+	// BinaryDir is the directory within the image the binary artifact is stored in.
+	BinaryDir string
+
+	// Cmd are the default arguments to the entrypoint.
+	Cmd []string
+
+	// Created is the date and time the image is marked as created.
+	// It is omitted when not set to ensure reproducible images.
+	Created time.Time
+
+	// Entrypoint is the command to execute when the container starts.
+	// It defaults to the path of the binary artifact within the image.
+	Entrypoint []string
+
+	// Env are additional environment variables in the "NAME=value" format that are merged with the environment of the
+	// base image.
+	Env []string
+
+	// ExposedPorts are the ports to expose in the "port/protocol" format.
+	ExposedPorts []string
+
+	// Labels are additional labels that are merged with the labels of the base image and the labels derived from the
+	// project metadata.
+	Labels map[string]string
+
+	// name is the task name.
+	name string
+
+	// OutputDir is the output directory, relative to the project root, for the image layout.
+	// Note that the directory is removed before the image layout is written.
+	OutputDir string
+
+	// Platforms are the platforms in the "os/arch[/variant]" format to build the image for.
+	// When no platform is set the binary artifact of a regular build is packaged for the platform it has been built for
+	// which is read from the build information embedded by the Go toolchain. Otherwise the cross-compiled binary
+	// artifacts are packaged and combined in an image index.
+	Platforms []string
+
+	// RefName is the reference name of the image in the image layout.
+	// It defaults to the project version.
+	RefName string
+
+	// User is the user name or UID, optionally with the group name or GID, the process runs as.
+	User string
+
+	// WorkingDir is the current working directory of the process.
+	WorkingDir string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		BinaryDir: DefaultBinaryDir,
+		Labels:    make(map[string]string),
+		name:      taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// WithArtifactDir sets the directory, relative to the project root, of the binary artifacts to package.
+func WithArtifactDir(dir string) Option {
+	return func(o *Options) {
+		o.ArtifactDir = dir
+	}
+}
+
+// WithBaseLayout sets the path, relative to the project root, to a locally available image layout that is used as
+// base image and the reference name of the base image within the layout.
+// The reference name can be empty when the base layout contains a single image.
+func WithBaseLayout(dir, refName string) Option {
+	return func(o *Options) {
+		o.BaseLayoutDir = dir
+		o.BaseRefName = refName
+	}
+}
+
+// WithBinaryArtifactName sets the name of the binary artifact.
+func WithBinaryArtifactName(name string) Option {
+	return func(o *Options) {
+		o.BinaryArtifactName = name
+	}
+}
+
+// WithBinaryDir sets the directory within the image the binary artifact is stored in.
+func WithBinaryDir(dir string) Option {
+	return func(o *Options) {
+		o.BinaryDir = dir
+	}
+}
+
+// WithCmd sets the default arguments to the entrypoint.
+func WithCmd(cmd ...string) Option {
+	return func(o *Options) {
+		o.Cmd = cmd
+	}
+}
+
+// WithCreated sets the date and time the image is marked as created.
+func WithCreated(created time.Time) Option {
+	return func(o *Options) {
+		o.Created = created
+	}
+}
+
+// WithEntrypoint sets the command to execute when the container starts.
+func WithEntrypoint(entrypoint ...string) Option {
+	return func(o *Options) {
+		o.Entrypoint = entrypoint
+	}
+}
+
+// WithEnv sets additional environment variables in the "NAME=value" format.
+func WithEnv(env ...string) Option {
+	return func(o *Options) {
+		o.Env = append(o.Env, env...)
+	}
+}
+
+// WithExposedPorts sets the ports to expose in the "port/protocol" format.
+func WithExposedPorts(ports ...string) Option {
+	return func(o *Options) {
+		o.ExposedPorts = append(o.ExposedPorts, ports...)
+	}
+}
+
+// WithLabels sets additional labels.
+func WithLabels(labels map[string]string) Option {
+	return func(o *Options) {
+		for k, v := range labels {
+			o.Labels[k] = v
+		}
+	}
+}
+
+// WithOutputDir sets the output directory, relative to the project root, for the image layout.
+func WithOutputDir(dir string) Option {
+	return func(o *Options) {
+		o.OutputDir = dir
+	}
+}
+
+// WithPlatforms sets the platforms in the "os/arch[/variant]" format to build the image for.
+func WithPlatforms(platforms ...string) Option {
+	return func(o *Options) {
+		o.Platforms = append(o.Platforms, platforms...)
+	}
+}
+
+// WithRefName sets the reference name of the image in the image layout.
+func WithRefName(refName string) Option {
+	return func(o *Options) {
+		o.RefName = refName
+	}
+}
+
+// WithUser sets the user name or UID, optionally with the group name or GID, the process runs as.
+func WithUser(user string) Option {
+	return func(o *Options) {
+		o.User = user
+	}
+}
+
+// WithWorkingDir sets the current working directory of the process.
+func WithWorkingDir(dir string) Option {
+	return func(o *Options) {
+		o.WorkingDir = dir
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package oci
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// AnnotationRefName is the annotation key for the reference name of a manifest or index in an image layout.
+	AnnotationRefName = "org.opencontainers.image.ref.name"
+
+	// LabelCreated is the label key for the date and time the image has been created.
+	LabelCreated = "org.opencontainers.image.created"
+
+	// LabelRevision is the label key for the source control revision the image has been built from.
+	LabelRevision = "org.opencontainers.image.revision"
+
+	// LabelTitle is the label key for the human-readable title of the image.
+	LabelTitle = "org.opencontainers.image.title"
+
+	// LabelVersion is the label key for the version of the packaged software.
+	LabelVersion = "org.opencontainers.image.version"
+
+	// LayoutVersion is the version of the image layout written to the "oci-layout" file.
+	LayoutVersion = "1.0.0"
+
+	// MediaTypeConfig is the media type of an image configuration.
+	MediaTypeConfig = "application/vnd.oci.image.config.v1+json"
+
+	// MediaTypeIndex is the media type of an image index.
+	MediaTypeIndex = "application/vnd.oci.image.index.v1+json"
+
+	// MediaTypeLayerGzip is the media type of a gzip compressed layer tarball.
+	MediaTypeLayerGzip = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	// MediaTypeManifest is the media type of an image manifest.
+	MediaTypeManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// schemaVersion is the schema version of image manifests and indexes.
+	schemaVersion = 2
+)
+
+// Config is the execution configuration of an image.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/config.md for more details.
+type Config struct {
+	// Cmd are the default arguments to the entrypoint.
+	Cmd []string `json:"Cmd,omitempty"`
+
+	// Entrypoint are the arguments to use as the command to execute when the container starts.
+	Entrypoint []string `json:"Entrypoint,omitempty"`
+
+	// Env are the environment variables in the "NAME=value" format.
+	Env []string `json:"Env,omitempty"`
+
+	// ExposedPorts are the ports to expose in the "port/protocol" format.
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+
+	// Labels are arbitrary metadata of the image.
+	Labels map[string]string `json:"Labels,omitempty"`
+
+	// StopSignal is the system call signal that is sent to the container to exit.
+	StopSignal string `json:"StopSignal,omitempty"`
+
+	// User is the user name or UID, optionally with the group name or GID, the process runs as.
+	User string `json:"User,omitempty"`
+
+	// Volumes are the directories that are used as volumes.
+	Volumes map[string]struct{} `json:"Volumes,omitempty"`
+
+	// WorkingDir is the current working directory of the process.
+	WorkingDir string `json:"WorkingDir,omitempty"`
+}
+
+// Descriptor describes the content a blob references.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/descriptor.md for more details.
+type Descriptor struct {
+	// Annotations are arbitrary metadata of the descriptor.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Digest is the digest of the referenced content in the "algorithm:encoded" format.
+	Digest string `json:"digest"`
+
+	// MediaType is the media type of the referenced content.
+	MediaType string `json:"mediaType"`
+
+	// Platform is the platform of the referenced image manifest, if any.
+	Platform *Platform `json:"platform,omitempty"`
+
+	// Size is the size in bytes of the referenced content.
+	Size int64 `json:"size"`
+}
+
+// History describes the history of a layer.
+type History struct {
+	// Comment is a custom message of the layer.
+	Comment string `json:"comment,omitempty"`
+
+	// Created is the date and time the layer has been created.
+	Created *time.Time `json:"created,omitempty"`
+
+	// CreatedBy is the command that created the layer.
+	CreatedBy string `json:"created_by,omitempty"`
+
+	// EmptyLayer indicates whether the history item created a filesystem difference.
+	EmptyLayer bool `json:"empty_layer,omitempty"`
+}
+
+// Image is the configuration of an image.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/config.md for more details.
+type Image struct {
+	// Architecture is the CPU architecture the binaries of the image are built to run on.
+	Architecture string `json:"architecture"`
+
+	// Config is the execution configuration of the image.
+	Config Config `json:"config,omitempty"`
+
+	// Created is the date and time the image has been created.
+	Created *time.Time `json:"created,omitempty"`
+
+	// History is the history of each layer.
+	History []History `json:"history,omitempty"`
+
+	// OS is the name of the operating system the image is built to run on.
+	OS string `json:"os"`
+
+	// RootFS are the layer content addresses of the image.
+	RootFS RootFS `json:"rootfs"`
+
+	// Variant is the variant of the CPU architecture.
+	Variant string `json:"variant,omitempty"`
+}
+
+// Index is an image index that references image manifests, e.g. of multiple platforms.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/image-index.md for more details.
+type Index struct {
+	// Annotations are arbitrary metadata of the index.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Manifests are the descriptors of the referenced manifests.
+	Manifests []Descriptor `json:"manifests"`
+
+	// MediaType is the media type of the index.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// SchemaVersion is the schema version of the index.
+	SchemaVersion int `json:"schemaVersion"`
+}
+
+// Layout is the content of the "oci-layout" file of an image layout.
+type Layout struct {
+	// Version is the version of the image layout.
+	Version string `json:"imageLayoutVersion"`
+}
+
+// Manifest is an image manifest for a single platform.
+//
+// See https://github.com/opencontainers/image-spec/blob/main/manifest.md for more details.
+type Manifest struct {
+	// Annotations are arbitrary metadata of the manifest.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Config is the descriptor of the image configuration.
+	Config Descriptor `json:"config"`
+
+	// Layers are the descriptors of the layers in order from the base layer.
+	Layers []Descriptor `json:"layers"`
+
+	// MediaType is the media type of the manifest.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// SchemaVersion is the schema version of the manifest.
+	SchemaVersion int `json:"schemaVersion"`
+}
+
+// Platform is a platform an image is built to run on.
+type Platform struct {
+	// Architecture is the CPU architecture, e.g. "amd64" or "arm64".
+	Architecture string `json:"architecture"`
+
+	// OS is the name of the operating system, e.g. "linux".
+	OS string `json:"os"`
+
+	// Variant is the variant of the CPU architecture, e.g. "v7" for "arm".
+	Variant string `json:"variant,omitempty"`
+}
+
+// RootFS are the layer content addresses of an image.
+type RootFS struct {
+	// DiffIDs are the digests of the uncompressed layer tarballs in order from the base layer.
+	DiffIDs []string `json:"diff_ids"`
+
+	// Type is the type of the root filesystem which must be "layers".
+	Type string `json:"type"`
+}
+
+// Matches checks whether the platform matches the given platform.
+// The variant is only compared when both platforms define one.
+func (p Platform) Matches(other Platform) bool {
+	if p.OS != other.OS || p.Architecture != other.Architecture {
+		return false
+	}
+	return p.Variant == "" || other.Variant == "" || p.Variant == other.Variant
+}
+
+func (p Platform) String() string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// ParsePlatform parses a platform in the "os/arch[/variant]" format, e.g. "linux/amd64" or "linux/arm/v7".
+func ParsePlatform(s string) (Platform, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Platform{}, fmt.Errorf("not a valid platform %q, expected format is \"os/arch[/variant]\"", s)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return Platform{}, fmt.Errorf("not a valid platform %q, expected format is \"os/arch[/variant]\"", s)
+		}
+	}
+
+	p := Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	return p, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	fsSupport "github.com/svengreb/wand/internal/support/fs"
+	"github.com/svengreb/wand/pkg/project"
+)
+
+// stdlibPath is the module path used for the Go standard library.
+const stdlibPath = "stdlib"
+
+// Artifact is the information about a binary artifact and the modules it has been built from.
+type Artifact struct {
+	// Deps are the dependency modules of the main module that have been compiled into the artifact.
+	Deps []Module
+
+	// GoVersion is the version of the Go toolchain that built the artifact.
+	GoVersion string
+
+	// Main is the main module the artifact has been built from.
+	Main Module
+
+	// Name is the file name of the artifact.
+	Name string
+
+	// Path is the absolute path to the artifact.
+	Path string
+
+	// SHA256 is the hex encoded SHA-256 checksum of the artifact.
+	SHA256 string
+
+	// Settings are the build settings like "GOOS", "GOARCH" or "vcs.revision".
+	Settings []debug.BuildSetting
+}
+
+// Module is a module compiled into a binary artifact.
+type Module struct {
+	// Direct indicates whether the module is a direct requirement of the main module.
+	Direct bool
+
+	// Path is the module path.
+	Path string
+
+	// Replace is the path of the module that replaces this module, if any.
+	Replace string
+
+	// SHA256 is the hex encoded SHA-256 checksum of the artifact for the main module and empty for all dependencies.
+	SHA256 string
+
+	// Sum is the checksum of the module in the "h1:" format of "go.sum" files, if any.
+	// Note that it is not a checksum of a single file but a hash of the file tree of the module.
+	//
+	// See https://go.dev/ref/mod#go-sum-files for more details.
+	Sum string
+
+	// Version is the module version.
+	Version string
+}
+
+// PURL returns the package URL of the module.
+//
+// See https://github.com/package-url/purl-spec for more details.
+func (m Module) PURL() string {
+	segments := strings.Split(m.Path, "/")
+	for i, s := range segments {
+		segments[i] = purlEscape(s)
+	}
+	purl := "pkg:golang/" + strings.Join(segments, "/")
+	if m.Version != "" && m.Version != project.GoModuleDefaultBuildInfoVersion {
+		purl += "@" + purlEscape(m.Version)
+	}
+	return purl
+}
+
+// purlEscape percent-encodes the given package URL component.
+// In addition to the path segment escaping the "@" character is encoded since it separates the version.
+func purlEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+}
+
+// ReadArtifact reads the build information embedded in the binary artifact at the given path.
+// The given "go.mod" file, that can be nil, is used to classify dependencies as direct requirements while the given
+// version is used as version of the main module.
+func ReadArtifact(path string, goMod *modfile.File, version string) (*Artifact, error) {
+	bi, biErr := buildinfo.ReadFile(path)
+	if biErr != nil {
+		return nil, fmt.Errorf("read build information of %q: %w", path, biErr)
+	}
+	sum, sumErr := fsSupport.FileSHA256(path)
+	if sumErr != nil {
+		return nil, sumErr
+	}
+
+	direct := make(map[string]bool)
+	if goMod != nil {
+		for _, r := range goMod.Require {
+			if !r.Indirect {
+				direct[r.Mod.Path] = true
+			}
+		}
+	}
+
+	mainPath := bi.Main.Path
+	if mainPath == "" {
+		mainPath = bi.Path
+	}
+	a := &Artifact{
+		GoVersion: bi.GoVersion,
+		Main:      Module{Path: mainPath, SHA256: sum, Version: version},
+		Name:      filepath.Base(path),
+		Path:      path,
+		SHA256:    sum,
+		Settings:  bi.Settings,
+	}
+	a.Deps = append(a.Deps, Module{Direct: true, Path: stdlibPath, Version: bi.GoVersion})
+	for _, dep := range bi.Deps {
+		m := Module{Direct: direct[dep.Path], Path: dep.Path, Sum: dep.Sum, Version: dep.Version}
+		if dep.Replace != nil {
+			m.Replace = dep.Replace.Path
+			m.Sum = dep.Replace.Sum
+			if dep.Replace.Version != "" {
+				m.Version = dep.Replace.Version
+			}
+		}
+		a.Deps = append(a.Deps, m)
+	}
+	return a, nil
+}
+
+// Platform returns the target platform of the artifact in the "os/arch" format.
+// An empty string is returned when the build information does not include the target platform.
+func (a *Artifact) Platform() string {
+	goos, goarch := a.setting("GOOS"), a.setting("GOARCH")
+	if goos == "" || goarch == "" {
+		return ""
+	}
+	return goos + "/" + goarch
+}
+
+// setting returns the value of the build setting with the given key.
+func (a *Artifact) setting(key string) string {
+	for _, s := range a.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulePURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		module Module
+		want   string
+	}{
+		{
+			name:   "versioned module",
+			module: Module{Path: "github.com/svengreb/wand", Version: "v0.9.0"},
+			want:   "pkg:golang/github.com/svengreb/wand@v0.9.0",
+		},
+		{name: "standard library", module: Module{Path: stdlibPath, Version: "go1.21.0"}, want: "pkg:golang/stdlib@go1.21.0"},
+		{
+			name:   "pseudo version",
+			module: Module{Path: "example.com/mod", Version: "v0.0.0-20230101000000-abcdef123456"},
+			want:   "pkg:golang/example.com/mod@v0.0.0-20230101000000-abcdef123456",
+		},
+		{
+			name:   "incompatible version",
+			module: Module{Path: "example.com/mod", Version: "v2.0.0+incompatible"},
+			want:   "pkg:golang/example.com/mod@v2.0.0+incompatible",
+		},
+		{
+			name:   "development version",
+			module: Module{Path: "example.com/mod", Version: "(devel)"},
+			want:   "pkg:golang/example.com/mod",
+		},
+		{name: "without version", module: Module{Path: "example.com/mod"}, want: "pkg:golang/example.com/mod"},
+		{
+			name:   "escaped path segments",
+			module: Module{Path: "example.com/my mod/v2", Version: "v2.1.0"},
+			want:   "pkg:golang/example.com/my%20mod/v2@v2.1.0",
+		},
+		{
+			name:   "escaped version separator",
+			module: Module{Path: "example.com/a@b", Version: "v1.0.0"},
+			want:   "pkg:golang/example.com/a%40b@v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.module.PURL())
+		})
+	}
+}
+
+func TestArtifactPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings []debug.BuildSetting
+		want     string
+	}{
+		{
+			name:     "target platform",
+			settings: []debug.BuildSetting{{Key: "GOARCH", Value: "arm64"}, {Key: "GOOS", Value: "linux"}},
+			want:     "linux/arm64",
+		},
+		{name: "missing architecture", settings: []debug.BuildSetting{{Key: "GOOS", Value: "linux"}}},
+		{name: "no settings"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, (&Artifact{Settings: tc.settings}).Platform())
+		})
+	}
+}
+
+// testArtifact returns an artifact with a direct, an indirect and a replaced dependency.
+func testArtifact() *Artifact {
+	return &Artifact{
+		Deps: []Module{
+			{Direct: true, Path: stdlibPath, Version: "go1.21.0"},
+			{Direct: true, Path: "example.com/direct", Sum: "h1:direct=", Version: "v1.2.0"},
+			{Path: "example.com/indirect", Version: "v0.3.0"},
+			{Direct: true, Path: "example.com/replaced", Replace: "example.com/fork", Version: "v1.0.1"},
+		},
+		GoVersion: "go1.21.0",
+		Main:      Module{Path: "example.com/app", SHA256: "abc123", Version: "v1.0.0"},
+		Name:      "app-linux-amd64",
+		Path:      "/project/out/app-linux-amd64",
+		SHA256:    "abc123",
+		Settings:  []debug.BuildSetting{{Key: "GOARCH", Value: "amd64"}, {Key: "GOOS", Value: "linux"}},
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// cycloneDXSpecVersion is the version of the CycloneDX specification documents are generated for.
+const cycloneDXSpecVersion = "1.5"
+
+// CycloneDXComponent is a component of a CycloneDX document.
+type CycloneDXComponent struct {
+	// BOMRef is the identifier of the component within the document.
+	BOMRef string `json:"bom-ref"`
+
+	// Hashes are the checksums of the component.
+	Hashes []CycloneDXHash `json:"hashes,omitempty"`
+
+	// Name is the name of the component.
+	Name string `json:"name"`
+
+	// Properties are additional name-value properties of the component.
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+
+	// PURL is the package URL of the component.
+	PURL string `json:"purl,omitempty"`
+
+	// Scope is the scope of the component, e.g. "required".
+	Scope string `json:"scope,omitempty"`
+
+	// Type is the type of the component, e.g. "application" or "library".
+	Type string `json:"type"`
+
+	// Version is the version of the component.
+	Version string `json:"version,omitempty"`
+}
+
+// CycloneDXDependency is a dependency relationship of a CycloneDX document.
+type CycloneDXDependency struct {
+	// DependsOn are the references of the components the component depends on.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Ref is the reference of the component.
+	Ref string `json:"ref"`
+}
+
+// CycloneDXDocument is a CycloneDX JSON document.
+//
+// See https://cyclonedx.org/docs/1.5/json for more details.
+type CycloneDXDocument struct {
+	// BOMFormat is the format of the document which must be "CycloneDX".
+	BOMFormat string `json:"bomFormat"`
+
+	// Components are the components of the artifact.
+	Components []CycloneDXComponent `json:"components"`
+
+	// Dependencies are the dependency relationships of all components.
+	Dependencies []CycloneDXDependency `json:"dependencies"`
+
+	// Metadata is the metadata of the document.
+	Metadata CycloneDXMetadata `json:"metadata"`
+
+	// SerialNumber is the unique serial number of the document in the URN UUID format.
+	SerialNumber string `json:"serialNumber"`
+
+	// SpecVersion is the version of the CycloneDX specification.
+	SpecVersion string `json:"specVersion"`
+
+	// Version is the version of the document.
+	Version int `json:"version"`
+}
+
+// CycloneDXHash is a checksum of a CycloneDX component.
+type CycloneDXHash struct {
+	// Algorithm is the checksum algorithm, e.g. "SHA-256".
+	Algorithm string `json:"alg"`
+
+	// Content is the hex encoded checksum.
+	Content string `json:"content"`
+}
+
+// CycloneDXMetadata is the metadata of a CycloneDX document.
+type CycloneDXMetadata struct {
+	// Component is the component the document describes.
+	Component CycloneDXComponent `json:"component"`
+
+	// Timestamp is the date and time the document has been created.
+	Timestamp string `json:"timestamp"`
+
+	// Tools are the tools used to create the document.
+	Tools CycloneDXTools `json:"tools"`
+}
+
+// CycloneDXProperty is a name-value property of a CycloneDX component.
+type CycloneDXProperty struct {
+	// Name is the name of the property.
+	Name string `json:"name"`
+
+	// Value is the value of the property.
+	Value string `json:"value"`
+}
+
+// CycloneDXTools are the tools used to create a CycloneDX document.
+type CycloneDXTools struct {
+	// Components are the tools as components.
+	Components []CycloneDXComponent `json:"components"`
+}
+
+// NewCycloneDXDocument creates a new CycloneDX document for the given artifact created at the given time.
+func NewCycloneDXDocument(a *Artifact, created time.Time) *CycloneDXDocument {
+	main := cycloneDXComponent(a.Main, "application")
+	main.Name = a.Name
+	for _, s := range a.Settings {
+		main.Properties = append(main.Properties, CycloneDXProperty{Name: "go:build:" + s.Key, Value: s.Value})
+	}
+	main.Properties = append(main.Properties, CycloneDXProperty{Name: "go:module:path", Value: a.Main.Path})
+
+	doc := &CycloneDXDocument{
+		BOMFormat:  "CycloneDX",
+		Components: []CycloneDXComponent{},
+		Metadata: CycloneDXMetadata{
+			Component: main,
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools: CycloneDXTools{
+				Components: []CycloneDXComponent{{BOMRef: "tool:wand", Name: "wand", Type: "application"}},
+			},
+		},
+		SerialNumber: "urn:uuid:" + documentUUID(a, created),
+		SpecVersion:  cycloneDXSpecVersion,
+		Version:      1,
+	}
+
+	mainDeps := CycloneDXDependency{Ref: main.BOMRef}
+	for _, m := range a.Deps {
+		c := cycloneDXComponent(m, "library")
+		c.Scope = "required"
+		if !m.Direct {
+			c.Properties = append(c.Properties, CycloneDXProperty{Name: "go:module:indirect", Value: "true"})
+		}
+		if m.Replace != "" {
+			c.Properties = append(c.Properties, CycloneDXProperty{Name: "go:module:replace", Value: m.Replace})
+		}
+		// The "h1:" checksum is a hash of the module file tree and can not be represented as hash of the component.
+		if m.Sum != "" {
+			c.Properties = append(c.Properties, CycloneDXProperty{Name: "go:module:sum", Value: m.Sum})
+		}
+		doc.Components = append(doc.Components, c)
+		mainDeps.DependsOn = append(mainDeps.DependsOn, c.BOMRef)
+		doc.Dependencies = append(doc.Dependencies, CycloneDXDependency{Ref: c.BOMRef})
+	}
+	doc.Dependencies = append([]CycloneDXDependency{mainDeps}, doc.Dependencies...)
+	return doc
+}
+
+// cycloneDXComponent returns the CycloneDX component of the given module with the given type.
+func cycloneDXComponent(m Module, typ string) CycloneDXComponent {
+	c := CycloneDXComponent{BOMRef: m.PURL(), Name: m.Path, PURL: m.PURL(), Type: typ, Version: m.Version}
+	if m.SHA256 != "" {
+		c.Hashes = []CycloneDXHash{{Algorithm: "SHA-256", Content: m.SHA256}}
+	}
+	return c
+}
+
+// documentUUID returns a UUID in the version 4 format that is derived from the given artifact and creation time.
+// The same artifact generated at the same time always results in the same UUID to ensure reproducible documents.
+func documentUUID(a *Artifact, created time.Time) string {
+	sum := sha256.Sum256([]byte(a.SHA256 + created.UTC().Format(time.RFC3339Nano)))
+	sum[6] = (sum[6] & 0x0f) | 0x40
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCycloneDXDocument(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	main := CycloneDXComponent{
+		BOMRef: "pkg:golang/example.com/app@v1.0.0",
+		Hashes: []CycloneDXHash{{Algorithm: "SHA-256", Content: "abc123"}},
+		Name:   "app-linux-amd64",
+		Properties: []CycloneDXProperty{
+			{Name: "go:build:GOARCH", Value: "amd64"},
+			{Name: "go:build:GOOS", Value: "linux"},
+			{Name: "go:module:path", Value: "example.com/app"},
+		},
+		PURL:    "pkg:golang/example.com/app@v1.0.0",
+		Type:    "application",
+		Version: "v1.0.0",
+	}
+	tools := CycloneDXTools{Components: []CycloneDXComponent{{BOMRef: "tool:wand", Name: "wand", Type: "application"}}}
+
+	tests := []struct {
+		name         string
+		deps         []Module
+		components   []CycloneDXComponent
+		dependencies []CycloneDXDependency
+	}{
+		{
+			name:         "without dependencies",
+			components:   []CycloneDXComponent{},
+			dependencies: []CycloneDXDependency{{Ref: main.BOMRef}},
+		},
+		{
+			name: "direct indirect and replaced dependencies",
+			deps: testArtifact().Deps,
+			components: []CycloneDXComponent{
+				{
+					BOMRef:  "pkg:golang/stdlib@go1.21.0",
+					Name:    stdlibPath,
+					PURL:    "pkg:golang/stdlib@go1.21.0",
+					Scope:   "required",
+					Type:    "library",
+					Version: "go1.21.0",
+				},
+				{
+					BOMRef:     "pkg:golang/example.com/direct@v1.2.0",
+					Name:       "example.com/direct",
+					Properties: []CycloneDXProperty{{Name: "go:module:sum", Value: "h1:direct="}},
+					PURL:       "pkg:golang/example.com/direct@v1.2.0",
+					Scope:      "required",
+					Type:       "library",
+					Version:    "v1.2.0",
+				},
+				{
+					BOMRef:     "pkg:golang/example.com/indirect@v0.3.0",
+					Name:       "example.com/indirect",
+					Properties: []CycloneDXProperty{{Name: "go:module:indirect", Value: "true"}},
+					PURL:       "pkg:golang/example.com/indirect@v0.3.0",
+					Scope:      "required",
+					Type:       "library",
+					Version:    "v0.3.0",
+				},
+				{
+					BOMRef:     "pkg:golang/example.com/replaced@v1.0.1",
+					Name:       "example.com/replaced",
+					Properties: []CycloneDXProperty{{Name: "go:module:replace", Value: "example.com/fork"}},
+					PURL:       "pkg:golang/example.com/replaced@v1.0.1",
+					Scope:      "required",
+					Type:       "library",
+					Version:    "v1.0.1",
+				},
+			},
+			dependencies: []CycloneDXDependency{
+				{
+					DependsOn: []string{
+						"pkg:golang/stdlib@go1.21.0",
+						"pkg:golang/example.com/direct@v1.2.0",
+						"pkg:golang/example.com/indirect@v0.3.0",
+						"pkg:golang/example.com/replaced@v1.0.1",
+					},
+					Ref: main.BOMRef,
+				},
+				{Ref: "pkg:golang/stdlib@go1.21.0"},
+				{Ref: "pkg:golang/example.com/direct@v1.2.0"},
+				{Ref: "pkg:golang/example.com/indirect@v0.3.0"},
+				{Ref: "pkg:golang/example.com/replaced@v1.0.1"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := testArtifact()
+			a.Deps = tc.deps
+			want := &CycloneDXDocument{
+				BOMFormat:    "CycloneDX",
+				Components:   tc.components,
+				Dependencies: tc.dependencies,
+				Metadata:     CycloneDXMetadata{Component: main, Timestamp: "2023-06-01T10:00:00Z", Tools: tools},
+				SerialNumber: "urn:uuid:" + documentUUID(a, created),
+				SpecVersion:  cycloneDXSpecVersion,
+				Version:      1,
+			}
+			require.Equal(t, want, NewCycloneDXDocument(a, created))
+		})
+	}
+}
+
+func TestDocumentUUID(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	a := testArtifact()
+
+	id := documentUUID(a, created)
+	require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	require.Equal(t, id, documentUUID(testArtifact(), created.In(time.FixedZone("CEST", 2*60*60))))
+	require.NotEqual(t, id, documentUUID(a, created.Add(time.Second)))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatNameCycloneDX is the Format name for CycloneDX JSON documents.
+	FormatNameCycloneDX = "cyclonedx"
+	// FormatNameSPDX is the Format name for SPDX JSON documents.
+	FormatNameSPDX = "spdx"
+	// FormatNameUnknown is the name for a unknown Format.
+	FormatNameUnknown = "unknown"
+)
+
+const (
+	// FormatCycloneDX is the Format for CycloneDX JSON documents.
+	//
+	// See https://cyclonedx.org/specification/overview for more details.
+	FormatCycloneDX Format = iota
+	// FormatSPDX is the Format for SPDX JSON documents.
+	//
+	// See https://spdx.github.io/spdx-spec for more details.
+	FormatSPDX
+)
+
+// Format defines a document format of a software bill of materials.
+type Format uint32
+
+// FileSuffix returns the suffix that is appended to the file name of an artifact for documents of the format.
+func (f Format) FileSuffix() string {
+	switch f {
+	case FormatCycloneDX:
+		return ".cdx.json"
+	case FormatSPDX:
+		return ".spdx.json"
+	}
+	return ".json"
+}
+
+// MarshalText returns the textual representation of itself.
+func (f Format) MarshalText() ([]byte, error) {
+	switch f {
+	case FormatCycloneDX:
+		return []byte(FormatNameCycloneDX), nil
+	case FormatSPDX:
+		return []byte(FormatNameSPDX), nil
+	}
+
+	return nil, fmt.Errorf("not a valid format %d", f)
+}
+
+func (f Format) String() string {
+	if b, err := f.MarshalText(); err == nil {
+		return string(b)
+	}
+	return FormatNameUnknown
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler to unmarshal a textual representation of itself.
+func (f *Format) UnmarshalText(text []byte) error {
+	parsed, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = parsed
+	return nil
+}
+
+// ParseFormat takes a format name and returns the Format constant.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case FormatNameCycloneDX:
+		return FormatCycloneDX, nil
+	case FormatNameSPDX:
+		return FormatSPDX, nil
+	}
+
+	var f Format
+	return f, fmt.Errorf("not a valid format: %q", name)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"time"
+)
+
+const (
+	// taskName is the name of the task.
+	taskName = "sbom"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// ArtifactDir is the directory, relative to the project root, of the binary artifacts.
+	// It defaults to the application output directory which is also used by the Go toolchain "build" and "gox" tasks.
+	ArtifactDir string
+
+	// Artifacts are the paths, relative to the project root, to the binary artifacts.
+	// When no artifacts are set all binary artifacts in the artifact directory whose names match the binary artifact
+	// name, either as is or as prefix of cross-compiled binary artifacts in the "name-os-arch" format, are used.
+	Artifacts []string
+
+	// BinaryArtifactName is the name of the binary artifact.
+	BinaryArtifactName string
+
+	// Created is the date and time the documents are marked as created.
+	// It defaults to the current time.
+	Created time.Time
+
+	// Formats are the document formats to generate.
+	Formats []Format
+
+	// GoModFile is the path, relative to the project root, to the "go.mod" file of the module the binary artifacts
+	// have been built from. It is used to classify dependencies as direct or indirect.
+	// It defaults to the "go.mod" file of the module that contains the application directory.
+	GoModFile string
+
+	// name is the task name.
+	name string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if len(opt.Formats) == 0 {
+		opt.Formats = []Format{FormatCycloneDX, FormatSPDX}
+	}
+
+	return opt
+}
+
+// WithArtifactDir sets the directory, relative to the project root, of the binary artifacts.
+func WithArtifactDir(dir string) Option {
+	return func(o *Options) {
+		o.ArtifactDir = dir
+	}
+}
+
+// WithArtifacts sets the paths, relative to the project root, to the binary artifacts.
+func WithArtifacts(artifacts ...string) Option {
+	return func(o *Options) {
+		o.Artifacts = append(o.Artifacts, artifacts...)
+	}
+}
+
+// WithBinaryArtifactName sets the name of the binary artifact.
+func WithBinaryArtifactName(name string) Option {
+	return func(o *Options) {
+		o.BinaryArtifactName = name
+	}
+}
+
+// WithCreated sets the date and time the documents are marked as created.
+func WithCreated(created time.Time) Option {
+	return func(o *Options) {
+		o.Created = created
+	}
+}
+
+// WithFormats sets the document formats to generate.
+func WithFormats(formats ...Format) Option {
+	return func(o *Options) {
+		o.Formats = append(o.Formats, formats...)
+	}
+}
+
+// WithGoModFile sets the path, relative to the project root, to the "go.mod" file of the module the binary artifacts
+// have been built from.
+func WithGoModFile(path string) Option {
+	return func(o *Options) {
+		o.GoModFile = path
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package sbom provides a task to generate software bills of materials for binary artifacts of applications.
+// The modules compiled into a binary artifact are read from its embedded build information while the "go.mod" file of
+// the main module is used to classify dependencies as direct or indirect. Documents are generated in the CycloneDX and
+// SPDX JSON formats and written next to the binary artifact.
+//
+// See https://pkg.go.dev/debug/buildinfo, https://cyclonedx.org and https://spdx.dev for more details.
+package sbom
+
+import (
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	glFS "github.com/svengreb/golib/pkg/io/fs"
+	"golang.org/x/mod/modfile"
+
+	fsSupport "github.com/svengreb/wand/internal/support/fs"
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Result is the result of the documents generated for a binary artifact.
+type Result struct {
+	// Artifact is the information about the binary artifact.
+	Artifact *Artifact
+
+	// Files are the absolute paths to the generated documents mapped by their format.
+	Files map[Format]string
+}
+
+// Task is a task to generate software bills of materials for binary artifacts of applications.
+type Task struct {
+	ac   app.Config
+	opts *Options
+	proj project.Metadata
+}
+
+// Generate generates the documents of all configured formats for all binary artifacts.
+// It returns an error of type *task.ErrTask for invalid options, like missing binary artifacts, and an error of type
+// *task.ErrRunner when any document could not be written.
+func (t *Task) Generate() ([]Result, error) {
+	artifacts, artifactsErr := t.artifacts()
+	if artifactsErr != nil {
+		return nil, &task.ErrTask{Err: artifactsErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	goMod, goModErr := t.goMod()
+	if goModErr != nil {
+		return nil, &task.ErrTask{Err: goModErr, Kind: task.ErrInvalidTaskOpts}
+	}
+
+	created := t.opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	var results []Result
+	for _, p := range artifacts {
+		a, err := ReadArtifact(p, goMod, t.proj.Version())
+		if err != nil {
+			return results, &task.ErrTask{Err: err, Kind: task.ErrInvalidTaskOpts}
+		}
+
+		res := Result{Artifact: a, Files: make(map[Format]string)}
+		for _, f := range t.opts.Formats {
+			var doc interface{}
+			switch f {
+			case FormatCycloneDX:
+				doc = NewCycloneDXDocument(a, created)
+			case FormatSPDX:
+				doc = NewSPDXDocument(a, created)
+			default:
+				return results, &task.ErrTask{Err: fmt.Errorf("unsupported format %q", f), Kind: task.ErrInvalidTaskOpts}
+			}
+
+			file := p + f.FileSuffix()
+			if err := writeDocument(file, doc); err != nil {
+				return results, &task.ErrRunner{Err: fmt.Errorf("write %s document: %w", f, err), Kind: task.ErrRun}
+			}
+			res.Files[f] = file
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// artifacts returns the absolute paths to the binary artifacts.
+func (t *Task) artifacts() ([]string, error) {
+	rootDir := t.proj.Options().RootDirPathAbs
+	if len(t.opts.Artifacts) > 0 {
+		paths := make([]string, 0, len(t.opts.Artifacts))
+		for _, a := range t.opts.Artifacts {
+			paths = append(paths, fsSupport.AbsPath(rootDir, a))
+		}
+		return paths, nil
+	}
+
+	dir := fsSupport.AbsPath(rootDir, t.opts.ArtifactDir)
+	entries, readErr := os.ReadDir(dir)
+	if readErr != nil {
+		return nil, fmt.Errorf("read artifact directory %q: %w", dir, readErr)
+	}
+
+	var paths []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() {
+			continue
+		}
+		if name != t.opts.BinaryArtifactName && !strings.HasPrefix(name, t.opts.BinaryArtifactName+"-") {
+			continue
+		}
+		// Skip other files like previously generated documents or checksum files.
+		if _, err := buildinfo.ReadFile(filepath.Join(dir, name)); err != nil {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf(`no binary artifacts found in %q, run the "go/build" or "gox" task first`, dir)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// goMod parses the configured "go.mod" file.
+// It defaults to the "go.mod" file of the module that contains the application directory and returns nil when there is
+// no such file up to the project root directory.
+func (t *Task) goMod() (*modfile.File, error) {
+	rootDir := t.proj.Options().RootDirPathAbs
+	path := fsSupport.AbsPath(rootDir, t.opts.GoModFile)
+	if t.opts.GoModFile == "" {
+		var findErr error
+		if path, findErr = findGoModFile(rootDir, t.ac.PathRel); findErr != nil || path == "" {
+			return nil, findErr
+		}
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("read %q: %w", path, readErr)
+	}
+	f, parseErr := modfile.ParseLax(path, data, nil)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, parseErr)
+	}
+	return f, nil
+}
+
+// findGoModFile returns the path to the "go.mod" file of the module that contains the given directory, relative to the
+// given root directory, by walking up to the root directory.
+// An empty path is returned when no directory up to the root directory contains a "go.mod" file.
+func findGoModFile(rootDir, dirRel string) (string, error) {
+	dir := filepath.Join(rootDir, dirRel)
+	for {
+		path := filepath.Join(dir, project.GoModuleDefaultFileName)
+		exists, fsErr := glFS.RegularFileExists(path)
+		if fsErr != nil {
+			return "", fmt.Errorf("check %q: %w", path, fsErr)
+		}
+		if exists {
+			return path, nil
+		}
+
+		rel, relErr := filepath.Rel(rootDir, dir)
+		if relErr != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// New creates a new task.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(proj project.Metadata, ac app.Config, opts ...Option) *Task {
+	opt := NewOptions(opts...)
+
+	if opt.ArtifactDir == "" {
+		opt.ArtifactDir = ac.BaseOutputDir
+	}
+
+	if opt.BinaryArtifactName == "" {
+		opt.BinaryArtifactName = ac.Name
+	}
+
+	return &Task{ac: ac, opts: opt, proj: proj}
+}
+
+// writeDocument encodes the given document as indented JSON and writes it to the given file.
+func writeDocument(file string, doc interface{}) error {
+	data, marshalErr := json.MarshalIndent(doc, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("encode document: %w", marshalErr)
+	}
+	//nolint:gosec // Software bills of materials are meant to be world-readable.
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindGoModFile(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"cmd/app", "tools/lint/cmd"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o700))
+	}
+	for _, dir := range []string{".", "tools/lint"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "go.mod"), []byte("module example.com/m\n"), 0o600))
+	}
+	noMod := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(noMod, "cmd"), 0o700))
+
+	tests := []struct {
+		name    string
+		rootDir string
+		dirRel  string
+		want    string
+	}{
+		{name: "project root", rootDir: root, dirRel: ".", want: filepath.Join(root, "go.mod")},
+		{name: "package of root module", rootDir: root, dirRel: "cmd/app", want: filepath.Join(root, "go.mod")},
+		{name: "nested module", rootDir: root, dirRel: "tools/lint", want: filepath.Join(root, "tools/lint/go.mod")},
+		{
+			name:    "package of nested module",
+			rootDir: root,
+			dirRel:  "tools/lint/cmd",
+			want:    filepath.Join(root, "tools/lint/go.mod"),
+		},
+		{name: "without module", rootDir: noMod, dirRel: "cmd"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := findGoModFile(tc.rootDir, tc.dirRel)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, path)
+		})
+	}
+}
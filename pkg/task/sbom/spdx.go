@@ -0,0 +1,200 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// spdxNoAssertion is the value for unknown SPDX fields.
+	spdxNoAssertion = "NOASSERTION"
+
+	// spdxVersion is the version of the SPDX specification documents are generated for.
+	spdxVersion = "SPDX-2.3"
+)
+
+// spdxIDInvalidChars matches all characters that are not allowed in SPDX identifiers.
+var spdxIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// SPDXChecksum is a checksum of a SPDX package.
+type SPDXChecksum struct {
+	// Algorithm is the checksum algorithm, e.g. "SHA256".
+	Algorithm string `json:"algorithm"`
+
+	// Value is the hex encoded checksum.
+	Value string `json:"checksumValue"`
+}
+
+// SPDXCreationInfo is the creation information of a SPDX document.
+type SPDXCreationInfo struct {
+	// Created is the date and time the document has been created.
+	Created string `json:"created"`
+
+	// Creators are the creators of the document.
+	Creators []string `json:"creators"`
+}
+
+// SPDXDocument is a SPDX JSON document.
+//
+// See https://spdx.github.io/spdx-spec/v2.3 for more details.
+type SPDXDocument struct {
+	// CreationInfo is the creation information of the document.
+	CreationInfo SPDXCreationInfo `json:"creationInfo"`
+
+	// DataLicense is the license of the document which must be "CC0-1.0".
+	DataLicense string `json:"dataLicense"`
+
+	// DocumentNamespace is the unique URI of the document.
+	DocumentNamespace string `json:"documentNamespace"`
+
+	// Name is the name of the document.
+	Name string `json:"name"`
+
+	// Packages are the packages of the artifact.
+	Packages []SPDXPackage `json:"packages"`
+
+	// Relationships are the relationships between the document and all packages.
+	Relationships []SPDXRelationship `json:"relationships"`
+
+	// SPDXID is the identifier of the document.
+	SPDXID string `json:"SPDXID"`
+
+	// SPDXVersion is the version of the SPDX specification.
+	SPDXVersion string `json:"spdxVersion"`
+}
+
+// SPDXExternalRef is an external reference of a SPDX package.
+type SPDXExternalRef struct {
+	// Category is the category of the reference, e.g. "PACKAGE-MANAGER".
+	Category string `json:"referenceCategory"`
+
+	// Locator is the reference locator, e.g. a package URL.
+	Locator string `json:"referenceLocator"`
+
+	// Type is the type of the reference, e.g. "purl".
+	Type string `json:"referenceType"`
+}
+
+// SPDXPackage is a package of a SPDX document.
+type SPDXPackage struct {
+	// Checksums are the checksums of the package.
+	Checksums []SPDXChecksum `json:"checksums,omitempty"`
+
+	// Comment is an additional comment about the package.
+	Comment string `json:"comment,omitempty"`
+
+	// CopyrightText is the copyright text of the package.
+	CopyrightText string `json:"copyrightText"`
+
+	// DownloadLocation is the download location of the package.
+	DownloadLocation string `json:"downloadLocation"`
+
+	// ExternalRefs are external references of the package.
+	ExternalRefs []SPDXExternalRef `json:"externalRefs,omitempty"`
+
+	// FilesAnalyzed indicates whether the files of the package have been analyzed.
+	FilesAnalyzed bool `json:"filesAnalyzed"`
+
+	// LicenseConcluded is the concluded license of the package.
+	LicenseConcluded string `json:"licenseConcluded"`
+
+	// LicenseDeclared is the declared license of the package.
+	LicenseDeclared string `json:"licenseDeclared"`
+
+	// Name is the name of the package.
+	Name string `json:"name"`
+
+	// PrimaryPackagePurpose is the primary purpose of the package, e.g. "APPLICATION" or "LIBRARY".
+	PrimaryPackagePurpose string `json:"primaryPackagePurpose,omitempty"`
+
+	// SPDXID is the identifier of the package.
+	SPDXID string `json:"SPDXID"`
+
+	// VersionInfo is the version of the package.
+	VersionInfo string `json:"versionInfo,omitempty"`
+}
+
+// SPDXRelationship is a relationship between SPDX elements.
+type SPDXRelationship struct {
+	// Element is the identifier of the element the relationship starts from.
+	Element string `json:"spdxElementId"`
+
+	// Related is the identifier of the related element.
+	Related string `json:"relatedSpdxElement"`
+
+	// Type is the type of the relationship, e.g. "DESCRIBES" or "DEPENDS_ON".
+	Type string `json:"relationshipType"`
+}
+
+// NewSPDXDocument creates a new SPDX document for the given artifact created at the given time.
+func NewSPDXDocument(a *Artifact, created time.Time) *SPDXDocument {
+	main := spdxPackage(a.Main, "APPLICATION")
+	main.Name = a.Name
+	main.Comment = fmt.Sprintf("Go module %s built with %s", a.Main.Path, a.GoVersion)
+	if platform := a.Platform(); platform != "" {
+		main.Comment += " for " + platform
+	}
+
+	doc := &SPDXDocument{
+		CreationInfo: SPDXCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: wand"},
+		},
+		DataLicense:       "CC0-1.0",
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s", a.Name, documentUUID(a, created)),
+		Name:              a.Name,
+		Packages:          []SPDXPackage{main},
+		Relationships:     []SPDXRelationship{{Element: "SPDXRef-DOCUMENT", Related: main.SPDXID, Type: "DESCRIBES"}},
+		SPDXID:            "SPDXRef-DOCUMENT",
+		SPDXVersion:       spdxVersion,
+	}
+
+	for _, m := range a.Deps {
+		p := spdxPackage(m, "LIBRARY")
+		var comments []string
+		switch {
+		case m.Replace != "" && !m.Direct:
+			comments = append(comments, fmt.Sprintf("indirect dependency replaced by %s", m.Replace))
+		case m.Replace != "":
+			comments = append(comments, fmt.Sprintf("replaced by %s", m.Replace))
+		case !m.Direct:
+			comments = append(comments, "indirect dependency")
+		}
+		// The "h1:" checksum is a hash of the module file tree and can not be represented as package checksum.
+		if m.Sum != "" {
+			comments = append(comments, fmt.Sprintf("go.sum checksum %s", m.Sum))
+		}
+		p.Comment = strings.Join(comments, ", ")
+		doc.Packages = append(doc.Packages, p)
+		doc.Relationships = append(doc.Relationships, SPDXRelationship{
+			Element: main.SPDXID,
+			Related: p.SPDXID,
+			Type:    "DEPENDS_ON",
+		})
+	}
+	return doc
+}
+
+// spdxPackage returns the SPDX package of the given module with the given primary purpose.
+func spdxPackage(m Module, purpose string) SPDXPackage {
+	p := SPDXPackage{
+		CopyrightText:         spdxNoAssertion,
+		DownloadLocation:      spdxNoAssertion,
+		ExternalRefs:          []SPDXExternalRef{{Category: "PACKAGE-MANAGER", Locator: m.PURL(), Type: "purl"}},
+		LicenseConcluded:      spdxNoAssertion,
+		LicenseDeclared:       spdxNoAssertion,
+		Name:                  m.Path,
+		PrimaryPackagePurpose: purpose,
+		SPDXID:                "SPDXRef-Package-" + spdxIDInvalidChars.ReplaceAllString(m.Path+"-"+m.Version, "-"),
+		VersionInfo:           m.Version,
+	}
+	if m.SHA256 != "" {
+		p.Checksums = []SPDXChecksum{{Algorithm: "SHA256", Value: m.SHA256}}
+	}
+	return p
+}
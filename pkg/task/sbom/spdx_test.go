@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSPDXDocument(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	pkg := func(path, version, purpose, comment string) SPDXPackage {
+		return SPDXPackage{
+			Comment:          comment,
+			CopyrightText:    spdxNoAssertion,
+			DownloadLocation: spdxNoAssertion,
+			ExternalRefs: []SPDXExternalRef{
+				{Category: "PACKAGE-MANAGER", Locator: (Module{Path: path, Version: version}).PURL(), Type: "purl"},
+			},
+			LicenseConcluded:      spdxNoAssertion,
+			LicenseDeclared:       spdxNoAssertion,
+			Name:                  path,
+			PrimaryPackagePurpose: purpose,
+			VersionInfo:           version,
+		}
+	}
+	main := pkg("example.com/app", "v1.0.0", "APPLICATION", "Go module example.com/app built with go1.21.0")
+	main.Checksums = []SPDXChecksum{{Algorithm: "SHA256", Value: "abc123"}}
+	main.Name = "app-linux-amd64"
+	main.SPDXID = "SPDXRef-Package-example.com-app-v1.0.0"
+
+	tests := []struct {
+		name     string
+		deps     []Module
+		platform bool
+		packages []SPDXPackage
+	}{
+		{name: "without dependencies and platform"},
+		{
+			name:     "direct indirect and replaced dependencies",
+			deps:     testArtifact().Deps,
+			platform: true,
+			packages: []SPDXPackage{
+				pkg(stdlibPath, "go1.21.0", "LIBRARY", ""),
+				pkg("example.com/direct", "v1.2.0", "LIBRARY", "go.sum checksum h1:direct="),
+				pkg("example.com/indirect", "v0.3.0", "LIBRARY", "indirect dependency"),
+				pkg("example.com/replaced", "v1.0.1", "LIBRARY", "replaced by example.com/fork"),
+			},
+		},
+		{
+			name: "indirect replaced dependency",
+			deps: []Module{{Path: "example.com/replaced", Replace: "../fork", Sum: "h1:fork=", Version: "v1.0.1"}},
+			packages: []SPDXPackage{
+				pkg(
+					"example.com/replaced", "v1.0.1", "LIBRARY",
+					"indirect dependency replaced by ../fork, go.sum checksum h1:fork=",
+				),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := testArtifact()
+			a.Deps = tc.deps
+			wantMain := main
+			if tc.platform {
+				wantMain.Comment += " for linux/amd64"
+			} else {
+				a.Settings = nil
+			}
+
+			want := &SPDXDocument{
+				CreationInfo:      SPDXCreationInfo{Created: "2023-06-01T10:00:00Z", Creators: []string{"Tool: wand"}},
+				DataLicense:       "CC0-1.0",
+				DocumentNamespace: "https://spdx.org/spdxdocs/app-linux-amd64-" + documentUUID(a, created),
+				Name:              "app-linux-amd64",
+				Packages:          []SPDXPackage{wantMain},
+				Relationships:     []SPDXRelationship{{Element: "SPDXRef-DOCUMENT", Related: main.SPDXID, Type: "DESCRIBES"}},
+				SPDXID:            "SPDXRef-DOCUMENT",
+				SPDXVersion:       spdxVersion,
+			}
+			for _, p := range tc.packages {
+				p.SPDXID = "SPDXRef-Package-" + spdxIDInvalidChars.ReplaceAllString(p.Name+"-"+p.VersionInfo, "-")
+				want.Packages = append(want.Packages, p)
+				want.Relationships = append(want.Relationships, SPDXRelationship{
+					Element: main.SPDXID,
+					Related: p.SPDXID,
+					Type:    "DEPENDS_ON",
+				})
+			}
+			require.Equal(t, want, NewSPDXDocument(a, created))
+		})
+	}
+}
+
+func TestSPDXPackageID(t *testing.T) {
+	tests := []struct {
+		module Module
+		want   string
+	}{
+		{module: Module{Path: "example.com/app", Version: "v1.0.0"}, want: "SPDXRef-Package-example.com-app-v1.0.0"},
+		{
+			module: Module{Path: "example.com/mod", Version: "v2.0.0+incompatible"},
+			want:   "SPDXRef-Package-example.com-mod-v2.0.0-incompatible",
+		},
+		{module: Module{Path: "example.com/my_mod"}, want: "SPDXRef-Package-example.com-my-mod-"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.module.Path, func(t *testing.T) {
+			require.Equal(t, tc.want, spdxPackage(tc.module, "LIBRARY").SPDXID)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package size
+
+import (
+	"debug/elf"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OtherPackageName is the name used for symbols that do not belong to a Go package, like linker generated symbols or
+// symbols of C code linked through cgo.
+const OtherPackageName = "<other>"
+
+// Analysis is the size analysis of a binary artifact.
+type Analysis struct {
+	// Packages are the sizes of all symbols grouped by the package they belong to, sorted by size in descending order.
+	// It is empty when the binary artifact has no symbol table.
+	Packages []Entry `json:"packages,omitempty"`
+
+	// Sections are the sizes of all sections with data in the file, sorted by size in descending order.
+	// Sections without data in the file, like ".bss", are omitted.
+	Sections []Entry `json:"sections"`
+
+	// Size is the total size of the binary artifact in bytes.
+	Size int64 `json:"size"`
+
+	// Stripped indicates whether the binary artifact has no symbol table, e.g. when it has been built with the
+	// "-s" linker flag through the "github.com/svengreb/wand/pkg/task/golang.MixinStripDebugMetadata" mixin.
+	Stripped bool `json:"stripped"`
+}
+
+// Comparison is the comparison of a size analysis with a baseline analysis.
+type Comparison struct {
+	// Packages are the packages whose size changed, sorted by the absolute change in descending order.
+	Packages []Delta
+
+	// Sections are the sections whose size changed, sorted by the absolute change in descending order.
+	Sections []Delta
+
+	// Size is the change of the total size.
+	Size Delta
+}
+
+// Delta is the size change of a binary artifact, section or package.
+type Delta struct {
+	// Base is the size in bytes of the baseline analysis.
+	Base int64
+
+	// Current is the size in bytes of the current analysis.
+	Current int64
+
+	// Name is the name of the section or package. It is empty for the total size of the binary artifact.
+	Name string
+}
+
+// Entry is the size of a section or package.
+type Entry struct {
+	// Name is the name of the section or package.
+	Name string `json:"name"`
+
+	// Size is the size in bytes.
+	Size int64 `json:"size"`
+}
+
+// Change returns the size change in bytes.
+func (d Delta) Change() int64 {
+	return d.Current - d.Base
+}
+
+// String returns a human-readable representation of the size change.
+func (d Delta) String() string {
+	name := d.Name
+	if name == "" {
+		name = "total"
+	}
+	s := fmt.Sprintf("%s: %d -> %d bytes (%+d bytes", name, d.Base, d.Current, d.Change())
+	if d.Base > 0 {
+		s += fmt.Sprintf(", %+.2f%%", float64(d.Change())/float64(d.Base)*100)
+	}
+	return s + ")"
+}
+
+// String returns a human-readable representation of the comparison.
+func (c *Comparison) String() string {
+	var sb strings.Builder
+	sb.WriteString(c.Size.String())
+	for _, group := range []struct {
+		deltas []Delta
+		title  string
+	}{{c.Sections, "Sections"}, {c.Packages, "Packages"}} {
+		if len(group.deltas) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n%s:", group.title)
+		for _, d := range group.deltas {
+			fmt.Fprintf(&sb, "\n  %s", d)
+		}
+	}
+	return sb.String()
+}
+
+// Analyze analyzes the size of the ELF binary artifact at the given path.
+// The size of packages is the sum of the sizes of all symbols with data in the file, comparable to the output of
+// `go tool nm -size -sort size`.
+//
+// See `go doc cmd/nm` for more details.
+func Analyze(path string) (*Analysis, error) {
+	fi, statErr := os.Stat(path)
+	if statErr != nil {
+		return nil, fmt.Errorf("read file information of %q: %w", path, statErr)
+	}
+
+	f, openErr := elf.Open(path)
+	if openErr != nil {
+		return nil, fmt.Errorf("open ELF file %q: %w", path, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	a := &Analysis{Size: fi.Size()}
+	for _, s := range f.Sections {
+		if s.Type == elf.SHT_NULL || s.Type == elf.SHT_NOBITS {
+			continue
+		}
+		a.Sections = append(a.Sections, Entry{Name: s.Name, Size: int64(s.FileSize)})
+	}
+	sortEntries(a.Sections)
+
+	symbols, symErr := f.Symbols()
+	if symErr != nil {
+		if errors.Is(symErr, elf.ErrNoSymbols) {
+			a.Stripped = true
+			return a, nil
+		}
+		return nil, fmt.Errorf("read symbol table of %q: %w", path, symErr)
+	}
+
+	packages := make(map[string]int64)
+	for _, sym := range symbols {
+		if sym.Size == 0 || sym.Section == elf.SHN_UNDEF || int(sym.Section) >= len(f.Sections) {
+			continue
+		}
+		if f.Sections[sym.Section].Type == elf.SHT_NOBITS {
+			continue
+		}
+		packages[packageOf(sym.Name)] += int64(sym.Size)
+	}
+	for name, size := range packages {
+		a.Packages = append(a.Packages, Entry{Name: name, Size: size})
+	}
+	sortEntries(a.Packages)
+
+	return a, nil
+}
+
+// Compare compares the given analysis with the given baseline analysis.
+func Compare(base, current *Analysis) *Comparison {
+	return &Comparison{
+		Packages: compareEntries(base.Packages, current.Packages),
+		Sections: compareEntries(base.Sections, current.Sections),
+		Size:     Delta{Base: base.Size, Current: current.Size},
+	}
+}
+
+// LoadAnalysis loads a stored analysis from the given JSON file.
+func LoadAnalysis(file string) (*Analysis, error) {
+	data, readErr := os.ReadFile(file)
+	if readErr != nil {
+		return nil, fmt.Errorf("read %q: %w", file, readErr)
+	}
+	a := &Analysis{}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("decode %q: %w", file, err)
+	}
+	return a, nil
+}
+
+// Save stores the analysis as JSON file and creates all parent directories.
+func (a *Analysis) Save(file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return fmt.Errorf("create directory %q: %w", filepath.Dir(file), err)
+	}
+	data, marshalErr := json.MarshalIndent(a, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("encode analysis: %w", marshalErr)
+	}
+	//nolint:gosec // Baselines are meant to be committed and shared.
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", file, err)
+	}
+	return nil
+}
+
+// compareEntries returns the deltas of all entries whose size changed, sorted by the absolute change in descending
+// order.
+func compareEntries(base, current []Entry) []Delta {
+	deltas := make(map[string]*Delta)
+	for _, e := range base {
+		deltas[e.Name] = &Delta{Base: e.Size, Name: e.Name}
+	}
+	for _, e := range current {
+		if d, ok := deltas[e.Name]; ok {
+			d.Current = e.Size
+			continue
+		}
+		deltas[e.Name] = &Delta{Current: e.Size, Name: e.Name}
+	}
+
+	var changed []Delta
+	for _, d := range deltas {
+		if d.Change() != 0 {
+			changed = append(changed, *d)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		ci, cj := abs(changed[i].Change()), abs(changed[j].Change())
+		if ci != cj {
+			return ci > cj
+		}
+		return changed[i].Name < changed[j].Name
+	})
+	return changed
+}
+
+// packageOf returns the import path of the package the symbol with the given name belongs to.
+// Type descriptors, like "type:*net/http.Client", are attributed to the package that declares the type while
+// OtherPackageName is returned for symbols that do not belong to a Go package.
+// The linker escapes dots and other special characters in the last element of import paths, like "gopkg.in/yaml%2ev3",
+// so the returned import path is unescaped.
+func packageOf(symbol string) string {
+	name := symbol
+	for _, prefix := range []string{"type:", "type.", "go:itab.", "go.itab."} {
+		if strings.HasPrefix(name, prefix) {
+			name = strings.TrimLeft(strings.TrimPrefix(name, prefix), "*[]")
+			break
+		}
+	}
+	// Ignore type arguments of generic functions and types that can contain arbitrary package paths.
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+
+	// Ignore linker generated symbols, like "go:buildid" or "go:func.*".
+	if strings.HasPrefix(name, "go:") || strings.HasPrefix(name, "go.") {
+		return OtherPackageName
+	}
+
+	pathEnd := strings.LastIndexByte(name, '/') + 1
+	dot := strings.IndexByte(name[pathEnd:], '.')
+	if dot <= 0 {
+		return OtherPackageName
+	}
+	pkg := name[:pathEnd+dot]
+	if unescaped, err := url.PathUnescape(pkg); err == nil {
+		return unescaped
+	}
+	return pkg
+}
+
+// sortEntries sorts the given entries by size in descending order and by name for equal sizes.
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// abs returns the absolute value of the given number.
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package size
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    *Analysis
+		current *Analysis
+		want    *Comparison
+	}{
+		{
+			name:    "unchanged",
+			base:    &Analysis{Sections: []Entry{{Name: ".text", Size: 100}}, Size: 200},
+			current: &Analysis{Sections: []Entry{{Name: ".text", Size: 100}}, Size: 200},
+			want:    &Comparison{Size: Delta{Base: 200, Current: 200}},
+		},
+		{
+			name: "changed sections sorted by absolute change",
+			base: &Analysis{
+				Sections: []Entry{{Name: ".text", Size: 100}, {Name: ".rodata", Size: 50}, {Name: ".data", Size: 10}},
+				Size:     300,
+			},
+			current: &Analysis{
+				Sections: []Entry{{Name: ".text", Size: 110}, {Name: ".rodata", Size: 20}, {Name: ".data", Size: 10}},
+				Size:     280,
+			},
+			want: &Comparison{
+				Sections: []Delta{{Base: 50, Current: 20, Name: ".rodata"}, {Base: 100, Current: 110, Name: ".text"}},
+				Size:     Delta{Base: 300, Current: 280},
+			},
+		},
+		{
+			name: "added and removed packages",
+			base: &Analysis{Packages: []Entry{{Name: "fmt", Size: 40}, {Name: "os", Size: 30}}, Size: 100},
+			current: &Analysis{
+				Packages: []Entry{{Name: "fmt", Size: 40}, {Name: "net/http", Size: 30}},
+				Size:     100,
+			},
+			want: &Comparison{
+				Packages: []Delta{{Current: 30, Name: "net/http"}, {Base: 30, Name: "os"}},
+				Size:     Delta{Base: 100, Current: 100},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Compare(tc.base, tc.current))
+		})
+	}
+}
+
+func TestDeltaString(t *testing.T) {
+	tests := []struct {
+		name  string
+		delta Delta
+		want  string
+	}{
+		{name: "total growth", delta: Delta{Base: 200, Current: 250}, want: "total: 200 -> 250 bytes (+50 bytes, +25.00%)"},
+		{
+			name:  "section shrink",
+			delta: Delta{Base: 100, Current: 75, Name: ".text"},
+			want:  ".text: 100 -> 75 bytes (-25 bytes, -25.00%)",
+		},
+		{name: "new package", delta: Delta{Current: 10, Name: "os"}, want: "os: 0 -> 10 bytes (+10 bytes)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.delta.String())
+		})
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{symbol: "main.main", want: "main"},
+		{symbol: "net/http.(*Client).Do", want: "net/http"},
+		{symbol: "type:*net/http.Client", want: "net/http"},
+		{symbol: "go:itab.*os.File,io.Reader", want: "os"},
+		{symbol: "slices.Sort[go.shape.int]", want: "slices"},
+		{symbol: "github.com/svengreb/wand/pkg/task.(*ErrTask).Error", want: "github.com/svengreb/wand/pkg/task"},
+		{symbol: "gopkg.in/yaml%2ev3.Unmarshal", want: "gopkg.in/yaml.v3"},
+		{symbol: "type:*gopkg.in/yaml%2ev3.Node", want: "gopkg.in/yaml.v3"},
+		{symbol: "go:buildid", want: OtherPackageName},
+		{symbol: "runtime", want: OtherPackageName},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.symbol, func(t *testing.T) {
+			require.Equal(t, tc.want, packageOf(tc.symbol))
+		})
+	}
+}
+
+func TestAnalyzeNotELF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.WriteFile(path, []byte("MZ not a ELF file"), 0o600))
+
+	_, err := Analyze(path)
+	var formatErr *elf.FormatError
+	require.ErrorAs(t, err, &formatErr)
+}
+
+func TestAnalyzeMissing(t *testing.T) {
+	_, err := Analyze(filepath.Join(t.TempDir(), "app"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAnalysisSaveLoad(t *testing.T) {
+	a := &Analysis{
+		Packages: []Entry{{Name: "main", Size: 10}},
+		Sections: []Entry{{Name: ".text", Size: 100}},
+		Size:     200,
+	}
+	file := filepath.Join(t.TempDir(), "size", "app", DefaultBaselineFileName)
+	require.NoError(t, a.Save(file))
+
+	loaded, err := LoadAnalysis(file)
+	require.NoError(t, err)
+	require.Equal(t, a, loaded)
+}
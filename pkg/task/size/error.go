@@ -0,0 +1,11 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package size
+
+import (
+	wErr "github.com/svengreb/wand/pkg/error"
+)
+
+// ErrBudgetExceeded indicates that the size of a binary artifact exceeds the configured budget.
+const ErrBudgetExceeded = wErr.ErrString("size budget exceeded")
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+package size
+
+const (
+	// DefaultBaselineFileName is the default file name for the stored baseline analysis.
+	DefaultBaselineFileName = "baseline.json"
+
+	// DefaultOutputDirName is the default name of the directory, within the wand data directory, for the stored baseline
+	// analyses of all applications.
+	DefaultOutputDirName = "size"
+
+	// taskName is the name of the task.
+	taskName = "size"
+)
+
+// Option is a task option.
+type Option func(*Options)
+
+// Options are task options.
+type Options struct {
+	// ArtifactDir is the directory, relative to the project root, of the binary artifact to analyze.
+	// It defaults to the application output directory which is also used by the Go toolchain "build" task.
+	ArtifactDir string
+
+	// BaselineFile is the path, relative to the project root, to the stored baseline analysis the analysis is compared
+	// with.
+	BaselineFile string
+
+	// BinaryArtifactName is the name of the binary artifact to analyze.
+	BinaryArtifactName string
+
+	// Budget is the maximum size of the binary artifact in bytes.
+	// No budget is enforced when zero.
+	Budget int64
+
+	// EnableBaselineUpdate indicates whether the analysis should be stored as new baseline.
+	// Note that the analysis is always stored as baseline when no baseline exists yet.
+	EnableBaselineUpdate bool
+
+	// name is the task name.
+	name string
+}
+
+// NewOptions creates new task options.
+func NewOptions(opts ...Option) *Options {
+	opt := &Options{
+		name: taskName,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return opt
+}
+
+// WithArtifactDir sets the directory, relative to the project root, of the binary artifact to analyze.
+func WithArtifactDir(dir string) Option {
+	return func(o *Options) {
+		o.ArtifactDir = dir
+	}
+}
+
+// WithBaselineFile sets the path, relative to the project root, to the stored baseline analysis.
+// Defaults to DefaultBaselineFileName within the application specific directory of the DefaultOutputDirName directory
+// in the wand data directory that, unlike the application output directory, is not removed by the "clean" task.
+// Use a path within the project to commit the baseline and share it with other developers and CI systems.
+func WithBaselineFile(file string) Option {
+	return func(o *Options) {
+		o.BaselineFile = file
+	}
+}
+
+// WithBaselineUpdate indicates whether the analysis should be stored as new baseline.
+func WithBaselineUpdate(enableBaselineUpdate bool) Option {
+	return func(o *Options) {
+		o.EnableBaselineUpdate = enableBaselineUpdate
+	}
+}
+
+// WithBinaryArtifactName sets the name of the binary artifact to analyze.
+func WithBinaryArtifactName(name string) Option {
+	return func(o *Options) {
+		o.BinaryArtifactName = name
+	}
+}
+
+// WithBudget sets the maximum size of the binary artifact in bytes.
+// Values less than or equal to zero disable the budget.
+func WithBudget(bytes int64) Option {
+	return func(o *Options) {
+		if bytes < 0 {
+			bytes = 0
+		}
+		o.Budget = bytes
+	}
+}
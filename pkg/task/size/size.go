@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Sven Greb <development@svengreb.de>
+// This source code is licensed under the MIT license found in the license file.
+
+// Package size provides a task to analyze the size of binary artifacts of applications.
+// The size of ELF binary artifacts is broken down by section and, using the symbol table, by package. The analysis is
+// compared with a stored baseline to track size changes over time while a per-application budget can be enforced to
+// prevent unintended growth.
+//
+// See https://pkg.go.dev/debug/elf and `go doc cmd/nm` for more details.
+package size
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	fsSupport "github.com/svengreb/wand/internal/support/fs"
+	"github.com/svengreb/wand/pkg/app"
+	"github.com/svengreb/wand/pkg/project"
+	"github.com/svengreb/wand/pkg/task"
+)
+
+// Report is the report of a size analysis.
+type Report struct {
+	// Analysis is the size analysis of the binary artifact.
+	Analysis *Analysis
+
+	// Artifact is the absolute path to the analyzed binary artifact.
+	Artifact string
+
+	// BaselineFile is the absolute path to the stored baseline analysis.
+	BaselineFile string
+
+	// BaselineUpdated indicates whether the analysis has been stored as new baseline.
+	BaselineUpdated bool
+
+	// Comparison is the comparison of the analysis with the stored baseline analysis.
+	// It is nil when no baseline existed yet.
+	Comparison *Comparison
+}
+
+// Task is a task to analyze the size of binary artifacts of applications.
+type Task struct {
+	ac   app.Config
+	opts *Options
+	proj project.Metadata
+}
+
+// Analyze analyzes the size of the binary artifact and compares it with the stored baseline analysis.
+// It returns an error of type *task.ErrTask for invalid options, like a missing binary artifact, and an error of type
+// *task.ErrRunner when the baseline could not be stored. An error of kind ErrBudgetExceeded is returned along with the
+// report when the size of the binary artifact exceeds the configured budget.
+func (t *Task) Analyze() (*Report, error) {
+	rootDir := t.proj.Options().RootDirPathAbs
+	report := &Report{
+		Artifact:     fsSupport.AbsPath(rootDir, filepath.Join(t.opts.ArtifactDir, t.opts.BinaryArtifactName)),
+		BaselineFile: fsSupport.AbsPath(rootDir, t.opts.BaselineFile),
+	}
+
+	a, analyzeErr := Analyze(report.Artifact)
+	if analyzeErr != nil {
+		var formatErr *elf.FormatError
+		switch {
+		case errors.Is(analyzeErr, os.ErrNotExist):
+			analyzeErr = fmt.Errorf(`analyze binary artifact, run the "go/build" task first: %w`, analyzeErr)
+		case errors.As(analyzeErr, &formatErr):
+			analyzeErr = fmt.Errorf("analyze binary artifact, only ELF binary artifacts are supported: %w", analyzeErr)
+		default:
+			analyzeErr = fmt.Errorf("analyze binary artifact: %w", analyzeErr)
+		}
+		return nil, &task.ErrTask{Err: analyzeErr, Kind: task.ErrInvalidTaskOpts}
+	}
+	report.Analysis = a
+
+	base, loadErr := LoadAnalysis(report.BaselineFile)
+	switch {
+	case loadErr == nil:
+		report.Comparison = Compare(base, a)
+	case !errors.Is(loadErr, os.ErrNotExist):
+		return nil, &task.ErrTask{Err: fmt.Errorf("load baseline: %w", loadErr), Kind: task.ErrInvalidTaskOpts}
+	}
+
+	if t.opts.EnableBaselineUpdate || report.Comparison == nil {
+		if err := a.Save(report.BaselineFile); err != nil {
+			return report, &task.ErrRunner{Err: fmt.Errorf("store baseline: %w", err), Kind: task.ErrRun}
+		}
+		report.BaselineUpdated = true
+	}
+
+	if t.opts.Budget > 0 && a.Size > t.opts.Budget {
+		return report, &task.ErrTask{
+			Err: fmt.Errorf(
+				"size of binary artifact %q is %d bytes and exceeds the budget of %d bytes by %d bytes",
+				report.Artifact, a.Size, t.opts.Budget, a.Size-t.opts.Budget,
+			),
+			Kind: ErrBudgetExceeded,
+		}
+	}
+	return report, nil
+}
+
+// Name returns the task name.
+func (t *Task) Name() string {
+	return t.opts.name
+}
+
+// Options returns the task options.
+func (t *Task) Options() task.Options {
+	return *t.opts
+}
+
+// New creates a new task.
+//nolint:gocritic // The app.Config struct is passed as value by design to ensure immutability.
+func New(proj project.Metadata, ac app.Config, opts ...Option) *Task {
+	opt := NewOptions(opts...)
+
+	if opt.ArtifactDir == "" {
+		opt.ArtifactDir = ac.BaseOutputDir
+	}
+
+	if opt.BaselineFile == "" {
+		opt.BaselineFile = filepath.Join(proj.Options().WandDataDir, DefaultOutputDirName, ac.Name, DefaultBaselineFileName)
+	}
+
+	if opt.BinaryArtifactName == "" {
+		opt.BinaryArtifactName = ac.Name
+	}
+
+	return &Task{ac: ac, opts: opt, proj: proj}
+}
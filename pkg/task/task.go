@@ -49,3 +49,11 @@ type Task interface {
 	// Options returns the task options.
 	Options() Options
 }
+
+// WorkingDir is a task that must run in a specific working directory.
+// Runners fall back to the working directory of the current process when a task does not implement this interface or
+// when the returned path is empty.
+type WorkingDir interface {
+	// WorkingDir returns the path to the working directory.
+	WorkingDir() string
+}